@@ -3,6 +3,9 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 
@@ -10,8 +13,12 @@ import (
 
 	"github.com/monitoror/monitoror/api/config"
 	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/service/middlewares"
 )
 
+// maxConfigSize limit size of config sent to SaveConfig
+const maxConfigSize = 1 << 20 // 1 MiB
+
 type ConfigDelivery struct {
 	configUsecase config.Usecase
 }
@@ -49,6 +56,131 @@ func (h *ConfigDelivery) GetConfig(c echo.Context) error {
 	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, encoded)
 }
 
+// GetConfigHash return hash of named config content, polled by UI to reload config when it change.
+// Hash is also sent as ETag, 304 is returned when it didn't change
+func (h *ConfigDelivery) GetConfigHash(c echo.Context) error {
+	params := &models.ConfigParams{Config: c.Param("config")}
+
+	configHash, configError := h.configUsecase.GetConfigHash(params)
+	if configError != nil {
+		return c.JSON(configErrorStatus(configError.ID), models.ConfigBag{Errors: []models.ConfigError{*configError}})
+	}
+
+	etag := fmt.Sprintf("%q", configHash.Hash)
+	c.Response().Header().Set("ETag", etag)
+	c.Response().Header().Set("Cache-Control", "no-cache")
+	if c.Request().Header.Get("If-None-Match") == etag {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.JSON(http.StatusOK, configHash)
+}
+
+// GetSchema return JSON Schema of config, with params of every enabled tile type
+func (h *ConfigDelivery) GetSchema(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.configUsecase.GetSchema())
+}
+
+// SaveConfig verify config sent in body (JSON or YAML, like named config file) and save it as new revision
+func (h *ConfigDelivery) SaveConfig(c echo.Context) error {
+	params := &models.ConfigParams{Config: c.Param("config")}
+
+	content, err := ioutil.ReadAll(io.LimitReader(c.Request().Body, maxConfigSize+1))
+	if err != nil {
+		return err
+	}
+	if len(content) > maxConfigSize {
+		return configTooLarge(c)
+	}
+
+	configBag, revision := h.configUsecase.SaveConfig(params, content, author(c))
+
+	return configWriteResponse(c, configBag, revision)
+}
+
+// ValidateConfig verify and hydrate config sent in body without saving it (dry-run), only errors are returned
+func (h *ConfigDelivery) ValidateConfig(c echo.Context) error {
+	content, err := ioutil.ReadAll(io.LimitReader(c.Request().Body, maxConfigSize+1))
+	if err != nil {
+		return err
+	}
+	if len(content) > maxConfigSize {
+		return configTooLarge(c)
+	}
+
+	configBag := h.configUsecase.ValidateConfig(content)
+
+	status := http.StatusOK
+	if len(configBag.Errors) > 0 {
+		status = http.StatusBadRequest
+	}
+
+	encoded, _ := JSONMarshal(models.ConfigBag{Errors: configBag.Errors})
+	return c.Blob(status, echo.MIMEApplicationJSONCharsetUTF8, encoded)
+}
+
+// GetConfigRevisions list saved revisions of named config
+func (h *ConfigDelivery) GetConfigRevisions(c echo.Context) error {
+	params := &models.ConfigParams{Config: c.Param("config")}
+
+	revisions, configError := h.configUsecase.GetConfigRevisions(params)
+	if configError != nil {
+		return c.JSON(configErrorStatus(configError.ID), models.ConfigBag{Errors: []models.ConfigError{*configError}})
+	}
+
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// RestoreConfigRevision save previous revision of named config as new revision
+func (h *ConfigDelivery) RestoreConfigRevision(c echo.Context) error {
+	params := &models.ConfigParams{Config: c.Param("config")}
+
+	configBag, revision := h.configUsecase.RestoreConfigRevision(params, c.Param("revision"), author(c))
+
+	return configWriteResponse(c, configBag, revision)
+}
+
+// configWriteResponse return saved revision, or ConfigBag with errors
+func configWriteResponse(c echo.Context, configBag *models.ConfigBag, revision *models.ConfigRevision) error {
+	if len(configBag.Errors) > 0 || revision == nil {
+		status := http.StatusBadRequest
+		if len(configBag.Errors) > 0 {
+			status = configErrorStatus(configBag.Errors[0].ID)
+		}
+
+		encoded, _ := JSONMarshal(models.ConfigBag{Errors: configBag.Errors})
+		return c.Blob(status, echo.MIMEApplicationJSONCharsetUTF8, encoded)
+	}
+
+	return c.JSON(http.StatusOK, revision)
+}
+
+func configTooLarge(c echo.Context) error {
+	return c.JSON(http.StatusRequestEntityTooLarge, models.ConfigBag{Errors: []models.ConfigError{{
+		ID:      models.ConfigErrorUnableToParseConfig,
+		Message: "Config is too large",
+	}}})
+}
+
+func configErrorStatus(id models.ConfigErrorID) int {
+	switch id {
+	case models.ConfigErrorUnknownNamedConfig, models.ConfigErrorRevisionNotFound:
+		return http.StatusNotFound
+	case models.ConfigErrorReadOnlyConfig:
+		return http.StatusConflict
+	case models.ConfigErrorUnexpectedError:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// author return authenticated user (set by AuthMiddleware)
+func author(c echo.Context) string {
+	user, _ := c.Get(middlewares.AuthUserContextKey).(string)
+	return user
+}
+
 // JSONMarshal same as JSON.Marshall but with SetEscapeHTML(false)
 func JSONMarshal(t interface{}) ([]byte, error) {
 	buffer := &bytes.Buffer{}
@@ -13,6 +13,8 @@ import (
 
 	"github.com/monitoror/monitoror/api/config/mocks"
 	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/internal/pkg/jsonschema"
+	"github.com/monitoror/monitoror/service/middlewares"
 )
 
 func initEcho() (ctx echo.Context, res *httptest.ResponseRecorder) {
@@ -50,6 +52,25 @@ func TestConfigDelivery_GetConfigList(t *testing.T) {
 	}
 }
 
+func TestConfigDelivery_GetSchema(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+
+	schema := &jsonschema.Schema{Schema: jsonschema.Draft07, Type: "object"}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetSchema").Return(schema)
+
+	handler := NewConfigDelivery(mockUsecase)
+	if assert.NoError(t, handler.GetSchema(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object"}`, res.Body.String())
+
+		mockUsecase.AssertNumberOfCalls(t, "GetSchema", 1)
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
 func TestDelivery_ConfigHandler_Success(t *testing.T) {
 	// Init
 	ctx, res := initEcho()
@@ -131,3 +152,182 @@ func TestDelivery_ConfigHandler_ErrorHydrate(t *testing.T) {
 		mockUsecase.AssertExpectations(t)
 	}
 }
+
+func initWriteEcho(method, body string) (ctx echo.Context, res *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/api/v1/configs/screen1", strings.NewReader(body))
+	res = httptest.NewRecorder()
+	ctx = e.NewContext(req, res)
+	ctx.SetParamNames("config", "revision")
+	ctx.SetParamValues("screen1", "1")
+	ctx.Set(middlewares.AuthUserContextKey, "alice")
+
+	return
+}
+
+func TestDelivery_SaveConfig_Success(t *testing.T) {
+	ctx, res := initWriteEcho(echo.PUT, `{"columns": 4}`)
+
+	revision := &models.ConfigRevision{ID: "1", Config: "screen1", Author: "alice"}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("SaveConfig", &models.ConfigParams{Config: "screen1"}, []byte(`{"columns": 4}`), "alice").
+		Return(&models.ConfigBag{}, revision)
+	handler := NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.SaveConfig(ctx)) {
+		expected, _ := json.Marshal(revision)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, string(expected), strings.TrimSpace(res.Body.String()))
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
+func TestDelivery_SaveConfig_Error(t *testing.T) {
+	for errorID, expectedCode := range map[models.ConfigErrorID]int{
+		models.ConfigErrorUnknownField:       http.StatusBadRequest,
+		models.ConfigErrorUnknownNamedConfig: http.StatusNotFound,
+		models.ConfigErrorReadOnlyConfig:     http.StatusConflict,
+		models.ConfigErrorUnexpectedError:    http.StatusInternalServerError,
+	} {
+		ctx, res := initWriteEcho(echo.POST, `{}`)
+
+		configBag := &models.ConfigBag{Config: &models.Config{}}
+		configBag.AddErrors(models.ConfigError{ID: errorID, Message: "boom"})
+
+		mockUsecase := new(mocks.Usecase)
+		mockUsecase.On("SaveConfig", Anything, Anything, Anything).Return(configBag, nil)
+		handler := NewConfigDelivery(mockUsecase)
+
+		if assert.NoError(t, handler.SaveConfig(ctx)) {
+			expected, _ := json.Marshal(models.ConfigBag{Errors: configBag.Errors})
+			assert.Equal(t, expectedCode, res.Code)
+			assert.Equal(t, string(expected), strings.TrimSpace(res.Body.String()))
+		}
+	}
+}
+
+func TestDelivery_SaveConfig_TooLarge(t *testing.T) {
+	ctx, res := initWriteEcho(echo.PUT, strings.Repeat(" ", maxConfigSize+1))
+
+	mockUsecase := new(mocks.Usecase)
+	handler := NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.SaveConfig(ctx)) {
+		assert.Equal(t, http.StatusRequestEntityTooLarge, res.Code)
+		mockUsecase.AssertNotCalled(t, "SaveConfig", Anything, Anything, Anything)
+	}
+}
+
+func TestDelivery_GetConfigRevisions(t *testing.T) {
+	ctx, res := initWriteEcho(echo.GET, "")
+
+	revisions := []models.ConfigRevision{{ID: "2"}, {ID: "1"}}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetConfigRevisions", &models.ConfigParams{Config: "screen1"}).Return(revisions, nil)
+	handler := NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.GetConfigRevisions(ctx)) {
+		expected, _ := json.Marshal(revisions)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, string(expected), strings.TrimSpace(res.Body.String()))
+	}
+
+	// Error
+	ctx, res = initWriteEcho(echo.GET, "")
+
+	mockUsecase = new(mocks.Usecase)
+	mockUsecase.On("GetConfigRevisions", Anything).Return(nil, &models.ConfigError{ID: models.ConfigErrorUnknownNamedConfig})
+	handler = NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.GetConfigRevisions(ctx)) {
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	}
+}
+
+func TestDelivery_RestoreConfigRevision(t *testing.T) {
+	ctx, res := initWriteEcho(echo.POST, "")
+
+	revision := &models.ConfigRevision{ID: "2", RestoredFrom: "1"}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("RestoreConfigRevision", &models.ConfigParams{Config: "screen1"}, "1", "alice").
+		Return(&models.ConfigBag{}, revision)
+	handler := NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.RestoreConfigRevision(ctx)) {
+		expected, _ := json.Marshal(revision)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, string(expected), strings.TrimSpace(res.Body.String()))
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
+func TestDelivery_ValidateConfig(t *testing.T) {
+	// Valid
+	ctx, res := initWriteEcho(echo.POST, `{"columns": 4}`)
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("ValidateConfig", []byte(`{"columns": 4}`)).Return(&models.ConfigBag{Config: &models.Config{}})
+	handler := NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.ValidateConfig(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `{}`, strings.TrimSpace(res.Body.String()))
+		mockUsecase.AssertExpectations(t)
+	}
+
+	// Invalid
+	ctx, res = initWriteEcho(echo.POST, `{"columns": 4}`)
+
+	configBag := &models.ConfigBag{Config: &models.Config{}}
+	configBag.AddErrors(models.ConfigError{ID: models.ConfigErrorMissingRequiredField, Message: "boom"})
+	mockUsecase = new(mocks.Usecase)
+	mockUsecase.On("ValidateConfig", Anything).Return(configBag)
+	handler = NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.ValidateConfig(ctx)) {
+		expected, _ := json.Marshal(models.ConfigBag{Errors: configBag.Errors})
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, string(expected), strings.TrimSpace(res.Body.String()))
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
+func TestDelivery_GetConfigHash(t *testing.T) {
+	ctx, res := initWriteEcho(echo.GET, "")
+
+	configHash := &models.ConfigHash{Config: "screen1", Hash: "abc"}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetConfigHash", &models.ConfigParams{Config: "screen1"}).Return(configHash, nil)
+	handler := NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.GetConfigHash(ctx)) {
+		expected, _ := json.Marshal(configHash)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `"abc"`, res.Header().Get("ETag"))
+		assert.Equal(t, string(expected), strings.TrimSpace(res.Body.String()))
+	}
+
+	// Not modified
+	ctx, res = initWriteEcho(echo.GET, "")
+	ctx.Request().Header.Set("If-None-Match", `"abc"`)
+
+	if assert.NoError(t, handler.GetConfigHash(ctx)) {
+		assert.Equal(t, http.StatusNotModified, res.Code)
+		assert.Empty(t, res.Body.String())
+	}
+
+	// Error
+	ctx, res = initWriteEcho(echo.GET, "")
+
+	mockUsecase = new(mocks.Usecase)
+	mockUsecase.On("GetConfigHash", Anything).Return(nil, &models.ConfigError{ID: models.ConfigErrorUnknownNamedConfig})
+	handler = NewConfigDelivery(mockUsecase)
+
+	if assert.NoError(t, handler.GetConfigHash(ctx)) {
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	}
+}
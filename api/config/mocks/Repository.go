@@ -4,6 +4,7 @@ package mocks
 
 import (
 	models "github.com/monitoror/monitoror/api/config/models"
+	config "github.com/monitoror/monitoror/config"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -12,6 +13,29 @@ type Repository struct {
 	mock.Mock
 }
 
+// GetConfigFromContent provides a mock function with given fields: content, filePath
+func (_m *Repository) GetConfigFromContent(content []byte, filePath string) (*models.Config, error) {
+	ret := _m.Called(content, filePath)
+
+	var r0 *models.Config
+	if rf, ok := ret.Get(0).(func([]byte, string) *models.Config); ok {
+		r0 = rf(content, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Config)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, string) error); ok {
+		r1 = rf(content, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetConfigFromPath provides a mock function with given fields: baseDir, filePath
 func (_m *Repository) GetConfigFromPath(baseDir string, filePath string) (*models.Config, error) {
 	ret := _m.Called(baseDir, filePath)
@@ -35,22 +59,114 @@ func (_m *Repository) GetConfigFromPath(baseDir string, filePath string) (*model
 	return r0, r1
 }
 
-// GetConfigFromURL provides a mock function with given fields: url
-func (_m *Repository) GetConfigFromURL(url string) (*models.Config, error) {
-	ret := _m.Called(url)
+// GetConfigFromURL provides a mock function with given fields: url, configName
+func (_m *Repository) GetConfigFromURL(url string, configName config.ConfigName) (*models.Config, error) {
+	ret := _m.Called(url, configName)
 
 	var r0 *models.Config
-	if rf, ok := ret.Get(0).(func(string) *models.Config); ok {
-		r0 = rf(url)
+	if rf, ok := ret.Get(0).(func(string, config.ConfigName) *models.Config); ok {
+		r0 = rf(url, configName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Config)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, config.ConfigName) error); ok {
+		r1 = rf(url, configName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContentFromGit provides a mock function with given fields: source
+func (_m *Repository) GetContentFromGit(source string) (*models.ConfigContent, error) {
+	ret := _m.Called(source)
+
+	var r0 *models.ConfigContent
+	if rf, ok := ret.Get(0).(func(string) *models.ConfigContent); ok {
+		r0 = rf(source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigContent)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(url)
+		r1 = rf(source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContentFromPath provides a mock function with given fields: baseDir, filePath
+func (_m *Repository) GetContentFromPath(baseDir string, filePath string) (*models.ConfigContent, error) {
+	ret := _m.Called(baseDir, filePath)
+
+	var r0 *models.ConfigContent
+	if rf, ok := ret.Get(0).(func(string, string) *models.ConfigContent); ok {
+		r0 = rf(baseDir, filePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigContent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(baseDir, filePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContentFromURL provides a mock function with given fields: url, configName, previous
+func (_m *Repository) GetContentFromURL(url string, configName config.ConfigName, previous *models.ConfigContent) (*models.ConfigContent, error) {
+	ret := _m.Called(url, configName, previous)
+
+	var r0 *models.ConfigContent
+	if rf, ok := ret.Get(0).(func(string, config.ConfigName, *models.ConfigContent) *models.ConfigContent); ok {
+		r0 = rf(url, configName, previous)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigContent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, config.ConfigName, *models.ConfigContent) error); ok {
+		r1 = rf(url, configName, previous)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveConfigToPath provides a mock function with given fields: baseDir, filePath, content
+func (_m *Repository) SaveConfigToPath(baseDir string, filePath string, content []byte) ([]byte, error) {
+	ret := _m.Called(baseDir, filePath, content)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string, string, []byte) []byte); ok {
+		r0 = rf(baseDir, filePath, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, []byte) error); ok {
+		r1 = rf(baseDir, filePath, content)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	models "github.com/monitoror/monitoror/api/config/models"
+	jsonschema "github.com/monitoror/monitoror/internal/pkg/jsonschema"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -28,6 +29,47 @@ func (_m *Usecase) GetConfig(params *models.ConfigParams) *models.ConfigBag {
 	return r0
 }
 
+// GetConfigFromContent provides a mock function with given fields: content, location
+func (_m *Usecase) GetConfigFromContent(content []byte, location string) *models.ConfigBag {
+	ret := _m.Called(content, location)
+
+	var r0 *models.ConfigBag
+	if rf, ok := ret.Get(0).(func([]byte, string) *models.ConfigBag); ok {
+		r0 = rf(content, location)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigBag)
+		}
+	}
+
+	return r0
+}
+
+// GetConfigHash provides a mock function with given fields: params
+func (_m *Usecase) GetConfigHash(params *models.ConfigParams) (*models.ConfigHash, *models.ConfigError) {
+	ret := _m.Called(params)
+
+	var r0 *models.ConfigHash
+	if rf, ok := ret.Get(0).(func(*models.ConfigParams) *models.ConfigHash); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigHash)
+		}
+	}
+
+	var r1 *models.ConfigError
+	if rf, ok := ret.Get(1).(func(*models.ConfigParams) *models.ConfigError); ok {
+		r1 = rf(params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.ConfigError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetConfigList provides a mock function with given fields:
 func (_m *Usecase) GetConfigList() []models.ConfigMetadata {
 	ret := _m.Called()
@@ -44,11 +86,128 @@ func (_m *Usecase) GetConfigList() []models.ConfigMetadata {
 	return r0
 }
 
+// GetConfigRevisions provides a mock function with given fields: params
+func (_m *Usecase) GetConfigRevisions(params *models.ConfigParams) ([]models.ConfigRevision, *models.ConfigError) {
+	ret := _m.Called(params)
+
+	var r0 []models.ConfigRevision
+	if rf, ok := ret.Get(0).(func(*models.ConfigParams) []models.ConfigRevision); ok {
+		r0 = rf(params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ConfigRevision)
+		}
+	}
+
+	var r1 *models.ConfigError
+	if rf, ok := ret.Get(1).(func(*models.ConfigParams) *models.ConfigError); ok {
+		r1 = rf(params)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.ConfigError)
+		}
+	}
+
+	return r0, r1
+}
+
+// GetSchema provides a mock function with given fields:
+func (_m *Usecase) GetSchema() *jsonschema.Schema {
+	ret := _m.Called()
+
+	var r0 *jsonschema.Schema
+	if rf, ok := ret.Get(0).(func() *jsonschema.Schema); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*jsonschema.Schema)
+		}
+	}
+
+	return r0
+}
+
 // Hydrate provides a mock function with given fields: _a0
 func (_m *Usecase) Hydrate(_a0 *models.ConfigBag) {
 	_m.Called(_a0)
 }
 
+// RestoreConfigRevision provides a mock function with given fields: params, revisionID, author
+func (_m *Usecase) RestoreConfigRevision(params *models.ConfigParams, revisionID string, author string) (*models.ConfigBag, *models.ConfigRevision) {
+	ret := _m.Called(params, revisionID, author)
+
+	var r0 *models.ConfigBag
+	if rf, ok := ret.Get(0).(func(*models.ConfigParams, string, string) *models.ConfigBag); ok {
+		r0 = rf(params, revisionID, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigBag)
+		}
+	}
+
+	var r1 *models.ConfigRevision
+	if rf, ok := ret.Get(1).(func(*models.ConfigParams, string, string) *models.ConfigRevision); ok {
+		r1 = rf(params, revisionID, author)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.ConfigRevision)
+		}
+	}
+
+	return r0, r1
+}
+
+// SaveConfig provides a mock function with given fields: params, content, author
+func (_m *Usecase) SaveConfig(params *models.ConfigParams, content []byte, author string) (*models.ConfigBag, *models.ConfigRevision) {
+	ret := _m.Called(params, content, author)
+
+	var r0 *models.ConfigBag
+	if rf, ok := ret.Get(0).(func(*models.ConfigParams, []byte, string) *models.ConfigBag); ok {
+		r0 = rf(params, content, author)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigBag)
+		}
+	}
+
+	var r1 *models.ConfigRevision
+	if rf, ok := ret.Get(1).(func(*models.ConfigParams, []byte, string) *models.ConfigRevision); ok {
+		r1 = rf(params, content, author)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.ConfigRevision)
+		}
+	}
+
+	return r0, r1
+}
+
+// StartWatching provides a mock function with given fields:
+func (_m *Usecase) StartWatching() {
+	_m.Called()
+}
+
+// StopWatching provides a mock function with given fields:
+func (_m *Usecase) StopWatching() {
+	_m.Called()
+}
+
+// ValidateConfig provides a mock function with given fields: content
+func (_m *Usecase) ValidateConfig(content []byte) *models.ConfigBag {
+	ret := _m.Called(content)
+
+	var r0 *models.ConfigBag
+	if rf, ok := ret.Get(0).(func([]byte) *models.ConfigBag); ok {
+		r0 = rf(content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ConfigBag)
+		}
+	}
+
+	return r0
+}
+
 // Verify provides a mock function with given fields: _a0
 func (_m *Usecase) Verify(_a0 *models.ConfigBag) {
 	_m.Called(_a0)
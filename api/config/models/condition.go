@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/monitoror/monitoror/pkg/humanize"
+)
+
+var timeRangeRegex = regexp.MustCompile(`^([01][0-9]|2[0-3]):([0-5][0-9])-([01][0-9]|2[0-3]):([0-5][0-9])$`)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// WeekdayNames are expected values of "weekdays" condition field (three first letters are also accepted)
+const WeekdayNames = "monday, tuesday, wednesday, thursday, friday, saturday, sunday"
+
+type (
+	// TileCondition restrict display of tile (or schedule) to a time range, weekdays or a variable value.
+	// Every defined field must match. Evaluated during Hydrate
+	TileCondition struct {
+		Time     string   `json:"time,omitempty"`     // "HH:MM-HH:MM", end before start to span midnight (ex: "20:00-08:00")
+		Weekdays []string `json:"weekdays,omitempty"` // "monday", ..., "sunday"
+
+		// Variable is the name of a config variable. Match when its value is true (or equals "equals" when defined)
+		Variable string      `json:"variable,omitempty"`
+		Equals   interface{} `json:"equals,omitempty"`
+
+		// variableValue is set during expansion, variables are removed before Verify
+		variableValue interface{}
+	}
+
+	// ScheduleConfig contains tiles displayed instead of config tiles when condition match
+	ScheduleConfig struct {
+		When  *TileCondition `json:"when" validate:"required"`
+		Tiles []TileConfig   `json:"tiles" validate:"required,notempty"`
+	}
+)
+
+// ParseTimeRange return start and end of "HH:MM-HH:MM" range, in minutes since midnight
+func ParseTimeRange(value string) (start, end int, err error) {
+	match := timeRangeRegex.FindStringSubmatch(value)
+	if match == nil {
+		return 0, 0, fmt.Errorf(`invalid %q time range, expected "HH:MM-HH:MM"`, value)
+	}
+
+	// Already validated by regex
+	var values [4]int
+	for i := range values {
+		values[i], _ = strconv.Atoi(match[i+1])
+	}
+
+	start, end = values[0]*60+values[1], values[2]*60+values[3]
+	if start == end {
+		return 0, 0, fmt.Errorf(`empty %q time range, start and end must be different`, value)
+	}
+
+	return start, end, nil
+}
+
+// ParseWeekday return weekday from its name or three first letters (case insensitive)
+func ParseWeekday(value string) (time.Weekday, error) {
+	name := strings.ToLower(value)
+	for weekdayName, weekday := range weekdays {
+		if name == weekdayName || (len(name) == 3 && strings.HasPrefix(weekdayName, name)) {
+			return weekday, nil
+		}
+	}
+
+	return 0, fmt.Errorf(`unknown %q weekday, expected %s`, value, WeekdayNames)
+}
+
+// SetVariableValue keep value of condition variable, resolved during expansion
+func (c *TileCondition) SetVariableValue(value interface{}) {
+	c.variableValue = value
+}
+
+// Match return true when every defined field of condition match at given time. Invalid fields never match
+func (c *TileCondition) Match(now time.Time) bool {
+	if c.Time != "" {
+		start, end, err := ParseTimeRange(c.Time)
+		if err != nil {
+			return false
+		}
+
+		minutes := now.Hour()*60 + now.Minute()
+		if start < end && (minutes < start || minutes >= end) {
+			return false
+		}
+		// Range spanning midnight
+		if start > end && minutes < start && minutes >= end {
+			return false
+		}
+	}
+
+	if len(c.Weekdays) > 0 {
+		found := false
+		for _, name := range c.Weekdays {
+			if weekday, err := ParseWeekday(name); err == nil && weekday == now.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if c.Variable != "" {
+		if c.variableValue == nil {
+			return false
+		}
+		if c.Equals != nil {
+			return humanize.Interface(c.variableValue) == humanize.Interface(c.Equals)
+		}
+		return isTruthy(c.variableValue)
+	}
+
+	return true
+}
+
+// isTruthy return false for false, 0 and "", "false", "0", "no", "off" strings
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "", "false", "0", "no", "off":
+			return false
+		}
+		return true
+	default:
+		return humanize.Interface(v) != "0"
+	}
+}
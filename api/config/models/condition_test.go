@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	start, end, err := ParseTimeRange("08:30-19:00")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 8*60+30, start)
+		assert.Equal(t, 19*60, end)
+	}
+
+	for _, value := range []string{"", "8:00-19:00", "08:00", "08:00-24:00", "08:60-09:00", "10:00-10:00"} {
+		_, _, err := ParseTimeRange(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseWeekday(t *testing.T) {
+	for value, expected := range map[string]time.Weekday{
+		"monday": time.Monday,
+		"Sunday": time.Sunday,
+		"SAT":    time.Saturday,
+		"wed":    time.Wednesday,
+	} {
+		weekday, err := ParseWeekday(value)
+		if assert.NoError(t, err, value) {
+			assert.Equal(t, expected, weekday, value)
+		}
+	}
+
+	for _, value := range []string{"", "mo", "mondays", "lundi"} {
+		_, err := ParseWeekday(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestTileCondition_Match(t *testing.T) {
+	// Friday
+	day := time.Date(2020, 5, 15, 14, 0, 0, 0, time.UTC)
+	night := time.Date(2020, 5, 15, 23, 30, 0, 0, time.UTC)
+	earlyMorning := time.Date(2020, 5, 16, 6, 59, 0, 0, time.UTC)
+
+	for _, testcase := range []struct {
+		condition *TileCondition
+		value     interface{}
+		now       time.Time
+		expected  bool
+	}{
+		{condition: &TileCondition{}, now: day, expected: true},
+		{condition: &TileCondition{Time: "08:00-19:00"}, now: day, expected: true},
+		{condition: &TileCondition{Time: "08:00-14:00"}, now: day, expected: false},
+		{condition: &TileCondition{Time: "19:00-07:00"}, now: day, expected: false},
+		{condition: &TileCondition{Time: "19:00-07:00"}, now: night, expected: true},
+		{condition: &TileCondition{Time: "19:00-07:00"}, now: earlyMorning, expected: true},
+		{condition: &TileCondition{Time: "invalid"}, now: day, expected: false},
+		{condition: &TileCondition{Weekdays: []string{"monday", "fri"}}, now: day, expected: true},
+		{condition: &TileCondition{Weekdays: []string{"saturday", "sunday"}}, now: day, expected: false},
+		{condition: &TileCondition{Weekdays: []string{"saturday"}, Time: "00:00-08:00"}, now: earlyMorning, expected: true},
+		{condition: &TileCondition{Weekdays: []string{"saturday"}, Time: "00:00-06:00"}, now: earlyMorning, expected: false},
+		{condition: &TileCondition{Variable: "onCall"}, value: true, now: day, expected: true},
+		{condition: &TileCondition{Variable: "onCall"}, value: "false", now: day, expected: false},
+		{condition: &TileCondition{Variable: "onCall"}, value: float64(0), now: day, expected: false},
+		{condition: &TileCondition{Variable: "onCall"}, now: day, expected: false},
+		{condition: &TileCondition{Variable: "team", Equals: "ops"}, value: "ops", now: day, expected: true},
+		{condition: &TileCondition{Variable: "team", Equals: "ops"}, value: "dev", now: day, expected: false},
+		{condition: &TileCondition{Variable: "screen", Equals: float64(2)}, value: 2, now: day, expected: true},
+	} {
+		testcase.condition.SetVariableValue(testcase.value)
+		assert.Equal(t, testcase.expected, testcase.condition.Match(testcase.now), "%+v at %s", testcase.condition, testcase.now)
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"github.com/monitoror/monitoror/api/config/versions"
+	pkgConfig "github.com/monitoror/monitoror/internal/pkg/api/config"
 	coreModels "github.com/monitoror/monitoror/models"
 )
 
@@ -9,17 +10,44 @@ type (
 	ConfigBag struct {
 		Config *Config       `json:"config,omitempty"`
 		Errors []ConfigError `json:"errors,omitempty"`
+
+		// Hash of named config content, used by UI to detect changes (see /configs/:config/hash)
+		Hash string `json:"hash,omitempty"`
+		// HashPollInterval is the delay between two UI checks of Hash, follow ConfigPollInterval of Core
+		HashPollInterval int `json:"hashPollInterval,omitempty"` // in Millisecond
+		// Commit SHA of named config loaded from git, used by UI to show live revision
+		Commit string `json:"commit,omitempty"`
 	}
 
 	Config struct {
 		Version *versions.ConfigVersion `json:"version"`
-		Columns *int                    `json:"columns" validate:"required,gt=0"`
+		Columns *int                    `json:"columns" validate:"omitempty,gt=0"` // required without pages
 		Zoom    *float32                `json:"zoom,omitempty" validate:"omitempty,gt=0,lte=10"`
-		Tiles   []TileConfig            `json:"tiles" validate:"required,notempty"`
+		Tiles   []TileConfig            `json:"tiles" validate:"omitempty,notempty"` // required without pages
+
+		// Pages are displayed in rotation by UI, instead of tiles. Columns and zoom of config are used by default
+		Pages []PageConfig `json:"pages,omitempty"`
+
+		// Schedule replace tiles by tiles of first matching entry, removed by Hydrate
+		Schedule []ScheduleConfig `json:"schedule,omitempty"`
+		// Timezone used to evaluate "when" conditions (ex: "Europe/Paris"). Server timezone when empty
+		Timezone string `json:"timezone,omitempty"`
+
+		// Expanded before Verify, removed before being returned to the UI
+		Variables map[string]interface{} `json:"variables,omitempty"`
+		Templates map[string]TileConfig  `json:"templates,omitempty"`
+		Include   []string               `json:"include,omitempty"` // path or url of files containing variables / templates
+	}
+
+	PageConfig struct {
+		Columns  *int         `json:"columns,omitempty" validate:"omitempty,gt=0"`
+		Zoom     *float32     `json:"zoom,omitempty" validate:"omitempty,gt=0,lte=10"`
+		Tiles    []TileConfig `json:"tiles" validate:"required,notempty"`
+		Duration *int         `json:"duration,omitempty" validate:"omitempty,gt=0"` // in seconds
 	}
 
 	TileConfig struct {
-		Type coreModels.TileType `json:"type" validate:"required"`
+		Type coreModels.TileType `json:"type,omitempty" validate:"required"`
 
 		Label      string `json:"label,omitempty"`
 		RowSpan    *int   `json:"rowSpan,omitempty" validate:"omitempty,gt=0"`
@@ -29,10 +57,21 @@ type (
 		URL             string       `json:"url,omitempty"`
 		InitialMaxDelay *int         `json:"initialMaxDelay,omitempty"`
 
+		// Tile is only displayed when condition match, removed by Hydrate
+		When *TileCondition `json:"when,omitempty"`
+
 		// Used to validate config and to create API URLs
 		// Will be removed before being returned to the UI
 		Params        map[string]interface{} `json:"params,omitempty"`
 		ConfigVariant coreModels.VariantName `json:"configVariant,omitempty"`
+
+		// Used to expand tile from templates, "with" contains template variables
+		// Will be removed before Verify
+		Template string                 `json:"template,omitempty"`
+		With     map[string]interface{} `json:"with,omitempty"`
+
+		// origin is the tile extract before expansion, used in errors
+		origin string
 	}
 
 	ConfigError struct {
@@ -53,12 +92,15 @@ type (
 )
 
 const (
+	ConfigErrorCircularReference                 ConfigErrorID = "ERROR_CIRCULAR_REFERENCE"
 	ConfigErrorConfigNotFound                    ConfigErrorID = "ERROR_CONFIG_NOT_FOUND"
 	ConfigErrorDisabledVariant                   ConfigErrorID = "ERROR_DISABLED_VARIANT"
 	ConfigErrorFieldTypeMismatch                 ConfigErrorID = "ERROR_FIELD_TYPE_MISMATCH"
 	ConfigErrorInvalidEscapedCharacter           ConfigErrorID = "ERROR_INVALID_ESCAPED_CHARACTER"
 	ConfigErrorInvalidFieldValue                 ConfigErrorID = "ERROR_INVALID_FIELD_VALUE"
 	ConfigErrorMissingRequiredField              ConfigErrorID = "ERROR_MISSING_REQUIRED_FIELD"
+	ConfigErrorReadOnlyConfig                    ConfigErrorID = "ERROR_READ_ONLY_CONFIG"
+	ConfigErrorRevisionNotFound                  ConfigErrorID = "ERROR_REVISION_NOT_FOUND"
 	ConfigErrorUnsupportedTileInThisVersion      ConfigErrorID = "ERROR_UNSUPPORTED_TILE_IN_THIS_VERSION"
 	ConfigErrorUnsupportedTileParamInThisVersion ConfigErrorID = "ERROR_UNSUPPORTED_TILE_PARAM_IN_THIS_VERSION"
 	ConfigErrorUnauthorizedField                 ConfigErrorID = "ERROR_UNAUTHORIZED_FIELD"
@@ -69,7 +111,9 @@ const (
 	ConfigErrorUnknownField                      ConfigErrorID = "ERROR_UNKNOWN_FIELD"
 	ConfigErrorUnknownGeneratorTileType          ConfigErrorID = "ERROR_UNKNOWN_GENERATOR_TILE_TYPE"
 	ConfigErrorUnknownNamedConfig                ConfigErrorID = "ERROR_UNKNOWN_NAMED_CONFIG"
+	ConfigErrorUnknownTemplate                   ConfigErrorID = "ERROR_UNKNOWN_TEMPLATE"
 	ConfigErrorUnknownTileType                   ConfigErrorID = "ERROR_UNKNOWN_TILE_TYPE"
+	ConfigErrorUnknownVariable                   ConfigErrorID = "ERROR_UNKNOWN_VARIABLE"
 	ConfigErrorUnknownVariant                    ConfigErrorID = "ERROR_UNKNOWN_VARIANT"
 	ConfigErrorUnsupportedVersion                ConfigErrorID = "ERROR_UNSUPPORTED_VERSION"
 )
@@ -77,3 +121,39 @@ const (
 func (c *ConfigBag) AddErrors(errors ...ConfigError) {
 	c.Errors = append(c.Errors, errors...)
 }
+
+// Extract return tile extract used in errors. Original extract for tiles expanded from templates / variables
+func (t *TileConfig) Extract() string {
+	if t.origin != "" {
+		return t.origin
+	}
+	return pkgConfig.Stringify(t)
+}
+
+// SetOrigin keep original extract of tile, before expansion
+func (t *TileConfig) SetOrigin(extract string) {
+	t.origin = extract
+}
+
+// GetTileURLs return every hydrated tile URL (including grouped tiles and tiles of pages), without duplicates
+func (c *Config) GetTileURLs() []string {
+	var urls []string
+	known := make(map[string]bool)
+
+	var walk func(tiles []TileConfig)
+	walk = func(tiles []TileConfig) {
+		for _, tile := range tiles {
+			if tile.URL != "" && !known[tile.URL] {
+				known[tile.URL] = true
+				urls = append(urls, tile.URL)
+			}
+			walk(tile.Tiles)
+		}
+	}
+	walk(c.Tiles)
+	for _, page := range c.Pages {
+		walk(page.Tiles)
+	}
+
+	return urls
+}
@@ -12,3 +12,29 @@ func TestConfig_AddErrors(t *testing.T) {
 
 	assert.Len(t, config.Errors, 1)
 }
+
+func TestConfig_GetTileURLs(t *testing.T) {
+	config := &Config{
+		Tiles: []TileConfig{
+			{Type: "EMPTY"},
+			{Type: "PING", URL: "/ping?hostname=a"},
+			{Type: "GROUP", Tiles: []TileConfig{
+				{Type: "PING", URL: "/ping?hostname=b"},
+				{Type: "PING", URL: "/ping?hostname=a"},
+			}},
+		},
+	}
+
+	assert.Equal(t, []string{"/ping?hostname=a", "/ping?hostname=b"}, config.GetTileURLs())
+}
+
+func TestConfig_GetTileURLs_WithPages(t *testing.T) {
+	config := &Config{
+		Pages: []PageConfig{
+			{Tiles: []TileConfig{{Type: "PING", URL: "/ping?hostname=a"}}},
+			{Tiles: []TileConfig{{Type: "PING", URL: "/ping?hostname=b"}, {Type: "PING", URL: "/ping?hostname=a"}}},
+		},
+	}
+
+	assert.Equal(t, []string{"/ping?hostname=a", "/ping?hostname=b"}, config.GetTileURLs())
+}
@@ -1,5 +1,34 @@
 package models
 
-type ConfigMetadata struct {
-	Name string `json:"name"`
-}
+import (
+	"time"
+
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+type (
+	// ConfigMetadata describe a named config with a health summary, used to list configs
+	ConfigMetadata struct {
+		Name string `json:"name"`
+
+		Source   ConfigSource `json:"source,omitempty"`
+		Location string       `json:"location,omitempty"` // path, url (without credentials) or git source
+		// LastLoad is the last time config was loaded by UI (or API), nil when not loaded since start
+		LastLoad *time.Time `json:"lastLoad,omitempty"`
+		Hash     string     `json:"hash,omitempty"`
+
+		// TileCount and ErrorCount are computed from config verified on listing
+		TileCount  int `json:"tileCount"`
+		ErrorCount int `json:"errorCount"`
+		// TileTypes are monitorable tile types used in config (including pages and schedule)
+		TileTypes []coreModels.TileType `json:"tileTypes,omitempty"`
+	}
+
+	ConfigSource string
+)
+
+const (
+	ConfigSourcePath ConfigSource = "path"
+	ConfigSourceURL  ConfigSource = "url"
+	ConfigSourceGit  ConfigSource = "git"
+)
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type (
+	// ConfigContent is the raw content of a config file or url, used to detect changes
+	ConfigContent struct {
+		Content []byte
+
+		// ContentType and URL (after redirects) of remote configs, used to detect format
+		ContentType string
+		URL         string
+
+		// ETag and LastModified are sent by servers of remote configs, used for conditional requests
+		ETag         string
+		LastModified string
+
+		// Commit is the SHA of commit containing content of configs loaded from git
+		Commit string
+	}
+
+	// ConfigHash identify current content of named config. Hash change every time config file / url change
+	ConfigHash struct {
+		Config    string    `json:"config"`
+		Hash      string    `json:"hash"`      // sha256 of content
+		UpdatedAt time.Time `json:"updatedAt"` // date of last detected change
+	}
+)
@@ -32,3 +32,13 @@ func (e *ConfigUnmarshalError) Error() string {
 	return strError
 }
 func (e *ConfigUnmarshalError) Unwrap() error { return e.Err }
+
+// ConfigRevisionNotFoundError
+type ConfigRevisionNotFoundError struct {
+	Config   string
+	Revision string
+}
+
+func (e *ConfigRevisionNotFoundError) Error() string {
+	return fmt.Sprintf(`Revision %q of %q named config not found`, e.Revision, e.Config)
+}
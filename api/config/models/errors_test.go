@@ -22,3 +22,8 @@ func TestConfigUnmarshalError(t *testing.T) {
 	assert.Equal(t, "boom", err.Error())
 	assert.Equal(t, "boom", err.Unwrap().Error())
 }
+
+func TestConfigRevisionNotFoundError(t *testing.T) {
+	err := &ConfigRevisionNotFoundError{Config: "screen1", Revision: "123"}
+	assert.Equal(t, `Revision "123" of "screen1" named config not found`, err.Error())
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// GitSourcePrefix identify configs stored in git repository
+const GitSourcePrefix = "git+"
+
+var gitSourceRegex = regexp.MustCompile(`^git\+((?:https?|file)://[^#]+)(?:#(.*))?$`)
+
+type (
+	// GitSource is a config file stored in git repository, like:
+	//	git+https://host/repo.git#branch:path/screen.json
+	//	git+file:///srv/configs.git#v1.2.0:screen.json
+	GitSource struct {
+		Repository string // url of repository, without prefix
+		Ref        string // branch, tag or commit. Default branch (HEAD) when empty
+		Path       string // path of config file in repository
+	}
+)
+
+// IsGitSource return true when named config, or include, is stored in git repository
+func IsGitSource(source string) bool {
+	return strings.HasPrefix(source, GitSourcePrefix)
+}
+
+// ParseGitSource split source in repository, ref and path
+func ParseGitSource(source string) (*GitSource, error) {
+	match := gitSourceRegex.FindStringSubmatch(source)
+	if match == nil {
+		return nil, fmt.Errorf(`invalid git source %q, expected "git+https://host/repo.git#branch:path/config.json"`, source)
+	}
+
+	gitSource := &GitSource{Repository: match[1], Path: match[2]}
+	if index := strings.Index(match[2], ":"); index != -1 {
+		gitSource.Ref, gitSource.Path = match[2][:index], match[2][index+1:]
+	}
+
+	// Path can't target files outside of repository, ref can't be mistaken for a git option
+	gitSource.Path = strings.TrimPrefix(path.Clean("/"+gitSource.Path), "/")
+	if gitSource.Path == "" {
+		return nil, fmt.Errorf(`missing config path in %q git source, expected "git+https://host/repo.git#branch:path/config.json"`, source)
+	}
+	if strings.HasPrefix(gitSource.Ref, "-") {
+		return nil, fmt.Errorf(`invalid %q ref in %q git source`, gitSource.Ref, source)
+	}
+
+	return gitSource, nil
+}
+
+// Resolve return source of file included by this config, relative paths are resolved from config directory
+func (s *GitSource) Resolve(include string) *GitSource {
+	resolved := &GitSource{Repository: s.Repository, Ref: s.Ref}
+	if strings.HasPrefix(include, "/") {
+		resolved.Path = strings.TrimPrefix(path.Clean(include), "/")
+	} else {
+		resolved.Path = strings.TrimPrefix(path.Clean("/"+path.Join(path.Dir(s.Path), include)), "/")
+	}
+	return resolved
+}
+
+func (s *GitSource) String() string {
+	if s.Ref == "" {
+		return fmt.Sprintf("%s%s#%s", GitSourcePrefix, s.Repository, s.Path)
+	}
+	return fmt.Sprintf("%s%s#%s:%s", GitSourcePrefix, s.Repository, s.Ref, s.Path)
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGitSource(t *testing.T) {
+	for _, testcase := range []struct {
+		source   string
+		expected *GitSource
+	}{
+		{
+			source:   "git+https://github.com/example/dashboards.git#main:screens/screen1.json",
+			expected: &GitSource{Repository: "https://github.com/example/dashboards.git", Ref: "main", Path: "screens/screen1.json"},
+		},
+		{
+			source:   "git+file:///srv/configs.git#screen1.yaml",
+			expected: &GitSource{Repository: "file:///srv/configs.git", Path: "screen1.yaml"},
+		},
+		{
+			source:   "git+file:///srv/configs.git#v1.2.0:../../etc/passwd",
+			expected: &GitSource{Repository: "file:///srv/configs.git", Ref: "v1.2.0", Path: "etc/passwd"},
+		},
+	} {
+		gitSource, err := ParseGitSource(testcase.source)
+		if assert.NoError(t, err, testcase.source) {
+			assert.Equal(t, testcase.expected, gitSource)
+		}
+	}
+
+	for _, source := range []string{
+		"https://github.com/example/dashboards.git#main:screen1.json",
+		"git+ssh://git@github.com/example/dashboards.git#main:screen1.json",
+		"git+https://github.com/example/dashboards.git",
+		"git+https://github.com/example/dashboards.git#main:",
+		"git+https://github.com/example/dashboards.git#--upload-pack=touch:screen1.json",
+	} {
+		_, err := ParseGitSource(source)
+		assert.Error(t, err, source)
+	}
+}
+
+func TestGitSource_Resolve(t *testing.T) {
+	gitSource := &GitSource{Repository: "file:///srv/configs.git", Ref: "main", Path: "screens/screen1.json"}
+
+	assert.Equal(t, "git+file:///srv/configs.git#main:screens/common.json", gitSource.Resolve("common.json").String())
+	assert.Equal(t, "git+file:///srv/configs.git#main:shared/common.json", gitSource.Resolve("../shared/common.json").String())
+	assert.Equal(t, "git+file:///srv/configs.git#main:common.json", gitSource.Resolve("/common.json").String())
+	assert.Equal(t, "git+file:///srv/configs.git#common.json", (&GitSource{Repository: "file:///srv/configs.git", Path: "common.json"}).String())
+}
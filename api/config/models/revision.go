@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+type (
+	// ConfigRevision describe a version of named config saved through the API
+	ConfigRevision struct {
+		ID     string    `json:"id"`
+		Config string    `json:"config"`
+		Author string    `json:"author,omitempty"`
+		Date   time.Time `json:"date"`
+		Hash   string    `json:"hash"` // sha256 of content
+
+		// RestoredFrom contains ID of restored revision
+		RestoredFrom string `json:"restoredFrom,omitempty"`
+	}
+)
@@ -4,11 +4,20 @@ package config
 
 import (
 	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
 )
 
 type (
 	Repository interface {
-		GetConfigFromURL(url string) (*models.Config, error)
+		// configName is the named config being loaded (or including url), empty for other urls
+		GetConfigFromURL(url string, configName coreConfig.ConfigName) (*models.Config, error)
 		GetConfigFromPath(baseDir, filePath string) (*models.Config, error)
+		GetConfigFromContent(content []byte, filePath string) (*models.Config, error)
+		SaveConfigToPath(baseDir, filePath string, content []byte) (previous []byte, err error)
+
+		// Used to detect config changes
+		GetContentFromURL(url string, configName coreConfig.ConfigName, previous *models.ConfigContent) (*models.ConfigContent, error)
+		GetContentFromPath(baseDir, filePath string) (*models.ConfigContent, error)
+		GetContentFromGit(source string) (*models.ConfigContent, error)
 	}
 )
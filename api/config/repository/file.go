@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/internal/pkg/path"
@@ -15,7 +18,59 @@ func (cr *configRepository) GetConfigFromPath(baseDir, filePath string) (config
 	}
 	defer file.Close()
 
-	config, err = ReadConfig(file)
+	config, err = ReadConfigWithFormat(file, FormatFromPath(filePath))
 
 	return
 }
+
+// GetContentFromPath return raw content of config file
+func (cr *configRepository) GetContentFromPath(baseDir, filePath string) (*models.ConfigContent, error) {
+	filePath = path.ToAbsolute(baseDir, filePath)
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: filePath}
+	}
+
+	return &models.ConfigContent{Content: content}, nil
+}
+
+// GetConfigFromContent read content which will be saved in filePath, format is detected from file extension
+func (cr *configRepository) GetConfigFromContent(content []byte, filePath string) (config *models.Config, err error) {
+	return ReadConfigWithFormat(bytes.NewReader(content), FormatFromPath(filePath))
+}
+
+// SaveConfigToPath replace config file and return its previous content (nil when file didn't exist).
+// Content is written in a temporary file then renamed, so config is never read half written
+func (cr *configRepository) SaveConfigToPath(baseDir, filePath string, content []byte) (previous []byte, err error) {
+	filePath = path.ToAbsolute(baseDir, filePath)
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(filePath); err == nil {
+		mode = info.Mode()
+		if previous, err = ioutil.ReadFile(filePath); err != nil {
+			return nil, err
+		}
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(filePath), "."+filepath.Base(filePath)+".*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err = tmpFile.Write(content); err != nil {
+		_ = tmpFile.Close()
+		return nil, err
+	}
+	if err = tmpFile.Close(); err != nil {
+		return nil, err
+	}
+	if err = os.Chmod(tmpFile.Name(), mode); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmpFile.Name(), filePath); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/monitoror/monitoror/api/config/models"
@@ -45,3 +46,81 @@ func TestConfigRepository_GetConfigFromPath_MissingFile(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "Config not found at: /test/monitoror-missing-file, open /test/monitoror-missing-file: no such file or directory", err.Error())
 }
+
+func TestConfigRepository_GetConfigFromPath_YAML(t *testing.T) {
+	tmpFile, err := ioutil.TempFile(os.TempDir(), "test-config-*.yml")
+	if assert.NoError(t, err) {
+		defer os.Remove(tmpFile.Name())
+		_, _ = tmpFile.WriteString("columns: 4\ntiles: []\n")
+
+		repository := NewConfigRepository()
+		config, err := repository.GetConfigFromPath("", tmpFile.Name())
+		if assert.NoError(t, err) {
+			assert.Equal(t, 4, *config.Columns)
+		}
+	}
+}
+
+func TestConfigRepository_GetConfigFromContent(t *testing.T) {
+	repository := NewConfigRepository()
+
+	config, err := repository.GetConfigFromContent([]byte("columns: 4\ntiles: []\n"), "/configs/screen1.yaml")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, *config.Columns)
+	}
+
+	_, err = repository.GetConfigFromContent([]byte("columns: 4\n"), "/configs/screen1.json")
+	assert.Error(t, err)
+}
+
+func TestConfigRepository_SaveConfigToPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-save-config-")
+	if assert.NoError(t, err) {
+		defer os.RemoveAll(dir)
+
+		repository := NewConfigRepository()
+
+		previous, err := repository.SaveConfigToPath(dir, "screen1.json", []byte(`{"columns": 4}`))
+		if assert.NoError(t, err) {
+			assert.Nil(t, previous)
+		}
+
+		previous, err = repository.SaveConfigToPath(dir, "screen1.json", []byte(`{"columns": 2}`))
+		if assert.NoError(t, err) {
+			assert.Equal(t, `{"columns": 4}`, string(previous))
+		}
+
+		content, err := ioutil.ReadFile(filepath.Join(dir, "screen1.json"))
+		if assert.NoError(t, err) {
+			assert.Equal(t, `{"columns": 2}`, string(content))
+		}
+
+		// Temporary files are removed
+		files, _ := ioutil.ReadDir(dir)
+		assert.Len(t, files, 1)
+	}
+}
+
+func TestConfigRepository_SaveConfigToPath_MissingDir(t *testing.T) {
+	repository := NewConfigRepository()
+	_, err := repository.SaveConfigToPath("/monitoror-missing-dir", "screen1.json", []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestConfigRepository_GetContentFromPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-config-GetContentFromPath-")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "config.json"), []byte(`{}`), 0644))
+
+	repository := NewConfigRepository()
+	content, err := repository.GetContentFromPath(dir, "config.json")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte(`{}`), content.Content)
+	}
+
+	_, err = repository.GetContentFromPath(dir, "missing.json")
+	assert.IsType(t, &models.ConfigFileNotFoundError{}, err)
+}
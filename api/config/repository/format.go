@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Format string
+
+const (
+	UnknownFormat Format = "" // Detected from content
+	JSONFormat    Format = "json"
+	YAMLFormat    Format = "yaml"
+)
+
+const yamlMergeKey = "<<"
+
+// FormatFromPath return config format from file extension (or url path)
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonc", ".json5":
+		return JSONFormat
+	case ".yaml", ".yml":
+		return YAMLFormat
+	default:
+		return UnknownFormat
+	}
+}
+
+// FormatFromContentType return config format from http Content-Type header
+func FormatFromContentType(contentType string) Format {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return UnknownFormat
+	}
+
+	switch {
+	case strings.HasSuffix(mediaType, "json"), strings.HasSuffix(mediaType, "json5"):
+		return JSONFormat
+	case strings.HasSuffix(mediaType, "yaml"):
+		return YAMLFormat
+	default:
+		return UnknownFormat
+	}
+}
+
+// detectFormat sniff content: JSON start with "{" (or a comment), everything else is parsed as YAML
+func detectFormat(data []byte) Format {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || bytes.HasPrefix(trimmed, []byte("{")) || bytes.HasPrefix(trimmed, []byte("[")) ||
+		bytes.HasPrefix(trimmed, []byte("//")) || bytes.HasPrefix(trimmed, []byte("/*")) {
+		return JSONFormat
+	}
+	return YAMLFormat
+}
+
+// toJSON convert config content into standard JSON
+func toJSON(data []byte, format Format) ([]byte, error) {
+	if format == UnknownFormat {
+		format = detectFormat(data)
+	}
+
+	if format == YAMLFormat {
+		return yamlToJSON(data)
+	}
+	return stripJSONComments(data), nil
+}
+
+// stripJSONComments replace comments (// and /* */) and trailing commas by spaces.
+// Content length and line breaks are kept, so json errors offsets still match raw config.
+func stripJSONComments(data []byte) []byte {
+	result := make([]byte, len(data))
+	copy(result, data)
+
+	// Comments
+	inString := false
+	for i := 0; i < len(result); i++ {
+		switch {
+		case inString:
+			if result[i] == '\\' {
+				i++
+			} else if result[i] == '"' {
+				inString = false
+			}
+		case result[i] == '"':
+			inString = true
+		case result[i] == '/' && i+1 < len(result) && result[i+1] == '/':
+			for ; i < len(result) && result[i] != '\n'; i++ {
+				result[i] = ' '
+			}
+		case result[i] == '/' && i+1 < len(result) && result[i+1] == '*':
+			end := bytes.Index(result[i+2:], []byte("*/"))
+			if end == -1 {
+				return result // Unterminated comment, let json decoder raise an error
+			}
+			for j := i; j < i+2+end+2; j++ {
+				if result[j] != '\n' {
+					result[j] = ' '
+				}
+			}
+			i += 2 + end + 1
+		}
+	}
+
+	// Trailing commas
+	inString = false
+	for i := 0; i < len(result); i++ {
+		switch {
+		case inString:
+			if result[i] == '\\' {
+				i++
+			} else if result[i] == '"' {
+				inString = false
+			}
+		case result[i] == '"':
+			inString = true
+		case result[i] == ',':
+			next := bytes.TrimLeft(result[i+1:], " \t\r\n")
+			if len(next) > 0 && (next[0] == '}' || next[0] == ']') {
+				result[i] = ' '
+			}
+		}
+	}
+
+	return result
+}
+
+// yamlToJSON convert YAML into JSON, keeping keys order (used by config extracts)
+func yamlToJSON(data []byte) ([]byte, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	// Empty document
+	if len(document.Content) == 0 {
+		return []byte("null"), nil
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := writeYAMLNode(buffer, document.Content[0], true); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeYAMLNode(buffer *bytes.Buffer, node *yaml.Node, root bool) error {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		return writeYAMLNode(buffer, node.Content[0], root)
+	case yaml.AliasNode:
+		return writeYAMLNode(buffer, node.Alias, root)
+	case yaml.SequenceNode:
+		buffer.WriteByte('[')
+		for i, child := range node.Content {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			if err := writeYAMLNode(buffer, child, false); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(']')
+	case yaml.MappingNode:
+		pairs, err := yamlMappingPairs(node)
+		if err != nil {
+			return err
+		}
+
+		buffer.WriteByte('{')
+		for i, pair := range pairs {
+			if i > 0 {
+				buffer.WriteByte(',')
+			}
+			key, _ := json.Marshal(pair[0].Value)
+			buffer.Write(key)
+			buffer.WriteByte(':')
+
+			// Version is a string in JSON ("2.0"), but a number for YAML when not quoted
+			value := pair[1]
+			if root && pair[0].Value == "version" && value.Kind == yaml.ScalarNode && (value.Tag == "!!float" || value.Tag == "!!int") {
+				value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value.Value}
+			}
+			if err := writeYAMLNode(buffer, value, false); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte('}')
+	case yaml.ScalarNode:
+		var value interface{}
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("yaml: line %d: unsupported value %q", node.Line, node.Value)
+		}
+		buffer.Write(b)
+	}
+
+	return nil
+}
+
+// yamlMappingPairs return key / value pairs of mapping, resolving merge keys ("<<: *anchor")
+func yamlMappingPairs(node *yaml.Node) ([][2]*yaml.Node, error) {
+	var pairs [][2]*yaml.Node
+	indexes := make(map[string]int)
+
+	add := func(key, value *yaml.Node, override bool) {
+		if index, ok := indexes[key.Value]; ok {
+			if override {
+				pairs[index][1] = value
+			}
+			return
+		}
+		indexes[key.Value] = len(pairs)
+		pairs = append(pairs, [2]*yaml.Node{key, value})
+	}
+
+	var merged []*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("yaml: line %d: unsupported non scalar key", key.Line)
+		}
+
+		if key.Tag == "!!merge" || (key.Value == yamlMergeKey && key.Tag != "!!str") {
+			merged = append(merged, value)
+			continue
+		}
+		add(key, value, true)
+	}
+
+	// Merged values never override explicit keys
+	for _, value := range merged {
+		if value.Kind == yaml.AliasNode {
+			value = value.Alias
+		}
+
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+
+		for _, source := range sources {
+			if source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind != yaml.MappingNode {
+				return nil, fmt.Errorf("yaml: line %d: map merge requires map or sequence of maps as the value", source.Line)
+			}
+
+			sourcePairs, err := yamlMappingPairs(source)
+			if err != nil {
+				return nil, err
+			}
+			for _, pair := range sourcePairs {
+				add(pair[0], pair[1], false)
+			}
+		}
+	}
+
+	return pairs, nil
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatFromPath(t *testing.T) {
+	for _, testcase := range []struct {
+		path   string
+		format Format
+	}{
+		{path: "./config.json", format: JSONFormat},
+		{path: "./config.jsonc", format: JSONFormat},
+		{path: "/config.YAML", format: YAMLFormat},
+		{path: "config.yml", format: YAMLFormat},
+		{path: "config", format: UnknownFormat},
+	} {
+		assert.Equal(t, testcase.format, FormatFromPath(testcase.path))
+	}
+}
+
+func TestFormatFromContentType(t *testing.T) {
+	for _, testcase := range []struct {
+		contentType string
+		format      Format
+	}{
+		{contentType: "application/json; charset=utf-8", format: JSONFormat},
+		{contentType: "application/x-yaml", format: YAMLFormat},
+		{contentType: "text/yaml", format: YAMLFormat},
+		{contentType: "text/plain", format: UnknownFormat},
+		{contentType: "", format: UnknownFormat},
+	} {
+		assert.Equal(t, testcase.format, FormatFromContentType(testcase.contentType))
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	assert.Equal(t, JSONFormat, detectFormat([]byte(`  {"columns": 4}`)))
+	assert.Equal(t, JSONFormat, detectFormat([]byte("// comment\n{}")))
+	assert.Equal(t, JSONFormat, detectFormat([]byte("")))
+	assert.Equal(t, YAMLFormat, detectFormat([]byte("# comment\ncolumns: 4")))
+}
+
+func TestStripJSONComments(t *testing.T) {
+	input := `{
+  // comment "with quotes"
+  "url": "http://example.com/*path*/", /* inline */
+  "tiles": [1, 2,],
+  /* multi
+     line */
+  "label": "a \" // b",
+}`
+	expected := `{
+` + strings.Repeat(" ", len(`  // comment "with quotes"`)) + `
+  "url": "http://example.com/*path*/",             
+  "tiles": [1, 2 ],
+          
+            
+  "label": "a \" // b" 
+}`
+
+	output := stripJSONComments([]byte(input))
+	assert.Equal(t, expected, string(output))
+	assert.Len(t, output, len(input))
+}
+
+func TestYAMLToJSON(t *testing.T) {
+	input := `
+version: 2.0
+columns: 4
+defaults: &ping
+  type: PING
+  columnSpan: 2
+tiles:
+  - <<: *ping
+    params: {hostname: server.com}
+  - <<: *ping
+    columnSpan: 1
+    label: ~
+  - type: EMPTY
+    params:
+      enabled: true
+      list: [1, "2"]
+`
+	output, err := yamlToJSON([]byte(input))
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"version":"2.0","columns":4,"defaults":{"type":"PING","columnSpan":2},"tiles":[`+
+			`{"params":{"hostname":"server.com"},"type":"PING","columnSpan":2},`+
+			`{"columnSpan":1,"label":null,"type":"PING"},`+
+			`{"type":"EMPTY","params":{"enabled":true,"list":[1,"2"]}}]}`, string(output))
+	}
+
+	output, err = yamlToJSON([]byte(""))
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(output))
+
+	_, err = yamlToJSON([]byte("columns: [4"))
+	assert.Error(t, err)
+
+	_, err = yamlToJSON([]byte("a: 1\n<<: 2"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config/models"
+
+	"github.com/labstack/gommon/log"
+)
+
+// GitCommandTimeout is the maximum duration of git commands (clone, fetch, ...)
+const GitCommandTimeout = 2 * time.Minute
+
+type (
+	// gitClone is a local mirror of repository, fetched at most once by fetch interval
+	gitClone struct {
+		sync.Mutex
+		repository string
+		dir        string
+		lastFetch  time.Time
+	}
+)
+
+// GetContentFromGit return content of config file at given ref of git repository, with its commit SHA.
+// Repository is cloned in git directory, then fetched periodically. Local clone is used when repository can't be fetched.
+// Credentials of https repositories come from git configuration (credential helper, ...)
+func (cr *configRepository) GetContentFromGit(source string) (*models.ConfigContent, error) {
+	gitSource, err := models.ParseGitSource(source)
+	if err != nil {
+		return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: source}
+	}
+
+	clone := cr.getGitClone(gitSource.Repository)
+	clone.Lock()
+	defer clone.Unlock()
+
+	if err := clone.update(cr.gitFetchInterval); err != nil {
+		if !clone.exists() {
+			return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: source}
+		}
+		log.Warnf("unable to fetch %s, using local clone. %v", gitSource.Repository, err)
+	}
+
+	ref := gitSource.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	commit, err := clone.git("rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return nil, &models.ConfigFileNotFoundError{Err: fmt.Errorf("unknown %q ref", ref), PathOrURL: source}
+	}
+	sha := strings.TrimSpace(string(commit))
+
+	content, err := clone.git("cat-file", "blob", fmt.Sprintf("%s:%s", sha, gitSource.Path))
+	if err != nil {
+		return nil, &models.ConfigFileNotFoundError{Err: fmt.Errorf("%s not found at %q ref", gitSource.Path, ref), PathOrURL: source}
+	}
+
+	return &models.ConfigContent{Content: content, Commit: sha}, nil
+}
+
+func (cr *configRepository) getGitClone(repository string) *gitClone {
+	cr.gitLock.Lock()
+	defer cr.gitLock.Unlock()
+
+	if clone, ok := cr.gitClones[repository]; ok {
+		return clone
+	}
+
+	sum := sha256.Sum256([]byte(repository))
+	clone := &gitClone{
+		repository: repository,
+		dir:        filepath.Join(cr.gitDir, hex.EncodeToString(sum[:8])+".git"),
+	}
+	cr.gitClones[repository] = clone
+
+	return clone
+}
+
+// update clone repository, or fetch it if last fetch is older than fetchInterval
+func (c *gitClone) update(fetchInterval time.Duration) error {
+	if c.exists() {
+		if time.Since(c.lastFetch) < fetchInterval {
+			return nil
+		}
+		c.lastFetch = time.Now() // Also on error, local clone is used until next fetch
+
+		_, err := c.git("fetch", "--prune", "--quiet")
+		return err
+	}
+
+	c.lastFetch = time.Now()
+	if err := os.MkdirAll(filepath.Dir(c.dir), 0755); err != nil {
+		return err
+	}
+
+	// Mirror keep every branches and tags, fetch update them all
+	if _, err := c.run(filepath.Dir(c.dir), "clone", "--mirror", "--quiet", "--", c.repository, c.dir); err != nil {
+		_ = os.RemoveAll(c.dir)
+		return err
+	}
+
+	return nil
+}
+
+func (c *gitClone) exists() bool {
+	_, err := os.Stat(filepath.Join(c.dir, "HEAD"))
+	return err == nil
+}
+
+func (c *gitClone) git(args ...string) ([]byte, error) {
+	return c.run(c.dir, args...)
+}
+
+func (c *gitClone) run(dir string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), GitCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0") // Never wait for credentials
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && len(exitError.Stderr) > 0 {
+			return nil, fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitError.Stderr)))
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+
+	return output, nil
+}
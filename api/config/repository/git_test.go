@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /!\ this is an integration test, git binary is required /!\
+
+func initGitRepository(t *testing.T) (dir string, commit func(file, content string) string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git binary not found")
+	}
+
+	dir, err := ioutil.TempDir("", "test-config-git-")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		output, err := cmd.CombinedOutput()
+		if !assert.NoError(t, err, string(output)) {
+			t.FailNow()
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	run("init", "--quiet")
+	run("checkout", "--quiet", "-b", "main")
+
+	return dir, func(file, content string) string {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0755))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(content), 0644))
+		run("add", file)
+		run("commit", "--quiet", "-m", "update "+file)
+		return run("rev-parse", "HEAD")
+	}
+}
+
+func initGitConfigRepository(t *testing.T, fetchInterval time.Duration) (*configRepository, func()) {
+	gitDir, err := ioutil.TempDir("", "test-config-git-clones-")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	repository := NewConfigRepository().(*configRepository)
+	repository.gitDir = gitDir
+	repository.gitFetchInterval = fetchInterval
+
+	return repository, func() { _ = os.RemoveAll(gitDir) }
+}
+
+func TestConfigRepository_GetContentFromGit(t *testing.T) {
+	dir, commit := initGitRepository(t)
+	defer os.RemoveAll(dir)
+	sha := commit("screens/screen1.json", `{"columns": 4}`)
+
+	repository, clean := initGitConfigRepository(t, 0)
+	defer clean()
+
+	for _, source := range []string{
+		fmt.Sprintf("git+file://%s#main:screens/screen1.json", dir),
+		fmt.Sprintf("git+file://%s#screens/screen1.json", dir),
+		fmt.Sprintf("git+file://%s#%s:/screens/screen1.json", dir, sha),
+	} {
+		content, err := repository.GetContentFromGit(source)
+		if assert.NoError(t, err, source) {
+			assert.Equal(t, `{"columns": 4}`, string(content.Content))
+			assert.Equal(t, sha, content.Commit)
+		}
+	}
+}
+
+func TestConfigRepository_GetContentFromGit_Fetch(t *testing.T) {
+	dir, commit := initGitRepository(t)
+	defer os.RemoveAll(dir)
+	commit("screen1.json", `{"columns": 4}`)
+	source := fmt.Sprintf("git+file://%s#main:screen1.json", dir)
+
+	repository, clean := initGitConfigRepository(t, time.Hour)
+	defer clean()
+
+	_, err := repository.GetContentFromGit(source)
+	assert.NoError(t, err)
+
+	// Not fetched before fetch interval
+	sha := commit("screen1.json", `{"columns": 2}`)
+	content, err := repository.GetContentFromGit(source)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"columns": 4}`, string(content.Content))
+	}
+
+	repository.gitFetchInterval = 0
+	content, err = repository.GetContentFromGit(source)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"columns": 2}`, string(content.Content))
+		assert.Equal(t, sha, content.Commit)
+	}
+
+	// Unavailable repository, local clone is used
+	assert.NoError(t, os.RemoveAll(dir))
+	content, err = repository.GetContentFromGit(source)
+	if assert.NoError(t, err) {
+		assert.Equal(t, `{"columns": 2}`, string(content.Content))
+	}
+}
+
+func TestConfigRepository_GetContentFromGit_Error(t *testing.T) {
+	dir, commit := initGitRepository(t)
+	defer os.RemoveAll(dir)
+	commit("screen1.json", `{"columns": 4}`)
+
+	repository, clean := initGitConfigRepository(t, 0)
+	defer clean()
+
+	for _, source := range []string{
+		"git+file:///monitoror/missing.git#main:screen1.json",
+		fmt.Sprintf("git+file://%s#unknown:screen1.json", dir),
+		fmt.Sprintf("git+file://%s#main:missing.json", dir),
+		fmt.Sprintf("git+file://%s", dir),
+	} {
+		_, err := repository.GetContentFromGit(source)
+		assert.IsType(t, &models.ConfigFileNotFoundError{}, err, source)
+	}
+}
@@ -1,19 +1,34 @@
 package repository
 
 import (
-	"net/http"
+	"bytes"
+	"net/url"
 
 	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
 )
 
-func (cr *configRepository) GetConfigFromURL(url string) (config *models.Config, err error) {
-	resp, err := cr.httpClient.Get(url)
-	if err != nil || resp.StatusCode != http.StatusOK {
-		return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: url}
+// GetConfigFromURL return config stored at url. configName is the named config being loaded (empty for other urls),
+// its RemoteConfig options are used for its url and urls hosted on the same server (see findRemoteConfig)
+func (cr *configRepository) GetConfigFromURL(rawURL string, configName coreConfig.ConfigName) (config *models.Config, err error) {
+	content, err := cr.fetch(rawURL, configName, nil)
+	if err != nil {
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	config, err = ReadConfig(resp.Body)
+	// Format from Content-Type, or from url extension (raw files are often served as text/plain)
+	format := FormatFromContentType(content.ContentType)
+	if format == UnknownFormat {
+		if contentURL, err := url.Parse(content.URL); err == nil {
+			format = FormatFromPath(contentURL.Path)
+		}
+	}
+
+	return ReadConfigWithFormat(bytes.NewReader(content.Content), format)
+}
 
-	return
+// GetContentFromURL return raw content of url. When previous is defined, a conditional request is sent
+// (ETag / Last-Modified) and previous is returned if content didn't change
+func (cr *configRepository) GetContentFromURL(rawURL string, configName coreConfig.ConfigName, previous *models.ConfigContent) (*models.ConfigContent, error) {
+	return cr.fetch(rawURL, configName, previous)
 }
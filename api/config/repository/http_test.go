@@ -20,13 +20,62 @@ func TestConfigRepository_GetConfigFromURL(t *testing.T) {
 	defer ts.Close()
 
 	repository := NewConfigRepository()
-	_, err := repository.GetConfigFromURL(ts.URL)
+	_, err := repository.GetConfigFromURL(ts.URL, "")
 	assert.NoError(t, err)
 }
 
 // TestConfigRepository_GetConfigFromURL test if http get works
 func TestConfigRepository_GetConfigFromURL_Error(t *testing.T) {
 	repository := NewConfigRepository()
-	_, err := repository.GetConfigFromURL("http://monitoror.example.com")
+	_, err := repository.GetConfigFromURL("http://monitoror.example.com", "")
+	assert.Error(t, err)
+}
+
+func TestConfigRepository_GetConfigFromURL_YAML(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-yaml")
+		_, _ = fmt.Fprintln(w, "columns: 4\ntiles: []")
+	}))
+	defer ts.Close()
+
+	repository := NewConfigRepository()
+	config, err := repository.GetConfigFromURL(ts.URL, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, *config.Columns)
+	}
+}
+
+func TestConfigRepository_GetContentFromURL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	repository := NewConfigRepository()
+	content, err := repository.GetContentFromURL(ts.URL, "", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte(`{}`), content.Content)
+		assert.Equal(t, `"v1"`, content.ETag)
+
+		// Not modified, previous content is returned
+		unchanged, err := repository.GetContentFromURL(ts.URL, "", content)
+		assert.NoError(t, err)
+		assert.True(t, content == unchanged)
+	}
+}
+
+func TestConfigRepository_GetContentFromURL_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	repository := NewConfigRepository()
+	_, err := repository.GetContentFromURL(ts.URL, "", nil)
 	assert.Error(t, err)
 }
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+
+	"gopkg.in/yaml.v3"
+)
+
+// migrateJSON apply registered migrations on JSON config, config is returned unchanged when already up to date
+func migrateJSON(data []byte) ([]byte, error) {
+	if !versions.HasMigrations() {
+		return data, nil
+	}
+
+	// Check version before parsing whole config in nodes
+	var header struct {
+		Version *versions.ConfigVersion `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil || header.Version == nil || !header.Version.IsLessThan(versions.CurrentVersion) {
+		return data, nil // Errors are raised by json decoder / Verify
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || len(document.Content) == 0 {
+		return data, nil
+	}
+
+	steps, err := versions.Migrate(&document)
+	if err != nil || len(steps) == 0 {
+		return data, err
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := writeYAMLNode(buffer, document.Content[0], true); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// MigrateConfig upgrade raw config to current version, keeping its format.
+// YAML comments are kept, JSON is indented and loses its comments.
+// Applied versions are returned (source version first), content is unchanged when config is already up to date
+func MigrateConfig(content []byte, format Format) ([]byte, []versions.RawVersion, error) {
+	if format == UnknownFormat {
+		format = detectFormat(content)
+	}
+
+	data := content
+	if format == JSONFormat {
+		data = stripJSONComments(content)
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, &models.ConfigUnmarshalError{Err: err, RawConfig: string(content)}
+	}
+	if len(document.Content) == 0 {
+		return content, nil, nil
+	}
+
+	steps, err := versions.Migrate(&document)
+	if err != nil || len(steps) == 0 {
+		return content, nil, err
+	}
+
+	buffer := &bytes.Buffer{}
+	if format == YAMLFormat {
+		encoder := yaml.NewEncoder(buffer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&document); err != nil {
+			return nil, nil, err
+		}
+		return buffer.Bytes(), steps, nil
+	}
+
+	if err := writeYAMLNode(buffer, document.Content[0], true); err != nil {
+		return nil, nil, err
+	}
+	indented := &bytes.Buffer{}
+	if err := json.Indent(indented, buffer.Bytes(), "", "  "); err != nil {
+		return nil, nil, err
+	}
+	indented.WriteByte('\n')
+	return indented.Bytes(), steps, nil
+}
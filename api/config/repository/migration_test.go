@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	// Fake migration, renaming "width" in "columns"
+	versions.RegisterMigration("1.0", versions.CurrentVersion, func(config *yaml.Node) error {
+		if versions.FieldNode(config, "error") != nil {
+			return errors.New("boom")
+		}
+		versions.RenameField(config, "width", "columns")
+		return nil
+	})
+}
+
+func TestReadConfig_WithMigration(t *testing.T) {
+	config, err := ReadConfig(strings.NewReader(`{"version": "1.0", "width": 4, "tiles": []}`))
+	if assert.NoError(t, err) {
+		assert.True(t, config.Version.IsEqualTo(versions.CurrentVersion))
+		assert.Equal(t, 4, *config.Columns)
+	}
+
+	// Up to date
+	config, err = ReadConfig(strings.NewReader(`{"version": "2.0", "columns": 3, "tiles": []}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, *config.Columns)
+	}
+
+	_, err = ReadConfig(strings.NewReader(`{"version": "1.0", "error": true}`))
+	assert.IsType(t, &versions.ConfigMigrationError{}, err)
+}
+
+func TestMigrateConfig(t *testing.T) {
+	for _, testcase := range []struct {
+		input    string
+		format   Format
+		expected string
+	}{
+		{
+			input: `{
+  // Comment
+  "version": "1.0", "width": 4,
+  "tiles": [{ "type": "EMPTY" }],
+}`,
+			format: JSONFormat,
+			expected: `{
+  "version": "2.0",
+  "columns": 4,
+  "tiles": [
+    {
+      "type": "EMPTY"
+    }
+  ]
+}
+`,
+		},
+		{
+			input: `# Comment
+version: 1.0
+width: 4 # Width
+tiles:
+  - type: EMPTY
+`,
+			format: UnknownFormat,
+			expected: `# Comment
+version: "2.0"
+columns: 4 # Width
+tiles:
+  - type: EMPTY
+`,
+		},
+	} {
+		migrated, steps, err := MigrateConfig([]byte(testcase.input), testcase.format)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []versions.RawVersion{"1.0", versions.CurrentVersion}, steps)
+			assert.Equal(t, testcase.expected, string(migrated))
+		}
+	}
+}
+
+func TestMigrateConfig_Unchanged(t *testing.T) {
+	input := `{"version": "2.0", "columns": 4}`
+	migrated, steps, err := MigrateConfig([]byte(input), JSONFormat)
+	if assert.NoError(t, err) {
+		assert.Nil(t, steps)
+		assert.Equal(t, input, string(migrated))
+	}
+
+	_, _, err = MigrateConfig([]byte("a: b: c"), YAMLFormat)
+	assert.IsType(t, &models.ConfigUnmarshalError{}, err)
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+
+	"github.com/labstack/gommon/log"
+)
+
+// RemoteConfigStoreKeyPrefix is used to store last fetched content of remote configs (see RemoteConfig.StaleIfError)
+const RemoteConfigStoreKeyPrefix = "monitoror.config.remoteConfig.key"
+
+type (
+	// remoteConfig contains fetch options of a named config loaded from url
+	remoteConfig struct {
+		configName coreConfig.ConfigName
+		url        *url.URL
+		options    *coreConfig.RemoteConfig
+		httpClient *http.Client
+	}
+)
+
+func newRemoteConfigs(config *coreConfig.CoreConfig) map[coreConfig.ConfigName]*remoteConfig {
+	remoteConfigs := make(map[coreConfig.ConfigName]*remoteConfig)
+	for configName, namedConfig := range config.NamedConfigs {
+		configURL, err := url.Parse(namedConfig)
+		if err != nil || (configURL.Scheme != "http" && configURL.Scheme != "https") {
+			continue
+		}
+
+		options := config.GetRemoteConfig(configName)
+		tr := &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: !options.SSLVerify},
+		}
+
+		remoteConfigs[configName] = &remoteConfig{
+			configName: configName,
+			url:        configURL,
+			options:    options,
+			httpClient: &http.Client{Transport: tr, Timeout: time.Duration(options.Timeout) * time.Millisecond},
+		}
+	}
+
+	return remoteConfigs
+}
+
+// findRemoteConfig return fetch options of configName when rawURL is its url, or is hosted on the same server (like its includes).
+// Other urls (and urls requested without named config) are fetched without options, credentials are never sent to unknown servers
+func (cr *configRepository) findRemoteConfig(configName coreConfig.ConfigName, rawURL string) *remoteConfig {
+	rc, ok := cr.remoteConfigs[configName]
+	if configName == "" || !ok {
+		return nil
+	}
+
+	requestURL, err := url.Parse(rawURL)
+	if err != nil || rc.url.Scheme != requestURL.Scheme || rc.url.Host != requestURL.Host {
+		return nil
+	}
+
+	return rc
+}
+
+// fetch return content of rawURL. When previous is defined, a conditional request is sent (ETag / Last-Modified)
+// and previous is returned if content didn't change.
+// Last fetched content of named configs is returned when url can't be fetched, during RemoteConfig.StaleIfError
+func (cr *configRepository) fetch(rawURL string, configName coreConfig.ConfigName, previous *models.ConfigContent) (*models.ConfigContent, error) {
+	rc := cr.findRemoteConfig(configName, rawURL)
+
+	content, err := cr.request(rc, rawURL, previous)
+
+	if rc == nil || rc.options.StaleIfError <= 0 || cr.cacheStore == nil {
+		return content, err
+	}
+
+	cacheKey := fmt.Sprintf("%s:%s:%s", RemoteConfigStoreKeyPrefix, configName, rawURL)
+	if err == nil {
+		_ = cr.cacheStore.Set(cacheKey, *content, time.Duration(rc.options.StaleIfError)*time.Millisecond)
+		return content, nil
+	}
+
+	stale := models.ConfigContent{}
+	if cacheErr := cr.cacheStore.Get(cacheKey, &stale); cacheErr != nil {
+		return nil, err
+	}
+	log.Warnf("unable to fetch %q named config, using last fetched content. %v", rc.configName, err)
+
+	return &stale, nil
+}
+
+func (cr *configRepository) request(rc *remoteConfig, rawURL string, previous *models.ConfigContent) (*models.ConfigContent, error) {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: rawURL}
+	}
+
+	httpClient := cr.httpClient
+	if rc != nil {
+		httpClient = rc.httpClient
+		setRemoteConfigHeaders(req, rc.options)
+	}
+
+	if previous != nil {
+		if previous.ETag != "" {
+			req.Header.Set("If-None-Match", previous.ETag)
+		}
+		if previous.LastModified != "" {
+			req.Header.Set("If-Modified-Since", previous.LastModified)
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: rawURL}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && previous != nil {
+		return previous, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &models.ConfigFileNotFoundError{Err: fmt.Errorf("unexpected status %s", resp.Status), PathOrURL: rawURL}
+	}
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: rawURL}
+	}
+
+	return &models.ConfigContent{
+		Content:      bytes,
+		ContentType:  resp.Header.Get("Content-Type"),
+		URL:          resp.Request.URL.String(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+func setRemoteConfigHeaders(req *http.Request, options *coreConfig.RemoteConfig) {
+	for _, header := range strings.Split(options.Headers, ",") {
+		splitHeader := strings.SplitN(header, ":", 2)
+		if len(splitHeader) != 2 || strings.TrimSpace(splitHeader[0]) == "" {
+			continue
+		}
+		req.Header.Set(strings.TrimSpace(splitHeader[0]), strings.TrimSpace(splitHeader[1]))
+	}
+
+	if options.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+options.Token)
+	} else if options.Username != "" {
+		req.SetBasicAuth(options.Username, options.Password)
+	}
+}
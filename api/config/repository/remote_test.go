@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func initRemoteConfigRepository(namedConfigs map[coreConfig.ConfigName]string, remoteConfigs map[coreConfig.ConfigName]*coreConfig.RemoteConfig) *configRepository {
+	config := &coreConfig.CoreConfig{NamedConfigs: namedConfigs, RemoteConfig: remoteConfigs}
+	return NewConfigRepositoryWithRemoteConfig(config, cache.NewGoCacheStore(time.Minute, time.Second)).(*configRepository)
+}
+
+func TestConfigRepository_RemoteConfig_Headers(t *testing.T) {
+	var headers http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	repository := initRemoteConfigRepository(
+		map[coreConfig.ConfigName]string{"screen1": ts.URL + "/screen1.json", "screen2": ts.URL + "/screen2.json"},
+		map[coreConfig.ConfigName]*coreConfig.RemoteConfig{
+			"screen1": {Token: "token", Headers: "X-Api-Key: xxx, X-Team:ops,invalid", SSLVerify: true, Timeout: 1000},
+			"screen2": {Username: "user", Password: "password", SSLVerify: true, Timeout: 1000},
+		},
+	)
+
+	_, err := repository.GetConfigFromURL(ts.URL+"/screen1.json", "screen1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Bearer token", headers.Get("Authorization"))
+		assert.Equal(t, "xxx", headers.Get("X-Api-Key"))
+		assert.Equal(t, "ops", headers.Get("X-Team"))
+	}
+
+	_, err = repository.GetConfigFromURL(ts.URL+"/screen2.json", "screen2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Basic dXNlcjpwYXNzd29yZA==", headers.Get("Authorization"))
+		assert.Empty(t, headers.Get("X-Api-Key"))
+	}
+
+	// Include of named config hosted on the same server
+	_, err = repository.GetContentFromURL(ts.URL+"/common.json", "screen2", nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Basic dXNlcjpwYXNzd29yZA==", headers.Get("Authorization"))
+	}
+
+	// Url requested without named config (like ?config=<url>), even url of a named config
+	for _, rawURL := range []string{ts.URL + "/screen1.json", ts.URL + "/common.json"} {
+		_, err = repository.GetConfigFromURL(rawURL, "")
+		if assert.NoError(t, err) {
+			assert.Empty(t, headers.Get("Authorization"))
+			assert.Empty(t, headers.Get("X-Api-Key"))
+		}
+	}
+}
+
+func TestConfigRepository_RemoteConfig_UnknownServer(t *testing.T) {
+	var headers http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	repository := initRemoteConfigRepository(
+		map[coreConfig.ConfigName]string{"default": "https://config.example.com/default.json"},
+		map[coreConfig.ConfigName]*coreConfig.RemoteConfig{"default": {Token: "token", SSLVerify: true}},
+	)
+
+	// Include of named config hosted on another server
+	_, err := repository.GetConfigFromURL(ts.URL+"/config.json", "default")
+	if assert.NoError(t, err) {
+		assert.Empty(t, headers.Get("Authorization"))
+	}
+}
+
+func TestConfigRepository_RemoteConfig_SSLVerify(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	for sslVerify, expectError := range map[bool]bool{true: true, false: false} {
+		repository := initRemoteConfigRepository(
+			map[coreConfig.ConfigName]string{"default": ts.URL},
+			map[coreConfig.ConfigName]*coreConfig.RemoteConfig{"default": {SSLVerify: sslVerify}},
+		)
+
+		_, err := repository.GetConfigFromURL(ts.URL, "default")
+		assert.Equal(t, expectError, err != nil)
+	}
+}
+
+func TestConfigRepository_RemoteConfig_Timeout(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(time.Millisecond * 200)
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer ts.Close()
+
+	repository := initRemoteConfigRepository(
+		map[coreConfig.ConfigName]string{"default": ts.URL},
+		map[coreConfig.ConfigName]*coreConfig.RemoteConfig{"default": {SSLVerify: true, Timeout: 50}},
+	)
+
+	_, err := repository.GetConfigFromURL(ts.URL, "default")
+	assert.Error(t, err)
+}
+
+func TestConfigRepository_RemoteConfig_StaleIfError(t *testing.T) {
+	var failing int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-yaml")
+		_, _ = fmt.Fprint(w, "columns: 4")
+	}))
+	defer ts.Close()
+
+	for staleIfError, expectError := range map[int]bool{0: true, 60000: false} {
+		atomic.StoreInt32(&failing, 0)
+		repository := initRemoteConfigRepository(
+			map[coreConfig.ConfigName]string{"default": ts.URL},
+			map[coreConfig.ConfigName]*coreConfig.RemoteConfig{"default": {SSLVerify: true, StaleIfError: staleIfError}},
+		)
+
+		_, err := repository.GetConfigFromURL(ts.URL, "default")
+		assert.NoError(t, err)
+
+		atomic.StoreInt32(&failing, 1)
+		config, err := repository.GetConfigFromURL(ts.URL, "default")
+		if assert.Equal(t, expectError, err != nil) && !expectError {
+			assert.Equal(t, 4, *config.Columns)
+		}
+	}
+}
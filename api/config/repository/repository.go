@@ -5,29 +5,84 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/monitoror/monitoror/api/config"
 	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
 	configRepository struct {
+		// httpClient is used for urls which aren't named configs
 		httpClient *http.Client
+
+		// remoteConfigs contains fetch options of named configs loaded from url
+		remoteConfigs map[coreConfig.ConfigName]*remoteConfig
+		// cacheStore keep last fetched content of named configs loaded from url, nil to disable stale-if-error
+		cacheStore cache.Store
+
+		// gitDir contains clones of repositories used by configs loaded from git
+		gitDir           string
+		gitFetchInterval time.Duration
+		gitLock          sync.Mutex
+		gitClones        map[string]*gitClone
 	}
 )
 
+// NewConfigRepository return repository fetching urls without options, used by commands
 func NewConfigRepository() config.Repository {
-	//TODO: Add possibility to disable SSL check?
-	return &configRepository{httpClient: http.DefaultClient}
+	return &configRepository{
+		httpClient: http.DefaultClient,
+		gitDir:     filepath.Join(os.TempDir(), "monitoror-git"),
+		gitClones:  make(map[string]*gitClone),
+	}
+}
+
+// NewConfigRepositoryWithRemoteConfig return repository fetching named configs (and their includes) with their
+// RemoteConfig options (auth, headers, SSL, timeout). Last fetched contents are kept in cacheStore.
+// Git repositories are cloned in ConfigGitDir and fetched every ConfigPollInterval
+func NewConfigRepositoryWithRemoteConfig(coreConfig *coreConfig.CoreConfig, cacheStore cache.Store) config.Repository {
+	return &configRepository{
+		httpClient:       http.DefaultClient,
+		remoteConfigs:    newRemoteConfigs(coreConfig),
+		cacheStore:       cacheStore,
+		gitDir:           path.ToAbsolute(path.MonitororBaseDir, coreConfig.ConfigGitDir),
+		gitFetchInterval: time.Duration(coreConfig.ConfigPollInterval) * time.Millisecond,
+		gitClones:        make(map[string]*gitClone),
+	}
 }
 
+// ReadConfig read JSON or YAML config, format is detected from content
 func ReadConfig(reader io.Reader) (config *models.Config, err error) {
+	return ReadConfigWithFormat(reader, UnknownFormat)
+}
+
+// ReadConfigWithFormat read config in given format (JSON with comments or YAML).
+// Config is always converted in JSON before parsing, to keep the same errors (unknown fields, type mismatch, ...)
+func ReadConfigWithFormat(reader io.Reader, format Format) (config *models.Config, err error) {
 	bytes, err := ioutil.ReadAll(reader)
 	if err != nil {
 		return
 	}
 
-	if err = json.Unmarshal(bytes, &config); err != nil {
+	jsonBytes, err := toJSON(bytes, format)
+	if err != nil {
+		return nil, &models.ConfigUnmarshalError{Err: err, RawConfig: string(bytes)}
+	}
+
+	// Upgrade old config versions
+	if jsonBytes, err = migrateJSON(jsonBytes); err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(jsonBytes, &config); err != nil {
 		err = &models.ConfigUnmarshalError{Err: err, RawConfig: string(bytes)}
 	}
 
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"testing/iotest"
 
+	"github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/api/config/versions"
 
 	"github.com/stretchr/testify/assert"
@@ -95,3 +96,77 @@ func TestRepository_ReadConfig_Error_WrongReader(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, "timeout")
 }
+
+func TestRepository_ReadConfig_WithComments(t *testing.T) {
+	input := `
+// Dashboard of team 1
+{
+  "version": "2.0",
+  "columns": 4, /* 4 columns on TV */
+  "tiles": [
+    { "type": "EMPTY" },
+    { "type": "PING", "params": { "hostname": "server.com" }}, // Production
+  ],
+}
+`
+	config, err := ReadConfig(strings.NewReader(input))
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, *config.Columns)
+		assert.Len(t, config.Tiles, 2)
+	}
+}
+
+func TestRepository_ReadConfig_YAML(t *testing.T) {
+	input := `
+# Dashboard of team 1
+version: 2.0
+columns: 4
+tiles:
+  - type: EMPTY
+  - type: PING
+    label: Production
+    params:
+      hostname: server.com
+  - type: GROUP
+    tiles:
+      - { type: PORT, params: { hostname: bserver.com, port: 22 } }
+`
+	config, err := ReadConfigWithFormat(strings.NewReader(input), YAMLFormat)
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, versions.RawVersion("2.0"), config.Version.ToRawVersion())
+		assert.Equal(t, 4, *config.Columns)
+		if assert.Len(t, config.Tiles, 3) {
+			assert.Equal(t, "Production", config.Tiles[1].Label)
+			assert.Equal(t, "server.com", config.Tiles[1].Params["hostname"])
+			assert.Equal(t, float64(22), config.Tiles[2].Tiles[0].Params["port"])
+		}
+	}
+}
+
+func TestRepository_ReadConfig_YAML_Error(t *testing.T) {
+	for _, testcase := range []struct {
+		input string
+		err   string
+	}{
+		{
+			input: "version: \"2.0\"\ncolumns: 4\ntiles:\n  - type: EMPTY\n    unknown: true\n",
+			err:   `json: unknown field "unknown"`,
+		},
+		{
+			input: "version: \"2.0\"\ncolumns: \"4\"\ntiles: []\n",
+			err:   "json: cannot unmarshal string into Go struct field Config.columns of type int",
+		},
+		{
+			input: "version: \"2.0\"\ncolumns: 4\ntiles: [\n",
+			err:   "yaml: line 3: did not find expected node content",
+		},
+	} {
+		_, err := ReadConfig(strings.NewReader(testcase.input))
+		if assert.Error(t, err) {
+			assert.EqualError(t, err, testcase.err)
+			assert.Equal(t, testcase.input, err.(*models.ConfigUnmarshalError).RawConfig)
+		}
+	}
+}
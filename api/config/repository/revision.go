@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config"
+	"github.com/monitoror/monitoror/api/config/models"
+)
+
+// revisionIDFormat is sortable, used as file name
+const revisionIDFormat = "20060102T150405.000000000Z"
+
+var revisionIDRegex = regexp.MustCompile(`^\d{8}T\d{6}\.\d{9}Z$`)
+
+type (
+	// revisionRepository save revisions in files: <dir>/<config>/<id>.json
+	revisionRepository struct {
+		dir   string
+		limit int
+
+		// lock protect revisions creation and cleanup
+		lock sync.Mutex
+	}
+
+	revisionFile struct {
+		models.ConfigRevision
+		Content string `json:"content"`
+	}
+)
+
+func NewRevisionRepository(dir string, limit int) config.RevisionRepository {
+	return &revisionRepository{dir: dir, limit: limit}
+}
+
+// GetRevisions return revisions of named config, newest first
+func (rr *revisionRepository) GetRevisions(configName string) ([]models.ConfigRevision, error) {
+	ids, err := rr.revisionIDs(configName)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []models.ConfigRevision{}
+	for _, id := range ids {
+		file, err := rr.readRevision(configName, id)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, file.ConfigRevision)
+	}
+
+	return revisions, nil
+}
+
+func (rr *revisionRepository) GetRevision(configName, revisionID string) (*models.ConfigRevision, []byte, error) {
+	if !revisionIDRegex.MatchString(revisionID) {
+		return nil, nil, &models.ConfigRevisionNotFoundError{Config: configName, Revision: revisionID}
+	}
+
+	file, err := rr.readRevision(configName, revisionID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, &models.ConfigRevisionNotFoundError{Config: configName, Revision: revisionID}
+		}
+		return nil, nil, err
+	}
+
+	return &file.ConfigRevision, []byte(file.Content), nil
+}
+
+// AddRevision save a new revision, ID, date and hash are set by repository. Older revisions are removed over limit
+func (rr *revisionRepository) AddRevision(revision *models.ConfigRevision, content []byte) (*models.ConfigRevision, error) {
+	rr.lock.Lock()
+	defer rr.lock.Unlock()
+
+	configDir := filepath.Join(rr.dir, revision.Config)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return nil, err
+	}
+
+	hash := sha256.Sum256(content)
+	file := &revisionFile{ConfigRevision: *revision, Content: string(content)}
+	file.Date = time.Now().UTC()
+	file.Hash = hex.EncodeToString(hash[:])
+
+	// ID must be unique and greater than previous ones
+	file.ID = file.Date.Format(revisionIDFormat)
+	if ids, err := rr.revisionIDs(revision.Config); err == nil && len(ids) > 0 && ids[0] >= file.ID {
+		last, _ := time.Parse(revisionIDFormat, ids[0])
+		file.Date = last.Add(time.Nanosecond)
+		file.ID = file.Date.Format(revisionIDFormat)
+	}
+
+	bytes, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(filepath.Join(configDir, file.ID+".json"), bytes, 0644); err != nil {
+		return nil, err
+	}
+
+	if err := rr.cleanup(revision.Config); err != nil {
+		return nil, err
+	}
+
+	return &file.ConfigRevision, nil
+}
+
+// revisionIDs return revision IDs of named config, newest first
+func (rr *revisionRepository) revisionIDs(configName string) ([]string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(rr.dir, configName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var ids []string
+	for _, file := range files {
+		id := strings.TrimSuffix(file.Name(), ".json")
+		if !file.IsDir() && revisionIDRegex.MatchString(id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+
+	return ids, nil
+}
+
+func (rr *revisionRepository) readRevision(configName, revisionID string) (*revisionFile, error) {
+	bytes, err := ioutil.ReadFile(filepath.Join(rr.dir, configName, revisionID+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	file := &revisionFile{}
+	if err := json.Unmarshal(bytes, file); err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+func (rr *revisionRepository) cleanup(configName string) error {
+	if rr.limit <= 0 {
+		return nil
+	}
+
+	ids, err := rr.revisionIDs(configName)
+	if err != nil {
+		return err
+	}
+
+	for len(ids) > rr.limit {
+		if err := os.Remove(filepath.Join(rr.dir, configName, ids[len(ids)-1]+".json")); err != nil {
+			return err
+		}
+		ids = ids[:len(ids)-1]
+	}
+
+	return nil
+}
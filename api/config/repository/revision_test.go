@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevisionRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-revisions-")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	repository := NewRevisionRepository(dir, 2)
+
+	revisions, err := repository.GetRevisions("screen1")
+	if assert.NoError(t, err) {
+		assert.Len(t, revisions, 0)
+	}
+
+	first, err := repository.AddRevision(&models.ConfigRevision{Config: "screen1", Author: "alice"}, []byte(`{"columns": 1}`))
+	assert.NoError(t, err)
+	second, err := repository.AddRevision(&models.ConfigRevision{Config: "screen1", Author: "bob"}, []byte(`{"columns": 2}`))
+	assert.NoError(t, err)
+	third, err := repository.AddRevision(&models.ConfigRevision{Config: "screen1", RestoredFrom: second.ID}, []byte(`{"columns": 3}`))
+	assert.NoError(t, err)
+
+	assert.True(t, first.ID < second.ID)
+	assert.True(t, second.ID < third.ID)
+	assert.Equal(t, "bob", second.Author)
+	assert.Len(t, second.Hash, 64)
+
+	// Oldest revision is removed over limit
+	revisions, err = repository.GetRevisions("screen1")
+	if assert.NoError(t, err) && assert.Len(t, revisions, 2) {
+		assert.Equal(t, *third, revisions[0])
+		assert.Equal(t, *second, revisions[1])
+	}
+
+	revision, content, err := repository.GetRevision("screen1", second.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, second.ID, revision.ID)
+		assert.Equal(t, `{"columns": 2}`, string(content))
+	}
+
+	for _, id := range []string{first.ID, "../../etc/passwd", "unknown"} {
+		_, _, err = repository.GetRevision("screen1", id)
+		assert.IsType(t, &models.ConfigRevisionNotFoundError{}, err)
+	}
+}
@@ -0,0 +1,15 @@
+//go:generate mockery -name RevisionRepository
+
+package config
+
+import (
+	"github.com/monitoror/monitoror/api/config/models"
+)
+
+type (
+	RevisionRepository interface {
+		GetRevisions(configName string) ([]models.ConfigRevision, error)
+		GetRevision(configName, revisionID string) (*models.ConfigRevision, []byte, error)
+		AddRevision(revision *models.ConfigRevision, content []byte) (*models.ConfigRevision, error)
+	}
+)
@@ -4,13 +4,26 @@ package config
 
 import (
 	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/internal/pkg/jsonschema"
 )
 
 type (
 	Usecase interface {
 		GetConfigList() []models.ConfigMetadata
 		GetConfig(params *models.ConfigParams) *models.ConfigBag
+		GetConfigFromContent(content []byte, location string) *models.ConfigBag
+		ValidateConfig(content []byte) *models.ConfigBag
 		Verify(config *models.ConfigBag)
 		Hydrate(config *models.ConfigBag)
+
+		SaveConfig(params *models.ConfigParams, content []byte, author string) (*models.ConfigBag, *models.ConfigRevision)
+		GetConfigRevisions(params *models.ConfigParams) ([]models.ConfigRevision, *models.ConfigError)
+		RestoreConfigRevision(params *models.ConfigParams, revisionID, author string) (*models.ConfigBag, *models.ConfigRevision)
+
+		GetConfigHash(params *models.ConfigParams) (*models.ConfigHash, *models.ConfigError)
+		StartWatching()
+		StopWatching()
+
+		GetSchema() *jsonschema.Schema
 	}
 )
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+// GetConfigList return named configs with a health summary. Configs are loaded and verified concurrently
+func (cu *configUsecase) GetConfigList() []models.ConfigMetadata {
+	var configNames []string
+	for configName := range cu.namedConfigs {
+		configNames = append(configNames, string(configName))
+	}
+	sort.Strings(configNames)
+
+	configList := make([]models.ConfigMetadata, len(configNames))
+
+	wg := sync.WaitGroup{}
+	for i, configName := range configNames {
+		wg.Add(1)
+		go func(i int, configName coreConfig.ConfigName) {
+			defer wg.Done()
+			configList[i] = cu.getConfigMetadata(configName)
+		}(i, coreConfig.ConfigName(configName))
+	}
+	wg.Wait()
+
+	return configList
+}
+
+func (cu *configUsecase) getConfigMetadata(configName coreConfig.ConfigName) models.ConfigMetadata {
+	namedConfig := cu.namedConfigs[configName]
+
+	metadata := models.ConfigMetadata{
+		Name:     string(configName),
+		Source:   configSource(namedConfig),
+		Location: configLocation(namedConfig),
+		LastLoad: cu.getLastLoad(configName),
+	}
+
+	if configHash, err := cu.GetConfigHash(&models.ConfigParams{Config: string(configName)}); err == nil {
+		metadata.Hash = configHash.Hash
+	}
+
+	// Same steps as UI loading, without hydrate (generators could be slow)
+	configBag := &models.ConfigBag{}
+	location, err := cu.getNamedConfig(configBag, configName)
+	if err != nil {
+		cu.addRepositoryError(configBag, err)
+	} else if configBag.Config != nil {
+		cu.expand(configBag, configName, location, true)
+		if len(configBag.Errors) == 0 {
+			cu.Verify(configBag)
+		}
+	}
+
+	metadata.ErrorCount = len(configBag.Errors)
+	if configBag.Config != nil {
+		metadata.TileCount, metadata.TileTypes = summarizeTiles(configBag.Config)
+	}
+
+	return metadata
+}
+
+// summarizeTiles return number of tiles (without groups and empty tiles) and sorted tile types used in config
+func summarizeTiles(config *models.Config) (int, []coreModels.TileType) {
+	count := 0
+	tileTypes := make(map[coreModels.TileType]bool)
+
+	var walk func(tiles []models.TileConfig)
+	walk = func(tiles []models.TileConfig) {
+		for _, tile := range tiles {
+			switch tile.Type {
+			case "", EmptyTileType:
+			case GroupTileType:
+				walk(tile.Tiles)
+			default:
+				count++
+				tileTypes[tile.Type] = true
+			}
+		}
+	}
+	walk(config.Tiles)
+	for _, page := range config.Pages {
+		walk(page.Tiles)
+	}
+	for _, schedule := range config.Schedule {
+		walk(schedule.Tiles)
+	}
+
+	var result []coreModels.TileType
+	for tileType := range tileTypes {
+		result = append(result, tileType)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+
+	return count, result
+}
+
+func configSource(namedConfig string) models.ConfigSource {
+	if urlRegex.MatchString(namedConfig) {
+		return models.ConfigSourceURL
+	}
+	if models.IsGitSource(namedConfig) {
+		return models.ConfigSourceGit
+	}
+	return models.ConfigSourcePath
+}
+
+// configLocation return absolute path of named config, or its url without user info and query (they can contain credentials)
+func configLocation(namedConfig string) string {
+	prefix := ""
+	rawURL := namedConfig
+	if models.IsGitSource(namedConfig) {
+		prefix = models.GitSourcePrefix
+		rawURL = strings.TrimPrefix(namedConfig, models.GitSourcePrefix)
+	} else if !urlRegex.MatchString(namedConfig) {
+		return path.ToAbsolute(path.MonitororBaseDir, namedConfig)
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+
+	return prefix + u.String()
+}
+
+func (cu *configUsecase) setLastLoad(configName coreConfig.ConfigName) {
+	cu.lastLoadLock.Lock()
+	defer cu.lastLoadLock.Unlock()
+
+	cu.lastLoads[configName] = cu.now()
+}
+
+func (cu *configUsecase) getLastLoad(configName coreConfig.ConfigName) *time.Time {
+	cu.lastLoadLock.RLock()
+	defer cu.lastLoadLock.RUnlock()
+
+	if lastLoad, ok := cu.lastLoads[configName]; ok {
+		return &lastLoad
+	}
+	return nil
+}
@@ -0,0 +1,444 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	pkgConfig "github.com/monitoror/monitoror/internal/pkg/api/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+	"github.com/monitoror/monitoror/pkg/humanize"
+)
+
+// variableRegex match "${name}", "$${name}" is used to escape variable
+var variableRegex = regexp.MustCompile(`\$?\$\{([A-Za-z0-9_.-]+)\}`)
+
+type expander struct {
+	configBag *models.ConfigBag
+	templates map[string]models.TileConfig
+}
+
+// expand resolve includes, templates and variables before Verify.
+// location (path or url of config) is used to resolve relative includes.
+// configName is the named config being expanded (empty otherwise), its RemoteConfig options are used for included urls.
+// When localIncludes is false, includes targeting local files or repositories are refused (see isRemoteLocation),
+// they are always refused for configs loaded from url or remote git repository
+func (cu *configUsecase) expand(configBag *models.ConfigBag, configName coreConfig.ConfigName, location string, localIncludes bool) {
+	config := configBag.Config
+	localIncludes = localIncludes && !isRemoteLocation(location)
+
+	variables := make(map[string]interface{})
+	for name, value := range config.Variables {
+		variables[name] = value
+	}
+	templates := make(map[string]models.TileConfig)
+	for name, template := range config.Templates {
+		templates[name] = template
+	}
+
+	if !cu.loadIncludes(configBag, configName, location, localIncludes, config.Include, variables, templates, []string{location}) {
+		return
+	}
+
+	e := &expander{configBag: configBag, templates: templates}
+	for i := range config.Tiles {
+		e.expandTile(&config.Tiles[i], variables, "", nil)
+	}
+	for i := range config.Pages {
+		for j := range config.Pages[i].Tiles {
+			e.expandTile(&config.Pages[i].Tiles[j], variables, "", nil)
+		}
+	}
+	for i := range config.Schedule {
+		schedule := &config.Schedule[i]
+		if schedule.When != nil && !e.expandCondition(schedule.When, variables, pkgConfig.Stringify(schedule)) {
+			continue
+		}
+		for j := range schedule.Tiles {
+			e.expandTile(&schedule.Tiles[j], variables, "", nil)
+		}
+	}
+
+	config.Variables = nil
+	config.Templates = nil
+	config.Include = nil
+}
+
+// loadIncludes add variables and templates of included files. First definition win (config, then includes in order)
+func (cu *configUsecase) loadIncludes(configBag *models.ConfigBag, configName coreConfig.ConfigName, location string, localIncludes bool, includes []string,
+	variables map[string]interface{}, templates map[string]models.TileConfig, stack []string) bool {
+	for _, include := range includes {
+		includeLocation := resolveLocation(location, include)
+
+		if !localIncludes && !isRemoteLocation(includeLocation) {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorInvalidFieldValue,
+				Message: fmt.Sprintf(`Invalid %q include. Only remote files can be included here`, include),
+				Data: models.ConfigErrorData{
+					FieldName: "include",
+					Value:     include,
+					Expected:  `http(s) url or "git+http(s)://" source`,
+				},
+			})
+			return false
+		}
+
+		for _, previous := range stack {
+			if previous == includeLocation {
+				configBag.AddErrors(models.ConfigError{
+					ID:      models.ConfigErrorCircularReference,
+					Message: fmt.Sprintf(`Circular include of %q. Included files can't include themselves`, include),
+					Data: models.ConfigErrorData{
+						FieldName: "include",
+						Value:     include,
+					},
+				})
+				return false
+			}
+		}
+
+		var included *models.Config
+		var err error
+		if urlRegex.MatchString(includeLocation) {
+			included, err = cu.repository.GetConfigFromURL(includeLocation, configName)
+		} else if models.IsGitSource(includeLocation) {
+			included, _, err = cu.getConfigFromGit(includeLocation)
+		} else {
+			included, err = cu.repository.GetConfigFromPath("", includeLocation)
+		}
+		if err != nil {
+			// Content of included file is never returned, include could target any local file
+			if e, ok := err.(*models.ConfigUnmarshalError); ok {
+				configBag.AddErrors(models.ConfigError{
+					ID:      models.ConfigErrorUnableToParseConfig,
+					Message: fmt.Sprintf(`Unable to parse %q included file, %v`, include, e),
+					Data: models.ConfigErrorData{
+						FieldName: "include",
+						Value:     include,
+					},
+				})
+				return false
+			}
+
+			cu.addRepositoryError(configBag, err)
+			return false
+		}
+		if included == nil {
+			continue
+		}
+
+		for name, value := range included.Variables {
+			if _, exists := variables[name]; !exists {
+				variables[name] = value
+			}
+		}
+		for name, template := range included.Templates {
+			if _, exists := templates[name]; !exists {
+				templates[name] = template
+			}
+		}
+
+		if !cu.loadIncludes(configBag, configName, includeLocation, localIncludes && !isRemoteLocation(includeLocation), included.Include, variables, templates, append(stack, includeLocation)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// resolveLocation return absolute path, url or git source of include, relative to location of parent config
+func resolveLocation(location, include string) string {
+	if urlRegex.MatchString(include) || models.IsGitSource(include) {
+		return include
+	}
+
+	// Relative to config path in the same repository and ref
+	if models.IsGitSource(location) {
+		gitSource, err := models.ParseGitSource(location)
+		if err != nil {
+			return include
+		}
+		return gitSource.Resolve(include).String()
+	}
+
+	// Relative to config url (including "/path" relative to host)
+	if urlRegex.MatchString(location) {
+		base, err := url.Parse(location)
+		if err != nil {
+			return include
+		}
+		reference, err := url.Parse(include)
+		if err != nil {
+			return include
+		}
+		return base.ResolveReference(reference).String()
+	}
+
+	if filepath.IsAbs(include) {
+		return include
+	}
+	if location == "" {
+		return path.ToAbsolute(path.MonitororBaseDir, include)
+	}
+	return filepath.Join(filepath.Dir(location), include)
+}
+
+// isRemoteLocation return true for urls and git sources of remote repositories
+func isRemoteLocation(location string) bool {
+	if urlRegex.MatchString(location) {
+		return true
+	}
+	if models.IsGitSource(location) {
+		gitSource, err := models.ParseGitSource(location)
+		return err == nil && urlRegex.MatchString(gitSource.Repository)
+	}
+	return false
+}
+
+// expandTile replace template by its content, then variables. origin is the extract of tile using template (empty outside of template)
+func (e *expander) expandTile(tile *models.TileConfig, variables map[string]interface{}, origin string, templateStack []string) bool {
+	extract := origin
+	if extract == "" {
+		extract = tile.Extract()
+	}
+
+	if tile.Template != "" {
+		name := tile.Template
+
+		for _, previous := range templateStack {
+			if previous == name {
+				e.configBag.AddErrors(models.ConfigError{
+					ID:      models.ConfigErrorCircularReference,
+					Message: fmt.Sprintf(`Circular reference of %q template. Templates can't use themselves`, name),
+					Data: models.ConfigErrorData{
+						FieldName:     "template",
+						Value:         name,
+						ConfigExtract: extract,
+					},
+				})
+				return false
+			}
+		}
+
+		template, ok := e.templates[name]
+		if !ok {
+			e.configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorUnknownTemplate,
+				Message: fmt.Sprintf(`Unknown %q template. Must be defined in "templates" of config or included files`, name),
+				Data: models.ConfigErrorData{
+					FieldName:     "template",
+					Value:         name,
+					ConfigExtract: extract,
+					Expected:      keys(e.templates),
+				},
+			})
+			return false
+		}
+
+		// Template variables, "with" values can use config variables
+		with, ok := e.substitute(tile.With, variables, extract)
+		if !ok {
+			return false
+		}
+		scope := make(map[string]interface{})
+		for key, value := range variables {
+			scope[key] = value
+		}
+		for key, value := range with.(map[string]interface{}) {
+			scope[key] = value
+		}
+
+		*tile = mergeTemplate(template, tile)
+		return e.expandTile(tile, scope, extract, append(templateStack, name))
+	}
+
+	changed := false
+	for _, field := range []*string{&tile.Label, (*string)(&tile.Type), (*string)(&tile.ConfigVariant), &tile.URL} {
+		value, ok := e.substitute(*field, variables, extract)
+		if !ok {
+			return false
+		}
+		str := humanize.Interface(value)
+		changed = changed || str != *field
+		*field = str
+	}
+
+	if tile.Params != nil {
+		params, ok := e.substitute(tile.Params, variables, extract)
+		if !ok {
+			return false
+		}
+		changed = changed || pkgConfig.Stringify(params) != pkgConfig.Stringify(tile.Params)
+		tile.Params = params.(map[string]interface{})
+	}
+
+	if tile.When != nil && !e.expandCondition(tile.When, variables, extract) {
+		return false
+	}
+
+	if origin != "" || changed {
+		tile.SetOrigin(extract)
+	}
+	tile.With = nil
+
+	for i := range tile.Tiles {
+		if !e.expandTile(&tile.Tiles[i], variables, origin, templateStack) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// substitute replace variables in strings of value (string, map or array)
+// A string only containing one variable is replaced by the variable value (keeping number, boolean, ...)
+func (e *expander) substitute(value interface{}, variables map[string]interface{}, extract string) (interface{}, bool) {
+	switch v := value.(type) {
+	case string:
+		if match := variableRegex.FindStringSubmatch(v); match != nil && match[0] == v && !strings.HasPrefix(v, "$$") {
+			variable, ok := variables[match[1]]
+			if !ok {
+				e.addUnknownVariableError(match[1], match[0], variables, extract)
+				return nil, false
+			}
+			return variable, true
+		}
+
+		ok := true
+		result := variableRegex.ReplaceAllStringFunc(v, func(match string) string {
+			if strings.HasPrefix(match, "$$") {
+				return match[1:]
+			}
+			name := variableRegex.FindStringSubmatch(match)[1]
+			variable, exists := variables[name]
+			if !exists {
+				if ok {
+					e.addUnknownVariableError(name, match, variables, extract)
+				}
+				ok = false
+				return match
+			}
+			return humanize.Interface(variable)
+		})
+		return result, ok
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			substituted, ok := e.substitute(item, variables, extract)
+			if !ok {
+				return nil, false
+			}
+			result[key] = substituted
+		}
+		return result, true
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			substituted, ok := e.substitute(item, variables, extract)
+			if !ok {
+				return nil, false
+			}
+			result[i] = substituted
+		}
+		return result, true
+	case nil:
+		return map[string]interface{}{}, true
+	default:
+		return v, true
+	}
+}
+
+// expandCondition replace variables in time and equals fields, then resolve value of condition variable
+func (e *expander) expandCondition(condition *models.TileCondition, variables map[string]interface{}, extract string) bool {
+	value, ok := e.substitute(condition.Time, variables, extract)
+	if !ok {
+		return false
+	}
+	condition.Time = humanize.Interface(value)
+
+	if condition.Equals != nil {
+		if condition.Equals, ok = e.substitute(condition.Equals, variables, extract); !ok {
+			return false
+		}
+	}
+
+	if condition.Variable != "" {
+		variable, exists := variables[condition.Variable]
+		if !exists {
+			e.addUnknownVariableError(condition.Variable, condition.Variable, variables, extract)
+			return false
+		}
+		condition.SetVariableValue(variable)
+	}
+
+	return true
+}
+
+// addUnknownVariableError add error on unknown variable, highlight is the variable reference in extract
+func (e *expander) addUnknownVariableError(name, highlight string, variables map[string]interface{}, extract string) {
+	e.configBag.AddErrors(models.ConfigError{
+		ID:      models.ConfigErrorUnknownVariable,
+		Message: fmt.Sprintf(`Unknown %q variable. Must be defined in "variables" of config, included files or in "with" of template`, name),
+		Data: models.ConfigErrorData{
+			Value:                  name,
+			ConfigExtract:          extract,
+			ConfigExtractHighlight: highlight,
+			Expected:               keys(variables),
+		},
+	})
+}
+
+// mergeTemplate return a copy of template, overridden by fields of tile using it. Params are merged
+func mergeTemplate(template models.TileConfig, tile *models.TileConfig) models.TileConfig {
+	// Deep copy, template can be used many times
+	var result models.TileConfig
+	bytes, _ := json.Marshal(template)
+	_ = json.Unmarshal(bytes, &result)
+
+	if tile.Type != "" {
+		result.Type = tile.Type
+	}
+	if tile.Label != "" {
+		result.Label = tile.Label
+	}
+	if tile.RowSpan != nil {
+		result.RowSpan = tile.RowSpan
+	}
+	if tile.ColumnSpan != nil {
+		result.ColumnSpan = tile.ColumnSpan
+	}
+	if tile.InitialMaxDelay != nil {
+		result.InitialMaxDelay = tile.InitialMaxDelay
+	}
+	if tile.ConfigVariant != "" {
+		result.ConfigVariant = tile.ConfigVariant
+	}
+	if len(tile.Tiles) > 0 {
+		result.Tiles = tile.Tiles
+	}
+	if tile.When != nil {
+		result.When = tile.When
+	}
+	if len(tile.Params) > 0 {
+		if result.Params == nil {
+			result.Params = make(map[string]interface{})
+		}
+		for key, value := range tile.Params {
+			result.Params[key] = value
+		}
+	}
+
+	return result
+}
+
+func keys(m interface{}) string {
+	strKeys := strings.Split(pkgConfig.Keys(m), ", ")
+	sort.Strings(strKeys)
+	return strings.Join(strKeys, ", ")
+}
@@ -0,0 +1,414 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/mocks"
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+	coreModels "github.com/monitoror/monitoror/models"
+	jenkinsApi "github.com/monitoror/monitoror/monitorables/jenkins/api"
+	jenkinsModels "github.com/monitoror/monitoror/monitorables/jenkins/api/models"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func TestUsecase_Expand_Success(t *testing.T) {
+	input := `
+{
+  "version": "2.0",
+  "columns": 4,
+  "variables": { "server": "server.com", "port": 22, "variant": "default" },
+  "templates": {
+    "jenkins-job": { "type": "JENKINS-BUILD", "label": "${job} on ${branch}", "params": { "job": "${job}", "branch": "${branch}" }},
+    "servers": { "type": "GROUP", "label": "Servers", "tiles": [
+      { "type": "PING", "params": { "hostname": "${server}" }},
+      { "type": "PORT", "configVariant": "${variant}", "params": { "hostname": "${server}", "port": "${port}" }}
+    ]}
+  },
+  "tiles": [
+    { "template": "jenkins-job", "with": { "job": "app", "branch": "master" }},
+    { "template": "jenkins-job", "with": { "job": "${server}", "branch": "develop" }, "label": "Develop", "columnSpan": 2, "params": { "branch": "dev" }},
+    { "template": "servers", "with": { "server": "other.com" }},
+    { "type": "PING", "label": "$${escaped}", "params": { "hostname": "${server}" }}
+  ]
+}
+`
+	configBag := readConfigBag(t, input)
+	usecase := initConfigUsecase(nil)
+	usecase.expand(configBag, "", "", true)
+
+	assert.Len(t, configBag.Errors, 0)
+	assert.Nil(t, configBag.Config.Variables)
+	assert.Nil(t, configBag.Config.Templates)
+
+	tiles := configBag.Config.Tiles
+	if assert.Len(t, tiles, 4) {
+		assert.Equal(t, "JENKINS-BUILD", string(tiles[0].Type))
+		assert.Equal(t, "app on master", tiles[0].Label)
+		assert.Equal(t, map[string]interface{}{"job": "app", "branch": "master"}, tiles[0].Params)
+		assert.Empty(t, tiles[0].Template)
+		assert.Nil(t, tiles[0].With)
+
+		assert.Equal(t, "Develop", tiles[1].Label)
+		assert.Equal(t, 2, *tiles[1].ColumnSpan)
+		assert.Equal(t, map[string]interface{}{"job": "server.com", "branch": "dev"}, tiles[1].Params)
+
+		assert.Equal(t, "GROUP", string(tiles[2].Type))
+		if assert.Len(t, tiles[2].Tiles, 2) {
+			assert.Equal(t, "other.com", tiles[2].Tiles[0].Params["hostname"])
+			assert.Equal(t, float64(22), tiles[2].Tiles[1].Params["port"])
+			assert.Equal(t, "default", string(tiles[2].Tiles[1].ConfigVariant))
+			assert.Equal(t, `{"template":"servers","with":{"server":"other.com"}}`, tiles[2].Tiles[1].Extract())
+		}
+
+		assert.Equal(t, "${escaped}", tiles[3].Label)
+		assert.Equal(t, "server.com", tiles[3].Params["hostname"])
+	}
+}
+
+func TestUsecase_Expand_Error(t *testing.T) {
+	for _, testcase := range []struct {
+		tiles     string
+		errorID   models.ConfigErrorID
+		errorData models.ConfigErrorData
+	}{
+		{
+			tiles:   `{ "template": "unknown" }`,
+			errorID: models.ConfigErrorUnknownTemplate,
+			errorData: models.ConfigErrorData{
+				FieldName:     "template",
+				Value:         "unknown",
+				ConfigExtract: `{"template":"unknown"}`,
+				Expected:      "loop1, loop2, ping",
+			},
+		},
+		{
+			tiles:   `{ "template": "ping" }`,
+			errorID: models.ConfigErrorUnknownVariable,
+			errorData: models.ConfigErrorData{
+				Value:                  "server",
+				ConfigExtract:          `{"template":"ping"}`,
+				ConfigExtractHighlight: "${server}",
+				Expected:               "label",
+			},
+		},
+		{
+			tiles:   `{ "type": "PING", "label": "${unknown} tile" }`,
+			errorID: models.ConfigErrorUnknownVariable,
+			errorData: models.ConfigErrorData{
+				Value:                  "unknown",
+				ConfigExtract:          `{"type":"PING","label":"${unknown} tile"}`,
+				ConfigExtractHighlight: "${unknown}",
+				Expected:               "label",
+			},
+		},
+		{
+			tiles:   `{ "type": "PING", "when": { "variable": "unknown" } }`,
+			errorID: models.ConfigErrorUnknownVariable,
+			errorData: models.ConfigErrorData{
+				Value:                  "unknown",
+				ConfigExtract:          `{"type":"PING","when":{"variable":"unknown"}}`,
+				ConfigExtractHighlight: "unknown",
+				Expected:               "label",
+			},
+		},
+		{
+			tiles:   `{ "template": "loop1" }`,
+			errorID: models.ConfigErrorCircularReference,
+			errorData: models.ConfigErrorData{
+				FieldName:     "template",
+				Value:         "loop1",
+				ConfigExtract: `{"template":"loop1"}`,
+			},
+		},
+	} {
+		input := fmt.Sprintf(`
+{
+  "version": "2.0",
+  "columns": 4,
+  "variables": { "label": "test" },
+  "templates": {
+    "ping": { "type": "PING", "params": { "hostname": "${server}" }},
+    "loop1": { "template": "loop2" },
+    "loop2": { "type": "GROUP", "tiles": [{ "template": "loop1" }]}
+  },
+  "tiles": [%s]
+}
+`, testcase.tiles)
+
+		configBag := readConfigBag(t, input)
+		usecase := initConfigUsecase(nil)
+		usecase.expand(configBag, "", "", true)
+
+		if assert.Len(t, configBag.Errors, 1) {
+			assert.Equal(t, testcase.errorID, configBag.Errors[0].ID)
+			assert.Equal(t, testcase.errorData, configBag.Errors[0].Data)
+		}
+	}
+}
+
+func TestUsecase_Expand_VerifyErrorWithOriginalExtract(t *testing.T) {
+	input := `
+{
+  "version": "2.0",
+  "columns": 4,
+  "templates": {
+    "ping": { "type": "PING", "params": { "hostname": "${server}", "unknown": true }}
+  },
+  "tiles": [
+    { "template": "ping", "with": { "server": "server.com" }}
+  ]
+}
+`
+	configBag := readConfigBag(t, input)
+	usecase := initConfigUsecase(nil)
+	usecase.expand(configBag, "", "", true)
+	if assert.Len(t, configBag.Errors, 0) {
+		usecase.Verify(configBag)
+		if assert.Len(t, configBag.Errors, 1) {
+			assert.Equal(t, models.ConfigErrorUnknownField, configBag.Errors[0].ID)
+			assert.Equal(t, `{"template":"ping","with":{"server":"server.com"}}`, configBag.Errors[0].Data.ConfigExtract)
+		}
+	}
+}
+
+func TestUsecase_Expand_WithInclude(t *testing.T) {
+	input := `
+{
+  "version": "2.0",
+  "columns": 4,
+  "include": ["shared/jenkins.json"],
+  "variables": { "branch": "master" },
+  "tiles": [
+    { "template": "jenkins-job", "with": { "job": "app" }}
+  ]
+}
+`
+	jenkinsPath := filepath.Join("/configs", "shared", "jenkins.json")
+	commonPath := filepath.Join("/configs", "shared", "common.json")
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromPath", "", jenkinsPath).Return(&models.Config{
+		Include:   []string{"common.json"},
+		Variables: map[string]interface{}{"branch": "develop"},
+		Templates: map[string]models.TileConfig{
+			"jenkins-job": {Type: "JENKINS-BUILD", Params: map[string]interface{}{"job": "${job}", "branch": "${branch}"}, ConfigVariant: "${variant}"},
+		},
+	}, nil)
+	mockRepo.On("GetConfigFromPath", "", commonPath).Return(&models.Config{
+		Variables: map[string]interface{}{"variant": "default"},
+	}, nil)
+
+	configBag := readConfigBag(t, input)
+	usecase := initConfigUsecase(mockRepo)
+	usecase.expand(configBag, "", filepath.Join("/configs", "screen1.json"), true)
+
+	if assert.Len(t, configBag.Errors, 0) {
+		assert.Nil(t, configBag.Config.Include)
+		assert.Equal(t, map[string]interface{}{"job": "app", "branch": "master"}, configBag.Config.Tiles[0].Params)
+		assert.Equal(t, "default", string(configBag.Config.Tiles[0].ConfigVariant))
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUsecase_Expand_WithInclude_Error(t *testing.T) {
+	input := `
+{
+  "version": "2.0",
+  "columns": 4,
+  "include": ["shared.json"],
+  "tiles": [{ "type": "EMPTY" }]
+}
+`
+	// Not found
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromURL", "http://example.com/configs/shared.json", coreConfig.ConfigName("screen1")).
+		Return(nil, &models.ConfigFileNotFoundError{Err: errors.New("boom"), PathOrURL: "http://example.com/configs/shared.json"})
+
+	configBag := readConfigBag(t, input)
+	usecase := initConfigUsecase(mockRepo)
+	usecase.expand(configBag, "screen1", "http://example.com/configs/screen1.json", true)
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorConfigNotFound, configBag.Errors[0].ID)
+	}
+
+	// Unable to parse, content of included file is hidden
+	mockRepo = new(mocks.Repository)
+	mockRepo.On("GetConfigFromURL", "http://example.com/configs/shared.json", coreConfig.ConfigName("screen1")).
+		Return(nil, &models.ConfigUnmarshalError{Err: errors.New("boom"), RawConfig: "secret"})
+
+	configBag = readConfigBag(t, input)
+	usecase = initConfigUsecase(mockRepo)
+	usecase.expand(configBag, "screen1", "http://example.com/configs/screen1.json", true)
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorUnableToParseConfig, configBag.Errors[0].ID)
+		assert.Equal(t, "shared.json", configBag.Errors[0].Data.Value)
+		assert.Empty(t, configBag.Errors[0].Data.ConfigExtract)
+	}
+
+	// Circular include
+	mockRepo = new(mocks.Repository)
+	mockRepo.On("GetConfigFromURL", "http://example.com/configs/shared.json", coreConfig.ConfigName("screen1")).
+		Return(&models.Config{Include: []string{"screen1.json"}}, nil)
+
+	configBag = readConfigBag(t, input)
+	usecase = initConfigUsecase(mockRepo)
+	usecase.expand(configBag, "screen1", "http://example.com/configs/screen1.json", true)
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorCircularReference, configBag.Errors[0].ID)
+		assert.Equal(t, "screen1.json", configBag.Errors[0].Data.Value)
+	}
+}
+
+func TestUsecase_GetConfig_WithExpand(t *testing.T) {
+	configBag := readConfigBag(t, `
+{
+  "version": "2.0",
+  "columns": 4,
+  "variables": { "server": "server.com" },
+  "tiles": [{ "type": "PING", "params": { "hostname": "${server}" }}]
+}
+`)
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromURL", AnythingOfType("string"), Anything).Return(configBag.Config, nil)
+
+	usecase := initConfigUsecase(mockRepo)
+	configBag = usecase.GetConfig(&models.ConfigParams{Config: "http://example.com/config.json"})
+	if assert.Len(t, configBag.Errors, 0) {
+	}
+}
+
+func TestUsecase_GetConfig_WithRemoteLocation_LocalIncludes(t *testing.T) {
+	include := "git+file:///srv/configs.git#shared.json"
+	configBag := readConfigBag(t, fmt.Sprintf(`{"version": "2.0", "columns": 4, "include": [%q], "tiles": [{ "type": "EMPTY" }]}`, include))
+
+	// Config loaded from url
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromURL", "http://example.com/config.json", coreConfig.ConfigName("")).Return(configBag.Config, nil)
+
+	usecase := initConfigUsecase(mockRepo)
+	configBag = usecase.GetConfig(&models.ConfigParams{Config: "http://example.com/config.json"})
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorInvalidFieldValue, configBag.Errors[0].ID)
+		assert.Equal(t, include, configBag.Errors[0].Data.Value)
+	}
+	mockRepo.AssertNotCalled(t, "GetContentFromGit", Anything)
+
+	// Local named config including a remote file
+	mockRepo = new(mocks.Repository)
+	mockRepo.On("GetConfigFromPath", AnythingOfType("string"), "config.json").
+		Return(&models.Config{Include: []string{"http://example.com/shared.json"}, Tiles: []models.TileConfig{{Type: "EMPTY"}}}, nil)
+	mockRepo.On("GetConfigFromURL", "http://example.com/shared.json", coreConfig.ConfigName("default")).
+		Return(&models.Config{Include: []string{include}}, nil)
+
+	usecase = initConfigUsecase(mockRepo)
+	usecase.namedConfigs = map[coreConfig.ConfigName]string{"default": "config.json"}
+	configBag = usecase.GetConfig(&models.ConfigParams{Config: "default"})
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorInvalidFieldValue, configBag.Errors[0].ID)
+		assert.Equal(t, include, configBag.Errors[0].Data.Value)
+	}
+	mockRepo.AssertNotCalled(t, "GetContentFromGit", Anything)
+}
+
+func TestResolveLocation(t *testing.T) {
+	for _, testcase := range []struct {
+		location, include, expected string
+	}{
+		{location: "http://example.com/configs/screen.json", include: "shared.json", expected: "http://example.com/configs/shared.json"},
+		{location: "http://example.com/configs/screen.json", include: "/shared.json", expected: "http://example.com/shared.json"},
+		{location: "/configs/screen.json", include: "https://example.com/shared.json", expected: "https://example.com/shared.json"},
+		{location: filepath.Join("/configs", "screen.json"), include: "shared.json", expected: filepath.Join("/configs", "shared.json")},
+		{location: "", include: "shared.json", expected: filepath.Join(path.MonitororBaseDir, "shared.json")},
+		{location: "git+https://example.com/configs.git#main:screens/screen.json", include: "shared.json", expected: "git+https://example.com/configs.git#main:screens/shared.json"},
+		{location: "git+https://example.com/configs.git#main:screens/screen.json", include: "/shared.json", expected: "git+https://example.com/configs.git#main:shared.json"},
+		{location: "/configs/screen.json", include: "git+file:///srv/configs.git#shared.json", expected: "git+file:///srv/configs.git#shared.json"},
+	} {
+		assert.Equal(t, testcase.expected, resolveLocation(testcase.location, testcase.include))
+	}
+}
+
+func TestUsecase_GetConfigFromContent(t *testing.T) {
+	content := []byte(`{"version": "2.0", "columns": 4, "variables": { "server": "server.com" }, "tiles": [{ "type": "PING", "params": { "hostname": "${server}" }}]}`)
+	configBag := readConfigBag(t, string(content))
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromContent", content, "/configs/screen1.json").Return(configBag.Config, nil)
+	mockRepo.On("GetConfigFromContent", []byte("xxx"), "").Return(nil, &models.ConfigUnmarshalError{Err: errors.New("boom"), RawConfig: "xxx"})
+
+	usecase := initConfigUsecase(mockRepo)
+	configBag = usecase.GetConfigFromContent(content, "/configs/screen1.json")
+	if assert.Len(t, configBag.Errors, 0) {
+	}
+
+	configBag = usecase.GetConfigFromContent([]byte("xxx"), "")
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorUnableToParseConfig, configBag.Errors[0].ID)
+		assert.Equal(t, "xxx", configBag.Errors[0].Data.ConfigExtract)
+	}
+}
+
+func TestUsecase_ValidateConfig(t *testing.T) {
+	content := []byte(`{"version": "2.0", "columns": 4, "include": ["https://example.com/shared.json"], "tiles": [{ "type": "PING", "params": { "hostname": "${server}" }}]}`)
+	configBag := readConfigBag(t, string(content))
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromContent", content, "").Return(configBag.Config, nil)
+	mockRepo.On("GetConfigFromURL", "https://example.com/shared.json", coreConfig.ConfigName("")).
+		Return(&models.Config{Variables: map[string]interface{}{"server": "server.com"}}, nil)
+
+	usecase := initConfigUsecase(mockRepo)
+	configBag = usecase.ValidateConfig(content)
+	if assert.Len(t, configBag.Errors, 0) {
+		assert.Equal(t, "/ping/default/ping?hostname=server.com", configBag.Config.Tiles[0].URL)
+	}
+	mockRepo.AssertExpectations(t)
+
+	// Hydrate errors are reported
+	content = []byte(`{"version": "2.0", "columns": 4, "tiles": [{ "type": "GENERATE:JENKINS-BUILD", "params": { "job": "test" }}]}`)
+	configBag = readConfigBag(t, string(content))
+
+	mockRepo = new(mocks.Repository)
+	mockRepo.On("GetConfigFromContent", content, "").Return(configBag.Config, nil)
+
+	usecase = initConfigUsecase(mockRepo)
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariantName}).
+		Enable(coreModels.DefaultVariantName, &jenkinsModels.BuildGeneratorParams{}, func(_ interface{}) ([]models.GeneratedTile, error) {
+			return nil, errors.New("unable to find job")
+		})
+	configBag = usecase.ValidateConfig(content)
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorUnableToHydrate, configBag.Errors[0].ID)
+	}
+
+	// Local includes are refused
+	for _, include := range []string{"shared.json", "/etc/monitoror/shared.json", "git+file:///srv/configs.git#shared.json"} {
+		content := []byte(fmt.Sprintf(`{"version": "2.0", "columns": 4, "include": [%q], "tiles": [{ "type": "EMPTY" }]}`, include))
+		configBag := readConfigBag(t, string(content))
+
+		mockRepo := new(mocks.Repository)
+		mockRepo.On("GetConfigFromContent", content, "").Return(configBag.Config, nil)
+
+		usecase := initConfigUsecase(mockRepo)
+		configBag = usecase.ValidateConfig(content)
+		if assert.Len(t, configBag.Errors, 1, include) {
+			assert.Equal(t, models.ConfigErrorInvalidFieldValue, configBag.Errors[0].ID)
+			assert.Equal(t, include, configBag.Errors[0].Data.Value)
+		}
+		mockRepo.AssertNotCalled(t, "GetConfigFromPath", Anything, Anything)
+		mockRepo.AssertNotCalled(t, "GetContentFromGit", Anything)
+	}
+}
+
+func readConfigBag(t *testing.T, input string) *models.ConfigBag {
+	config, err := readConfig(input)
+	assert.NoError(t, err)
+	return config
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/api/config/versions"
@@ -37,58 +38,138 @@ func init() {
 	urlRegex = regexp.MustCompile(validate.HTTPRegex)
 }
 
-// GetConfig and set default value for Config from repository
-func (cu *configUsecase) GetConfigList() []models.ConfigMetadata {
-	var configList []models.ConfigMetadata
-
-	for configName := range cu.namedConfigs {
-		configList = append(configList, models.ConfigMetadata{
-			Name: string(configName),
-		})
-	}
-
-	return configList
-}
-
 // GetConfig and set default value for Config from repository
 func (cu *configUsecase) GetConfig(params *models.ConfigParams) *models.ConfigBag {
 	configBag := &models.ConfigBag{}
 	var err error
 
+	// location of config (path, url or git source), used to resolve includes
+	var location string
+	var configName coreConfig.ConfigName
+
 	// Lookup for a url
 	if urlRegex.MatchString(params.Config) {
-		configBag.Config, err = cu.repository.GetConfigFromURL(params.Config)
+		location = params.Config
+		configBag.Config, err = cu.repository.GetConfigFromURL(params.Config, "") // Never fetched with RemoteConfig credentials
 	} else {
-		configName := coreConfig.ConfigName(strings.ToLower(params.Config))
+		configName = coreConfig.ConfigName(strings.ToLower(params.Config))
 
 		// Lookup for a named Config
-		if namedConfig, ok := cu.namedConfigs[configName]; ok {
-			if urlRegex.MatchString(namedConfig) {
-				configBag.Config, err = cu.repository.GetConfigFromURL(namedConfig)
-			} else {
-				configBag.Config, err = cu.repository.GetConfigFromPath(path.MonitororBaseDir, namedConfig)
-			}
+		if _, ok := cu.namedConfigs[configName]; ok {
+			cu.setLastLoad(configName)
+			location, err = cu.getNamedConfig(configBag, configName)
 		} else {
-			message := fmt.Sprintf(`Unknown %q named config. No named configuration found.`, params.Config)
-			if len(cu.namedConfigs) != 0 {
-				message = fmt.Sprintf(`Unknown %q named config. Must be %s`, params.Config, config.Keys(cu.namedConfigs))
-			}
-
-			configBag.AddErrors(models.ConfigError{
-				ID:      models.ConfigErrorUnknownNamedConfig,
-				Message: message,
-				Data: models.ConfigErrorData{
-					Value:    params.Config,
-					Expected: config.Keys(cu.namedConfigs),
-				},
-			})
+			configBag.AddErrors(cu.unknownNamedConfigError(params.Config))
 		}
 	}
 
-	if err == nil {
+	if err != nil {
+		cu.addRepositoryError(configBag, err)
 		return configBag
 	}
 
+	// Expand includes, templates and variables before Verify
+	if configBag.Config != nil {
+		cu.expand(configBag, configName, location, true)
+	}
+
+	return configBag
+}
+
+// getNamedConfig load named config in configBag and return its location
+func (cu *configUsecase) getNamedConfig(configBag *models.ConfigBag, configName coreConfig.ConfigName) (location string, err error) {
+	namedConfig := cu.namedConfigs[configName]
+
+	// Hash is read before config, a change during loading is detected on next UI check
+	if configHash := cu.watchedConfigHash(configName); configHash != nil {
+		configBag.Hash = configHash.Hash
+		configBag.HashPollInterval = int(cu.pollInterval / time.Millisecond)
+	}
+
+	if urlRegex.MatchString(namedConfig) {
+		configBag.Config, err = cu.repository.GetConfigFromURL(namedConfig, configName)
+		return namedConfig, err
+	}
+
+	if models.IsGitSource(namedConfig) {
+		configBag.Config, configBag.Commit, err = cu.getConfigFromGit(namedConfig)
+		return namedConfig, err
+	}
+
+	configBag.Config, err = cu.repository.GetConfigFromPath(path.MonitororBaseDir, namedConfig)
+	return path.ToAbsolute(path.MonitororBaseDir, namedConfig), err
+}
+
+// getConfigFromGit return config stored in git repository and SHA of its commit
+func (cu *configUsecase) getConfigFromGit(source string) (*models.Config, string, error) {
+	content, err := cu.repository.GetContentFromGit(source)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Format is detected from file extension
+	gitSource, _ := models.ParseGitSource(source) // Already parsed by repository
+	config, err := cu.repository.GetConfigFromContent(content.Content, gitSource.Path)
+
+	return config, content.Commit, err
+}
+
+func (cu *configUsecase) unknownNamedConfigError(name string) models.ConfigError {
+	message := fmt.Sprintf(`Unknown %q named config. No named configuration found.`, name)
+	if len(cu.namedConfigs) != 0 {
+		message = fmt.Sprintf(`Unknown %q named config. Must be %s`, name, config.Keys(cu.namedConfigs))
+	}
+
+	return models.ConfigError{
+		ID:      models.ConfigErrorUnknownNamedConfig,
+		Message: message,
+		Data: models.ConfigErrorData{
+			Value:    name,
+			Expected: config.Keys(cu.namedConfigs),
+		},
+	}
+}
+
+// GetConfigFromContent parse raw config (JSON or YAML) like GetConfig.
+// location is the path or url where config is (or will be) stored, used to detect format and to resolve includes
+func (cu *configUsecase) GetConfigFromContent(content []byte, location string) *models.ConfigBag {
+	return cu.getConfigFromContent(content, location, true)
+}
+
+// ValidateConfig parse and verify config sent to the API without saving it (dry-run).
+// Content isn't trusted: only remote includes (url, git+http(s) source) are loaded, local files and repositories are refused.
+// Config is hydrated like on load: generators are called (with the timeout of their monitorable) and only
+// current schedule and conditions are checked
+func (cu *configUsecase) ValidateConfig(content []byte) *models.ConfigBag {
+	configBag := cu.getConfigFromContent(content, "", false)
+	if len(configBag.Errors) == 0 {
+		cu.Verify(configBag)
+	}
+	if len(configBag.Errors) == 0 {
+		cu.Hydrate(configBag)
+	}
+
+	return configBag
+}
+
+func (cu *configUsecase) getConfigFromContent(content []byte, location string, localIncludes bool) *models.ConfigBag {
+	configBag := &models.ConfigBag{}
+
+	var err error
+	if configBag.Config, err = cu.repository.GetConfigFromContent(content, location); err != nil {
+		cu.addRepositoryError(configBag, err)
+		return configBag
+	}
+
+	if configBag.Config != nil {
+		cu.expand(configBag, "", location, localIncludes)
+	}
+
+	return configBag
+}
+
+// addRepositoryError convert errors returned by repository in ConfigError
+func (cu *configUsecase) addRepositoryError(configBag *models.ConfigBag, err error) {
 	switch e := err.(type) {
 	case *models.ConfigFileNotFoundError:
 		configBag.AddErrors(models.ConfigError{
@@ -106,6 +187,15 @@ func (cu *configUsecase) GetConfig(params *models.ConfigParams) *models.ConfigBa
 				Expected:  fmt.Sprintf(`%q >= version >= %q`, versions.MinimalVersion, versions.CurrentVersion),
 			},
 		})
+	case *versions.ConfigMigrationError:
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnsupportedVersion,
+			Message: e.Error(),
+			Data: models.ConfigErrorData{
+				FieldName: "version",
+				Value:     string(e.From),
+			},
+		})
 	case *models.ConfigUnmarshalError:
 		// Check if error is "json: unknown field"
 		if unknownFieldRegex.MatchString(err.Error()) {
@@ -189,6 +279,4 @@ func (cu *configUsecase) GetConfig(params *models.ConfigParams) *models.ConfigBa
 			Message: err.Error(),
 		})
 	}
-
-	return configBag
 }
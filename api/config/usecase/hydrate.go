@@ -1,22 +1,113 @@
 package usecase
 
 import (
+	"encoding/gob"
 	"encoding/json"
 	"fmt"
 	"net/url"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/monitoror/monitoror/api/config/models"
-	pkgConfig "github.com/monitoror/monitoror/internal/pkg/api/config"
+	"github.com/monitoror/monitoror/internal/pkg/signature"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/pkg/humanize"
 )
 
+func init() {
+	// Generated tiles params are stored as map in persistent stores
+	gob.Register(map[string]interface{}{})
+	gob.Register([]interface{}{})
+}
+
 func (cu *configUsecase) Hydrate(configBag *models.ConfigBag) {
+	// Timezone is already verified
+	now := cu.now()
+	if configBag.Config.Timezone != "" {
+		if location, err := time.LoadLocation(configBag.Config.Timezone); err == nil {
+			now = now.In(location)
+		}
+	}
+
+	// First matching schedule replace config tiles
+	for _, schedule := range configBag.Config.Schedule {
+		if schedule.When != nil && schedule.When.Match(now) {
+			configBag.Config.Tiles = schedule.Tiles
+			break
+		}
+	}
+	configBag.Config.Schedule = nil
+
+	if len(configBag.Config.Pages) > 0 {
+		cu.hydratePages(configBag, now)
+		return
+	}
+
+	configBag.Config.Tiles = filterTiles(configBag.Config.Tiles, now)
 	cu.hydrateTiles(configBag, &configBag.Config.Tiles)
 }
 
+// hydratePages hydrate tiles page by page and set columns, zoom and duration of pages.
+// Pages without tiles to display are removed, errors are prefixed by page index
+func (cu *configUsecase) hydratePages(configBag *models.ConfigBag, now time.Time) {
+	config := configBag.Config
+
+	pages := make([]models.PageConfig, 0, len(config.Pages))
+	for i, page := range config.Pages {
+		pageBag := &models.ConfigBag{Config: config}
+		page.Tiles = filterTiles(page.Tiles, now)
+		cu.hydrateTiles(pageBag, &page.Tiles)
+		configBag.AddErrors(prefixPageErrors(i, pageBag.Errors)...)
+
+		if len(page.Tiles) == 0 {
+			continue
+		}
+
+		if page.Columns == nil {
+			page.Columns = config.Columns
+		}
+		if page.Zoom == nil {
+			page.Zoom = config.Zoom
+		}
+		if page.Duration == nil {
+			duration := DefaultPageDuration
+			page.Duration = &duration
+		}
+
+		pages = append(pages, page)
+	}
+	config.Pages = pages
+
+	// Nothing to display, UI expects tiles without pages
+	if len(pages) == 0 {
+		config.Tiles = []models.TileConfig{}
+	}
+}
+
+// filterTiles remove tiles (and grouped tiles) with "when" condition not matching now. Empty groups are removed
+func filterTiles(tiles []models.TileConfig, now time.Time) []models.TileConfig {
+	result := make([]models.TileConfig, 0, len(tiles))
+	for _, tile := range tiles {
+		if tile.When != nil {
+			if !tile.When.Match(now) {
+				continue
+			}
+			tile.When = nil
+		}
+
+		if tile.Type == GroupTileType {
+			if tile.Tiles = filterTiles(tile.Tiles, now); len(tile.Tiles) == 0 {
+				continue
+			}
+		}
+
+		result = append(result, tile)
+	}
+
+	return result
+}
+
 func (cu *configUsecase) hydrateTiles(configBag *models.ConfigBag, tiles *[]models.TileConfig) {
 	for i := 0; i < len(*tiles); i++ {
 		tile := &((*tiles)[i])
@@ -76,6 +167,11 @@ func (cu *configUsecase) hydrateTile(configBag *models.ConfigBag, tile *models.T
 	}
 	tile.URL = fmt.Sprintf("%s?%s", *tileVariantMetadata.RoutePath, urlParams.Encode())
 
+	// Sign URL, anonymous users can only access tiles of configs they can read
+	if cu.authSecret != "" {
+		tile.URL, _ = signature.SignURL(cu.authSecret, tile.URL) // Ignoring error, URL is built above
+	}
+
 	// Add initial max delay from config
 	tile.InitialMaxDelay = &cu.initialMaxDelay
 
@@ -107,7 +203,7 @@ func (cu *configUsecase) hydrateGeneratorTile(configBag *models.ConfigBag, tile
 					ID:      models.ConfigErrorUnableToHydrate,
 					Message: fmt.Sprintf(`Error while generating %s tiles (params: %s). Timeout or host unreachable`, tile.Type, string(bParams)),
 					Data: models.ConfigErrorData{
-						ConfigExtract: pkgConfig.Stringify(tile),
+						ConfigExtract: tile.Extract(),
 					},
 				})
 			}
@@ -116,12 +212,18 @@ func (cu *configUsecase) hydrateGeneratorTile(configBag *models.ConfigBag, tile
 				ID:      models.ConfigErrorUnableToHydrate,
 				Message: fmt.Sprintf(`Error while generating %s tiles (params: %s). %v`, tile.Type, string(bParams), err),
 				Data: models.ConfigErrorData{
-					ConfigExtract: pkgConfig.Stringify(tile),
+					ConfigExtract: tile.Extract(),
 				},
 			})
 		}
 	} else {
-		// Add result in cache
+		// Add result in cache, params are converted in map to be serializable by persistent stores
+		for i := range results {
+			var params map[string]interface{}
+			bResultParams, _ := json.Marshal(results[i].Params)
+			_ = json.Unmarshal(bResultParams, &params)
+			results[i].Params = params
+		}
 		_ = cu.generatorTileStore.Set(cacheKey, results, cu.cacheExpiration)
 	}
 
@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/api/config/versions"
+	"github.com/monitoror/monitoror/internal/pkg/signature"
 	coreModels "github.com/monitoror/monitoror/models"
 	jenkinsApi "github.com/monitoror/monitoror/monitorables/jenkins/api"
 	jenkinsModels "github.com/monitoror/monitoror/monitorables/jenkins/api/models"
+	"github.com/monitoror/monitoror/pkg/diskcache"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -65,6 +71,29 @@ func TestUsecase_Hydrate(t *testing.T) {
 	assert.Equal(t, 1000, *config.Config.Tiles[6].InitialMaxDelay)
 }
 
+func TestUsecase_Hydrate_WithSignature(t *testing.T) {
+	input := `
+{
+  "columns": 4,
+  "tiles": [
+    { "type": "PING", "params": { "hostname": "aserver.com" } }
+  ]
+}
+`
+
+	usecase := initConfigUsecase(nil)
+	usecase.authSecret = "secret"
+
+	config, err := readConfig(input)
+	assert.NoError(t, err)
+
+	usecase.Hydrate(config)
+	assert.Len(t, config.Errors, 0)
+
+	assert.Regexp(t, `^/ping/default/ping\?hostname=aserver.com&signature=[0-9a-f]{64}$`, config.Config.Tiles[0].URL)
+	assert.True(t, signature.VerifyURL("secret", config.Config.Tiles[0].URL))
+}
+
 func TestUsecase_Hydrate_WithGenerator(t *testing.T) {
 	input := `
 {
@@ -246,6 +275,44 @@ func TestUsecase_Hydrate_WithGenerator_WithTimeoutCache(t *testing.T) {
 	}
 }
 
+func TestUsecase_Hydrate_WithGenerator_WithTimeoutPersistentCache(t *testing.T) {
+	input := `
+{
+  "columns": 4,
+  "tiles": [
+    { "type": "GENERATE:JENKINS-BUILD", "params": {"job": "test"}}
+	]
+}
+`
+	dir, err := ioutil.TempDir("", "hydrate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	diskStore, err := diskcache.NewDiskStore(filepath.Join(dir, "cache.db"), time.Minute, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer diskStore.Close()
+
+	usecase := initConfigUsecase(nil)
+	usecase.generatorTileStore = diskStore
+
+	var builderErr error
+	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) {
+		return []models.GeneratedTile{{Params: &jenkinsModels.BuildParams{Job: "test"}}}, builderErr
+	}
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariantName}).
+		Enable(coreModels.DefaultVariantName, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
+
+	for _, builderErr = range []error{nil, context.DeadlineExceeded} {
+		config, err := readConfig(input)
+		if assert.NoError(t, err) {
+			usecase.Hydrate(config)
+			assert.Len(t, config.Errors, 0)
+			assert.Equal(t, "/jenkins/default/build?job=test", config.Config.Tiles[0].URL)
+		}
+	}
+}
+
 func TestUsecase_Hydrate_TwoGenerators(t *testing.T) {
 	input := `
 {
@@ -280,3 +347,138 @@ func TestUsecase_Hydrate_TwoGenerators(t *testing.T) {
 	}
 
 }
+
+func TestUsecase_Hydrate_WithCondition(t *testing.T) {
+	input := `
+{
+  "version": "2.0",
+  "columns": 4,
+  "timezone": "Europe/Paris",
+  "variables": { "onCall": true },
+  "templates": {
+    "deploy": { "type": "PING", "when": { "time": "08:00-19:00" }, "params": { "hostname": "${server}" }}
+  },
+  "tiles": [
+    { "type": "PING", "params": { "hostname": "always.com" }},
+    { "template": "deploy", "with": { "server": "deploy.com" }},
+    { "type": "GROUP", "label": "Deploys", "when": { "weekdays": ["mon", "tue", "wed", "thu", "fri"] }, "tiles": [
+      { "type": "PORT", "when": { "time": "08:00-19:00" }, "params": { "hostname": "day.com", "port": 22 }},
+      { "type": "PORT", "when": { "time": "19:00-08:00" }, "params": { "hostname": "night.com", "port": 22 }}
+    ]},
+    { "type": "GROUP", "label": "Day only", "tiles": [
+      { "type": "PING", "when": { "time": "08:00-19:00" }, "params": { "hostname": "day.com" }}
+    ]},
+    { "type": "PING", "when": { "variable": "onCall" }, "params": { "hostname": "oncall.com" }}
+  ]
+}
+`
+	usecase := initConfigUsecase(nil)
+	// Friday 21:30 in Paris
+	usecase.now = func() time.Time { return time.Date(2020, 5, 15, 19, 30, 0, 0, time.UTC) }
+
+	configBag := readConfigBag(t, input)
+	usecase.expand(configBag, "", "", true)
+	usecase.Verify(configBag)
+	usecase.Hydrate(configBag)
+
+	assert.Len(t, configBag.Errors, 0)
+	if tiles := configBag.Config.Tiles; assert.Len(t, tiles, 3) {
+		assert.Equal(t, "/ping/default/ping?hostname=always.com", tiles[0].URL)
+		assert.Equal(t, "Deploys", tiles[1].Label)
+		assert.Nil(t, tiles[1].When)
+		if assert.Len(t, tiles[1].Tiles, 1) {
+			assert.Equal(t, "/port/default/port?hostname=night.com&port=22", tiles[1].Tiles[0].URL)
+			assert.Nil(t, tiles[1].Tiles[0].When)
+		}
+		assert.Equal(t, "/ping/default/ping?hostname=oncall.com", tiles[2].URL)
+	}
+}
+
+func TestUsecase_Hydrate_WithSchedule(t *testing.T) {
+	input := `
+{
+  "version": "2.0",
+  "columns": 4,
+  "schedule": [
+    { "when": { "weekdays": ["saturday", "sunday"] }, "tiles": [{ "type": "PING", "params": { "hostname": "weekend.com" }}]},
+    { "when": { "time": "19:00-08:00" }, "tiles": [{ "type": "PING", "params": { "hostname": "night.com" }}]}
+  ],
+  "tiles": [
+    { "type": "PING", "params": { "hostname": "day.com" }}
+  ]
+}
+`
+	for _, testcase := range []struct {
+		now      time.Time
+		hostname string
+	}{
+		{now: time.Date(2020, 5, 15, 14, 0, 0, 0, time.Local), hostname: "day.com"},
+		{now: time.Date(2020, 5, 15, 23, 0, 0, 0, time.Local), hostname: "night.com"},
+		{now: time.Date(2020, 5, 16, 23, 0, 0, 0, time.Local), hostname: "weekend.com"},
+	} {
+		usecase := initConfigUsecase(nil)
+		usecase.now = func() time.Time { return testcase.now }
+
+		configBag := readConfigBag(t, input)
+		usecase.expand(configBag, "", "", true)
+		usecase.Verify(configBag)
+		usecase.Hydrate(configBag)
+
+		assert.Len(t, configBag.Errors, 0)
+		assert.Nil(t, configBag.Config.Schedule)
+		if assert.Len(t, configBag.Config.Tiles, 1) {
+			assert.Equal(t, "/ping/default/ping?hostname="+testcase.hostname, configBag.Config.Tiles[0].URL)
+		}
+	}
+}
+
+func TestUsecase_Hydrate_WithPages(t *testing.T) {
+	input := `
+{
+  "version": "2.0",
+  "columns": 4,
+  "zoom": 1.5,
+  "pages": [
+    { "tiles": [{ "type": "PING", "params": { "hostname": "page1.com" }}]},
+    { "tiles": [{ "type": "PING", "when": { "time": "19:00-08:00" }, "params": { "hostname": "night.com" }}]},
+    { "columns": 2, "duration": 10, "tiles": [
+      { "type": "PORT", "params": { "hostname": "page3.com", "port": 22 }},
+      { "type": "GENERATE:JENKINS-BUILD", "params": { "job": "test" }}
+    ]}
+  ]
+}
+`
+	usecase := initConfigUsecase(nil)
+	usecase.now = func() time.Time { return time.Date(2020, 5, 15, 14, 0, 0, 0, time.Local) }
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariantName}).
+		Enable(coreModels.DefaultVariantName, &jenkinsModels.BuildGeneratorParams{}, func(_ interface{}) ([]models.GeneratedTile, error) {
+			return nil, errors.New("boom")
+		})
+
+	configBag := readConfigBag(t, input)
+	usecase.expand(configBag, "", "", true)
+	usecase.Verify(configBag)
+	assert.Len(t, configBag.Errors, 0)
+	usecase.Hydrate(configBag)
+
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorUnableToHydrate, configBag.Errors[0].ID)
+		assert.Equal(t, `pages[2]: Error while generating GENERATE:JENKINS-BUILD tiles (params: {"job":"test"}). boom`, configBag.Errors[0].Message)
+	}
+
+	pages := configBag.Config.Pages
+	if assert.Len(t, pages, 2) {
+		assert.Equal(t, 4, *pages[0].Columns)
+		assert.Equal(t, float32(1.5), *pages[0].Zoom)
+		assert.Equal(t, DefaultPageDuration, *pages[0].Duration)
+		if assert.Len(t, pages[0].Tiles, 1) {
+			assert.Equal(t, "/ping/default/ping?hostname=page1.com", pages[0].Tiles[0].URL)
+		}
+
+		assert.Equal(t, 2, *pages[1].Columns)
+		assert.Equal(t, 10, *pages[1].Duration)
+		if assert.Len(t, pages[1].Tiles, 1) {
+			assert.Equal(t, "/port/default/port?hostname=page3.com&port=22", pages[1].Tiles[0].URL)
+		}
+	}
+}
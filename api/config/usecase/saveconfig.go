@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+)
+
+// SaveConfig verify content and save it in named config file, a revision is added on success
+func (cu *configUsecase) SaveConfig(params *models.ConfigParams, content []byte, author string) (*models.ConfigBag, *models.ConfigRevision) {
+	return cu.saveConfig(params.Config, content, &models.ConfigRevision{Author: author})
+}
+
+// GetConfigRevisions return saved revisions of named config, newest first
+func (cu *configUsecase) GetConfigRevisions(params *models.ConfigParams) ([]models.ConfigRevision, *models.ConfigError) {
+	configName, _, configError := cu.writableNamedConfig(params.Config)
+	if configError != nil {
+		return nil, configError
+	}
+
+	revisions, err := cu.revisionRepository.GetRevisions(string(configName))
+	if err != nil {
+		return nil, &models.ConfigError{ID: models.ConfigErrorUnexpectedError, Message: err.Error()}
+	}
+
+	return revisions, nil
+}
+
+// RestoreConfigRevision save content of previous revision in named config file, as a new revision.
+// Content is verified again, monitorables could have changed since revision was saved
+func (cu *configUsecase) RestoreConfigRevision(params *models.ConfigParams, revisionID, author string) (*models.ConfigBag, *models.ConfigRevision) {
+	configBag := &models.ConfigBag{}
+
+	configName, _, configError := cu.writableNamedConfig(params.Config)
+	if configError != nil {
+		configBag.AddErrors(*configError)
+		return configBag, nil
+	}
+
+	revision, content, err := cu.revisionRepository.GetRevision(string(configName), revisionID)
+	if err != nil {
+		if e, ok := err.(*models.ConfigRevisionNotFoundError); ok {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorRevisionNotFound,
+				Message: e.Error(),
+				Data:    models.ConfigErrorData{Value: revisionID},
+			})
+		} else {
+			configBag.AddErrors(models.ConfigError{ID: models.ConfigErrorUnexpectedError, Message: err.Error()})
+		}
+		return configBag, nil
+	}
+
+	return cu.saveConfig(params.Config, content, &models.ConfigRevision{Author: author, RestoredFrom: revision.ID})
+}
+
+func (cu *configUsecase) saveConfig(name string, content []byte, revision *models.ConfigRevision) (*models.ConfigBag, *models.ConfigRevision) {
+	configName, filePath, configError := cu.writableNamedConfig(name)
+	if configError != nil {
+		configBag := &models.ConfigBag{}
+		configBag.AddErrors(*configError)
+		return configBag, nil
+	}
+
+	// Same pipeline as GetConfig, config is only saved without error
+	configBag := cu.GetConfigFromContent(content, path.ToAbsolute(path.MonitororBaseDir, filePath))
+	if len(configBag.Errors) == 0 {
+		cu.Verify(configBag)
+	}
+	if len(configBag.Errors) > 0 {
+		return configBag, nil
+	}
+
+	cu.saveLock.Lock()
+	defer cu.saveLock.Unlock()
+
+	previous, err := cu.repository.SaveConfigToPath(path.MonitororBaseDir, filePath, content)
+	if err != nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnexpectedError,
+			Message: fmt.Sprintf("Unable to save %q named config, %v", configName, err),
+		})
+		return configBag, nil
+	}
+	configBag.Hash = cu.setConfigHash(configName, &models.ConfigContent{Content: content}).Hash
+
+	// Keep file edited outside of API as revision, it can be restored later
+	if previous != nil {
+		revisions, err := cu.revisionRepository.GetRevisions(string(configName))
+		if err == nil && (len(revisions) == 0 || revisions[0].Hash != hash(previous)) {
+			_, err = cu.revisionRepository.AddRevision(&models.ConfigRevision{Config: string(configName)}, previous)
+		}
+		if err != nil {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorUnexpectedError,
+				Message: fmt.Sprintf("Config saved, but unable to save previous revision of %q named config, %v", configName, err),
+			})
+			return configBag, nil
+		}
+	}
+
+	revision.Config = string(configName)
+	saved, err := cu.revisionRepository.AddRevision(revision, content)
+	if err != nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnexpectedError,
+			Message: fmt.Sprintf("Config saved, but unable to save revision of %q named config, %v", configName, err),
+		})
+		return configBag, nil
+	}
+
+	return configBag, saved
+}
+
+// writableNamedConfig return named config and its file path. Only named configs loaded from file can be updated
+func (cu *configUsecase) writableNamedConfig(name string) (coreConfig.ConfigName, string, *models.ConfigError) {
+	if name == "" {
+		name = string(coreConfig.DefaultConfigName)
+	}
+	configName := coreConfig.ConfigName(strings.ToLower(name))
+
+	namedConfig, ok := cu.namedConfigs[configName]
+	if !ok {
+		configError := cu.unknownNamedConfigError(name)
+		return configName, "", &configError
+	}
+
+	if urlRegex.MatchString(namedConfig) {
+		return configName, "", &models.ConfigError{
+			ID:      models.ConfigErrorReadOnlyConfig,
+			Message: fmt.Sprintf(`%q named config is loaded from url. Only named configs loaded from file can be updated`, name),
+			Data:    models.ConfigErrorData{Value: name},
+		}
+	}
+	if models.IsGitSource(namedConfig) {
+		return configName, "", &models.ConfigError{
+			ID:      models.ConfigErrorReadOnlyConfig,
+			Message: fmt.Sprintf(`%q named config is loaded from git repository. Only named configs loaded from file can be updated`, name),
+			Data:    models.ConfigErrorData{Value: name},
+		}
+	}
+
+	return configName, namedConfig, nil
+}
+
+func hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
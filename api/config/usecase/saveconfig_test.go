@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/mocks"
+	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+const saveConfigInput = `{"version": "2.0", "columns": 4, "tiles": [{ "type": "PING", "params": { "hostname": "server.com" }}]}`
+
+func initSaveConfigUsecase(mockRepo *mocks.Repository, mockRevisionRepo *mocks.RevisionRepository) *configUsecase {
+	usecase := initConfigUsecase(mockRepo)
+	usecase.revisionRepository = mockRevisionRepo
+	usecase.namedConfigs = map[coreConfig.ConfigName]string{
+		"screen1": "./screen1.json",
+		"remote":  "https://example.com/config.json",
+		"git":     "git+https://example.com/configs.git#main:screen1.json",
+	}
+	return usecase
+}
+
+func TestUsecase_SaveConfig_Success(t *testing.T) {
+	content := []byte(saveConfigInput)
+	filePath := path.ToAbsolute(path.MonitororBaseDir, "./screen1.json")
+	configBag := readConfigBag(t, saveConfigInput)
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromContent", content, filePath).Return(configBag.Config, nil)
+	mockRepo.On("SaveConfigToPath", path.MonitororBaseDir, "./screen1.json", content).Return([]byte(`{"columns": 2}`), nil)
+
+	mockRevisionRepo := new(mocks.RevisionRepository)
+	mockRevisionRepo.On("GetRevisions", "screen1").Return([]models.ConfigRevision{{ID: "1", Hash: "other"}}, nil)
+	mockRevisionRepo.On("AddRevision", &models.ConfigRevision{Config: "screen1"}, []byte(`{"columns": 2}`)).
+		Return(&models.ConfigRevision{ID: "2"}, nil)
+	mockRevisionRepo.On("AddRevision", &models.ConfigRevision{Config: "screen1", Author: "alice"}, content).
+		Return(&models.ConfigRevision{ID: "3", Config: "screen1", Author: "alice"}, nil)
+
+	usecase := initSaveConfigUsecase(mockRepo, mockRevisionRepo)
+	result, revision := usecase.SaveConfig(&models.ConfigParams{Config: "SCREEN1"}, content, "alice")
+
+	assert.Len(t, result.Errors, 0)
+	assert.Equal(t, hash(content), result.Hash)
+	if assert.NotNil(t, revision) {
+		assert.Equal(t, "3", revision.ID)
+	}
+	mockRepo.AssertExpectations(t)
+	mockRevisionRepo.AssertExpectations(t)
+}
+
+func TestUsecase_SaveConfig_SkipUnchangedPreviousRevision(t *testing.T) {
+	content := []byte(saveConfigInput)
+	configBag := readConfigBag(t, saveConfigInput)
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromContent", content, AnythingOfType("string")).Return(configBag.Config, nil)
+	mockRepo.On("SaveConfigToPath", path.MonitororBaseDir, "./screen1.json", content).Return([]byte(`{"columns": 2}`), nil)
+
+	mockRevisionRepo := new(mocks.RevisionRepository)
+	mockRevisionRepo.On("GetRevisions", "screen1").Return([]models.ConfigRevision{{ID: "1", Hash: hash([]byte(`{"columns": 2}`))}}, nil)
+	mockRevisionRepo.On("AddRevision", AnythingOfType("*models.ConfigRevision"), content).Return(&models.ConfigRevision{ID: "2"}, nil)
+
+	usecase := initSaveConfigUsecase(mockRepo, mockRevisionRepo)
+	result, revision := usecase.SaveConfig(&models.ConfigParams{Config: "screen1"}, content, "")
+
+	assert.Len(t, result.Errors, 0)
+	assert.NotNil(t, revision)
+	mockRevisionRepo.AssertNumberOfCalls(t, "AddRevision", 1)
+}
+
+func TestUsecase_SaveConfig_Error(t *testing.T) {
+	for _, testcase := range []struct {
+		config  string
+		content string
+		errorID models.ConfigErrorID
+	}{
+		{config: "unknown", content: saveConfigInput, errorID: models.ConfigErrorUnknownNamedConfig},
+		{config: "remote", content: saveConfigInput, errorID: models.ConfigErrorReadOnlyConfig},
+		{config: "git", content: saveConfigInput, errorID: models.ConfigErrorReadOnlyConfig},
+		{config: "screen1", content: `{"version": "2.0", "columns": 4, "unknown": true}`, errorID: models.ConfigErrorUnknownField},
+		{config: "screen1", content: `{"version": "2.0", "columns": 4, "tiles": [{ "type": "PING" }]}`, errorID: models.ConfigErrorMissingRequiredField},
+	} {
+		mockRepo := new(mocks.Repository)
+		mockRepo.On("GetConfigFromContent", []byte(testcase.content), AnythingOfType("string")).
+			Return(func(content []byte, _ string) *models.Config {
+				configBag, _ := readConfig(string(content))
+				return configBag.Config
+			}, func(content []byte, _ string) error {
+				_, err := readConfig(string(content))
+				return err
+			})
+
+		usecase := initSaveConfigUsecase(mockRepo, new(mocks.RevisionRepository))
+		result, revision := usecase.SaveConfig(&models.ConfigParams{Config: testcase.config}, []byte(testcase.content), "alice")
+
+		assert.Nil(t, revision)
+		if assert.Len(t, result.Errors, 1) {
+			assert.Equal(t, testcase.errorID, result.Errors[0].ID)
+		}
+		mockRepo.AssertNotCalled(t, "SaveConfigToPath", Anything, Anything, Anything)
+	}
+}
+
+func TestUsecase_SaveConfig_WriteError(t *testing.T) {
+	content := []byte(saveConfigInput)
+	configBag := readConfigBag(t, saveConfigInput)
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromContent", content, AnythingOfType("string")).Return(configBag.Config, nil)
+	mockRepo.On("SaveConfigToPath", Anything, Anything, content).Return(nil, errors.New("boom"))
+
+	usecase := initSaveConfigUsecase(mockRepo, new(mocks.RevisionRepository))
+	result, revision := usecase.SaveConfig(&models.ConfigParams{Config: "screen1"}, content, "alice")
+
+	assert.Nil(t, revision)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorUnexpectedError, result.Errors[0].ID)
+	}
+}
+
+func TestUsecase_GetConfigRevisions(t *testing.T) {
+	revisions := []models.ConfigRevision{{ID: "2"}, {ID: "1"}}
+
+	mockRevisionRepo := new(mocks.RevisionRepository)
+	mockRevisionRepo.On("GetRevisions", "screen1").Return(revisions, nil)
+
+	usecase := initSaveConfigUsecase(nil, mockRevisionRepo)
+	result, configError := usecase.GetConfigRevisions(&models.ConfigParams{Config: "screen1"})
+	assert.Nil(t, configError)
+	assert.Equal(t, revisions, result)
+
+	_, configError = usecase.GetConfigRevisions(&models.ConfigParams{Config: "remote"})
+	if assert.NotNil(t, configError) {
+		assert.Equal(t, models.ConfigErrorReadOnlyConfig, configError.ID)
+	}
+}
+
+func TestUsecase_RestoreConfigRevision(t *testing.T) {
+	content := []byte(saveConfigInput)
+	configBag := readConfigBag(t, saveConfigInput)
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromContent", content, AnythingOfType("string")).Return(configBag.Config, nil)
+	mockRepo.On("SaveConfigToPath", path.MonitororBaseDir, "./screen1.json", content).Return(content, nil)
+
+	mockRevisionRepo := new(mocks.RevisionRepository)
+	mockRevisionRepo.On("GetRevision", "screen1", "1").Return(&models.ConfigRevision{ID: "1"}, content, nil)
+	mockRevisionRepo.On("GetRevision", "screen1", "2").Return(nil, nil, &models.ConfigRevisionNotFoundError{Config: "screen1", Revision: "2"})
+	mockRevisionRepo.On("GetRevisions", "screen1").Return([]models.ConfigRevision{{ID: "3", Hash: hash(content)}}, nil)
+	mockRevisionRepo.On("AddRevision", &models.ConfigRevision{Config: "screen1", Author: "alice", RestoredFrom: "1"}, content).
+		Return(&models.ConfigRevision{ID: "4", RestoredFrom: "1"}, nil)
+
+	usecase := initSaveConfigUsecase(mockRepo, mockRevisionRepo)
+	result, revision := usecase.RestoreConfigRevision(&models.ConfigParams{Config: "screen1"}, "1", "alice")
+	assert.Len(t, result.Errors, 0)
+	if assert.NotNil(t, revision) {
+		assert.Equal(t, "1", revision.RestoredFrom)
+	}
+
+	result, revision = usecase.RestoreConfigRevision(&models.ConfigParams{Config: "screen1"}, "2", "alice")
+	assert.Nil(t, revision)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorRevisionNotFound, result.Errors[0].ID)
+		assert.Equal(t, "2", result.Errors[0].Data.Value)
+	}
+
+	mockRepo.AssertExpectations(t)
+	mockRevisionRepo.AssertExpectations(t)
+}
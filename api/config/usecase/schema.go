@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+	"github.com/monitoror/monitoror/internal/pkg/jsonschema"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/registry"
+)
+
+// GetSchema return JSON Schema of config in current version.
+// Params are described for every enabled variant of tile types and generators (see registry)
+func (cu *configUsecase) GetSchema() *jsonschema.Schema {
+	version := versions.CurrentVersion.ToConfigVersion()
+
+	reflector := jsonschema.NewReflector(version)
+	reflector.Types[reflect.TypeOf(versions.ConfigVersion{})] = &jsonschema.Schema{
+		Type:        "string",
+		Pattern:     `^[0-9]+\.[0-9]+$`,
+		Description: fmt.Sprintf("Config version, from %s to %s", versions.MinimalVersion, versions.CurrentVersion),
+	}
+
+	schema := reflector.Reflect(models.Config{})
+	schema.Schema = jsonschema.Draft07
+	schema.Title = "Monitoror config"
+	schema.Description = fmt.Sprintf("Generated for config version %s", versions.CurrentVersion)
+	schema.Required = append([]string{"version"}, schema.Required...)
+	schema.AnyOf = []*jsonschema.Schema{{Required: []string{"columns", "tiles"}}, {Required: []string{"pages"}}}
+	// Templates are merged with tiles using them, they can be partial
+	schema.Properties["templates"] = &jsonschema.Schema{Type: "object", AdditionalProperties: &jsonschema.Schema{Type: "object"}}
+
+	// Tiles can use a template instead of a type
+	tileSchema := reflector.Definitions["TileConfig"]
+	tileSchema.Required = removeString(tileSchema.Required, "type")
+	tileSchema.AnyOf = []*jsonschema.Schema{{Required: []string{"type"}}, {Required: []string{"template"}}}
+
+	// Params of tiles are validated by type and variant. Numbers and booleans can be replaced by variables
+	reflector.Definitions["variable"] = &jsonschema.Schema{Type: "string", Pattern: `^\$\{[A-Za-z0-9_.-]+\}$`}
+	reflector.Alternative = jsonschema.Ref("variable")
+
+	tileTypes := []interface{}{string(EmptyTileType), string(GroupTileType)}
+	tileSchema.AllOf = append(tileSchema.AllOf, &jsonschema.Schema{
+		If: tileTypeCondition(GroupTileType),
+		Then: &jsonschema.Schema{
+			Required: []string{"tiles"},
+			Not:      &jsonschema.Schema{Required: []string{"params"}},
+		},
+	})
+
+	explorers := make(map[coreModels.TileType]registry.TileMetadataExplorer)
+	for tileType, metadata := range cu.registry.TileMetadata {
+		explorers[tileType] = metadata
+	}
+	for tileType, metadata := range cu.registry.GeneratorMetadata {
+		explorers[tileType] = metadata
+	}
+
+	var sortedTileTypes []string
+	for tileType := range explorers {
+		sortedTileTypes = append(sortedTileTypes, string(tileType))
+	}
+	sort.Strings(sortedTileTypes)
+
+	for _, name := range sortedTileTypes {
+		tileType := coreModels.TileType(name)
+		explorer := explorers[tileType]
+		if version.IsLessThan(explorer.GetMinimalVersion()) {
+			continue
+		}
+
+		var variants []interface{}
+		var paramsSchemas []*jsonschema.Schema
+		for _, variantName := range sortVariantNames(explorer.GetVariantsNames()) {
+			variant, _ := explorer.GetVariant(variantName)
+			if !variant.IsEnabled() {
+				continue
+			}
+
+			definition := fmt.Sprintf("params.%s.%s", tileType, variantName)
+			reflector.Definitions[definition] = reflector.Reflect(variant.GetValidator())
+			variants = append(variants, string(variantName))
+
+			// Default variant is used when configVariant is missing
+			variantCondition := &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{"configVariant": {Const: string(variantName)}},
+			}
+			if variantName != coreModels.DefaultVariantName {
+				variantCondition.Required = []string{"configVariant"}
+			}
+			paramsSchemas = append(paramsSchemas, &jsonschema.Schema{
+				If: variantCondition,
+				Then: &jsonschema.Schema{
+					Required:   []string{"params"},
+					Properties: map[string]*jsonschema.Schema{"params": jsonschema.Ref(definition)},
+				},
+			})
+		}
+		if len(variants) == 0 {
+			continue
+		}
+
+		tileTypes = append(tileTypes, name)
+		tileSchema.AllOf = append(tileSchema.AllOf, &jsonschema.Schema{
+			If: tileTypeCondition(tileType),
+			Then: &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{"configVariant": {Enum: variants}},
+				AllOf:      paramsSchemas,
+			},
+		})
+	}
+
+	tileSchema.Properties["type"] = &jsonschema.Schema{Type: "string", Enum: tileTypes}
+	schema.Definitions = reflector.Definitions
+
+	return schema
+}
+
+func tileTypeCondition(tileType coreModels.TileType) *jsonschema.Schema {
+	return &jsonschema.Schema{
+		Required:   []string{"type"},
+		Properties: map[string]*jsonschema.Schema{"type": {Const: string(tileType)}},
+	}
+}
+
+func sortVariantNames(variantNames []coreModels.VariantName) []coreModels.VariantName {
+	sorted := append([]coreModels.VariantName{}, variantNames...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func removeString(values []string, value string) []string {
+	var result []string
+	for _, v := range values {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/monitoror/monitoror/internal/pkg/jsonschema"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_GetSchema(t *testing.T) {
+	usecase := initConfigUsecase(nil)
+
+	schema := usecase.GetSchema()
+
+	assert.Equal(t, jsonschema.Draft07, schema.Schema)
+	assert.Contains(t, schema.Required, "version")
+	assert.Contains(t, schema.Properties, "columns")
+	assert.Contains(t, schema.Properties, "pages")
+	assert.Equal(t, jsonschema.Ref("PageConfig"), schema.Properties["pages"].Items)
+
+	tileSchema := schema.Definitions["TileConfig"]
+	if assert.NotNil(t, tileSchema) {
+		assert.NotContains(t, tileSchema.Required, "type")
+		assert.Equal(t, []interface{}{"EMPTY", "GROUP", "JENKINS-BUILD", "PING", "PINGDOM-CHECK", "PORT"}, tileSchema.Properties["type"].Enum)
+		// GROUP + one rule by tile type
+		assert.Len(t, tileSchema.AllOf, 5)
+	}
+
+	pingParams := schema.Definitions["params.PING.default"]
+	if assert.NotNil(t, pingParams) {
+		assert.Equal(t, []string{"hostname"}, pingParams.Required)
+		assert.Equal(t, false, pingParams.AdditionalProperties)
+	}
+
+	portParams := schema.Definitions["params.PORT.default"]
+	if assert.NotNil(t, portParams) {
+		assert.ElementsMatch(t, []string{"hostname", "port"}, portParams.Required)
+		if assert.Len(t, portParams.Properties["port"].AnyOf, 2) {
+			assert.Equal(t, float64(0), *portParams.Properties["port"].AnyOf[0].ExclusiveMinimum)
+			assert.Equal(t, jsonschema.Ref("variable"), portParams.Properties["port"].AnyOf[1])
+		}
+	}
+
+	// Disabled variants are not described
+	assert.NotContains(t, schema.Definitions, "params.JENKINS-BUILD.disabledVariant")
+	assert.Contains(t, schema.Definitions, "params.JENKINS-BUILD."+string(coreModels.DefaultVariantName))
+
+	_, err := json.Marshal(schema)
+	assert.NoError(t, err)
+}
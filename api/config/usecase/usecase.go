@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"sync"
 	"time"
 
 	"github.com/monitoror/monitoror/api/config"
@@ -18,11 +19,15 @@ const (
 	GroupTileType coreModels.TileType = "GROUP"
 
 	TileGeneratorStoreKeyPrefix = "monitoror.config.tileGenerator.key"
+
+	// DefaultPageDuration is the display duration of pages without "duration", in seconds
+	DefaultPageDuration = 30
 )
 
 type (
 	configUsecase struct {
-		repository config.Repository
+		repository         config.Repository
+		revisionRepository config.RevisionRepository
 
 		registry *registry.MetadataRegistry
 
@@ -34,10 +39,32 @@ type (
 		cacheExpiration    time.Duration
 
 		initialMaxDelay int
+
+		// now is used to evaluate "when" conditions of tiles and schedules
+		now func() time.Time
+
+		// lastLoads contains last time named configs were loaded, used in config list
+		lastLoads    map[coreConfig.ConfigName]time.Time
+		lastLoadLock sync.RWMutex
+
+		// authSecret used to sign tile URLs, empty when auth is disabled
+		authSecret string
+
+		// saveLock avoid concurrent writes of config files and revisions
+		saveLock sync.Mutex
+
+		// configHashes contains hash of named configs content, kept up to date by watcher (see StartWatching)
+		configHashes map[coreConfig.ConfigName]*models.ConfigHash
+		// remoteContents contains last content of named configs loaded from url, used for conditional requests
+		remoteContents map[coreConfig.ConfigName]*models.ConfigContent
+		pollInterval   time.Duration
+		watchLock      sync.RWMutex
+		// watchDone is closed to stop watcher, nil when watcher isn't running
+		watchDone chan struct{}
 	}
 )
 
-func NewConfigUsecase(repository config.Repository, store *store.Store) config.Usecase {
+func NewConfigUsecase(repository config.Repository, revisionRepository config.RevisionRepository, store *store.Store) config.Usecase {
 	tileConfigs := make(map[coreModels.TileType]map[string]*models.TileConfig)
 
 	// Used for authorized type
@@ -46,10 +73,31 @@ func NewConfigUsecase(repository config.Repository, store *store.Store) config.U
 
 	return &configUsecase{
 		repository:         repository,
+		revisionRepository: revisionRepository,
 		registry:           store.Registry.(*registry.MetadataRegistry),
 		namedConfigs:       store.CoreConfig.NamedConfigs,
 		generatorTileStore: store.CacheStore,
 		cacheExpiration:    time.Millisecond * time.Duration(store.CoreConfig.DownstreamCacheExpiration),
 		initialMaxDelay:    store.CoreConfig.InitialMaxDelay,
+		now:                time.Now,
+		lastLoads:          make(map[coreConfig.ConfigName]time.Time),
+		authSecret:         authSecret(store.CoreConfig),
+		configHashes:       make(map[coreConfig.ConfigName]*models.ConfigHash),
+		remoteContents:     make(map[coreConfig.ConfigName]*models.ConfigContent),
+		pollInterval:       pollInterval(store.CoreConfig),
+	}
+}
+
+func authSecret(config *coreConfig.CoreConfig) string {
+	if !config.IsAuthEnabled() {
+		return ""
+	}
+	return config.AuthSecret
+}
+
+func pollInterval(config *coreConfig.CoreConfig) time.Duration {
+	if config.ConfigPollInterval <= 0 {
+		return 30 * time.Second
 	}
+	return time.Millisecond * time.Duration(config.ConfigPollInterval)
 }
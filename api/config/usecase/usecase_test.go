@@ -36,7 +36,7 @@ func initConfigUsecase(repository config.Repository) *configUsecase {
 		Registry:   registry.NewRegistry(),
 	}
 
-	usecase := NewConfigUsecase(repository, s).(*configUsecase)
+	usecase := NewConfigUsecase(repository, nil, s).(*configUsecase)
 
 	usecase.registry.RegisterTile(pingApi.PingTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariantName}).
 		Enable(coreModels.DefaultVariantName, &pingModels.PingParams{}, "/ping/default/ping")
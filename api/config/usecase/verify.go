@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/fatih/structs"
 
@@ -56,10 +57,215 @@ func (cu *configUsecase) Verify(configBag *models.ConfigBag) {
 		return
 	}
 
+	// Columns and tiles are only required without pages
+	if len(configBag.Config.Pages) == 0 {
+		for _, field := range []struct {
+			name    string
+			missing bool
+		}{
+			{name: "columns", missing: configBag.Config.Columns == nil},
+			{name: "tiles", missing: configBag.Config.Tiles == nil},
+		} {
+			if field.missing {
+				configBag.AddErrors(models.ConfigError{
+					ID:      models.ConfigErrorMissingRequiredField,
+					Message: fmt.Sprintf(`Required %q field is missing.`, field.name),
+					Data: models.ConfigErrorData{
+						FieldName:     field.name,
+						ConfigExtract: pkgConfig.Stringify(configBag.Config),
+					},
+				})
+			}
+		}
+		if len(configBag.Errors) > 0 {
+			return
+		}
+	}
+
+	if configBag.Config.Timezone != "" {
+		if _, err := time.LoadLocation(configBag.Config.Timezone); err != nil {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorInvalidFieldValue,
+				Message: fmt.Sprintf(`Invalid "timezone" field. %v`, err),
+				Data: models.ConfigErrorData{
+					FieldName: "timezone",
+					Value:     pkgConfig.Stringify(configBag.Config.Timezone),
+					Expected:  `IANA time zone name (ex: "Europe/Paris")`,
+				},
+			})
+		}
+	}
+
+	if len(configBag.Config.Pages) > 0 {
+		cu.verifyPages(configBag)
+		return
+	}
+
 	// Iterating through every config tiles
 	for _, tile := range configBag.Config.Tiles {
 		cu.verifyTile(configBag, &tile, nil)
 	}
+
+	// Every schedule tiles are verified, any of them can be displayed
+	for _, schedule := range configBag.Config.Schedule {
+		cu.verifySchedule(configBag, &schedule)
+	}
+}
+
+// verifyPages verify tiles page by page, errors are prefixed by page index
+func (cu *configUsecase) verifyPages(configBag *models.ConfigBag) {
+	config := configBag.Config
+
+	for _, field := range []struct {
+		name    string
+		defined bool
+	}{
+		{name: "tiles", defined: config.Tiles != nil},
+		{name: "schedule", defined: config.Schedule != nil},
+	} {
+		if field.defined {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorUnauthorizedField,
+				Message: fmt.Sprintf(`Unauthorized %q field with "pages" field. Tiles must be defined in pages.`, field.name),
+				Data: models.ConfigErrorData{
+					FieldName: field.name,
+				},
+			})
+		}
+	}
+	if len(configBag.Errors) > 0 {
+		return
+	}
+
+	for i, page := range config.Pages {
+		pageBag := &models.ConfigBag{Config: config}
+		cu.verifyPage(pageBag, &page)
+		configBag.AddErrors(prefixPageErrors(i, pageBag.Errors)...)
+	}
+}
+
+func (cu *configUsecase) verifyPage(configBag *models.ConfigBag, page *models.PageConfig) {
+	extract := pkgConfig.Stringify(page)
+
+	// Validate struct with "validate" and "available" tag
+	errors := validateStruct(page, configBag.Config.Version)
+	if len(errors) > 0 {
+		for _, err := range errors {
+			// Convert validator.Error into ConfigError
+			configError := convertValidatorError(err, page, extract)
+			configBag.AddErrors(*configError)
+		}
+		return
+	}
+
+	if page.Columns == nil && configBag.Config.Columns == nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorMissingRequiredField,
+			Message: `Required "columns" field is missing. Must be defined in page or in config.`,
+			Data: models.ConfigErrorData{
+				FieldName:     "columns",
+				ConfigExtract: extract,
+			},
+		})
+		return
+	}
+
+	for _, tile := range page.Tiles {
+		cu.verifyTile(configBag, &tile, nil)
+	}
+}
+
+// prefixPageErrors prefix messages of page errors by page index, like "pages[1]: ..."
+func prefixPageErrors(index int, errors []models.ConfigError) []models.ConfigError {
+	for i := range errors {
+		errors[i].Message = fmt.Sprintf("pages[%d]: %s", index, errors[i].Message)
+	}
+	return errors
+}
+
+func (cu *configUsecase) verifySchedule(configBag *models.ConfigBag, schedule *models.ScheduleConfig) {
+	extract := pkgConfig.Stringify(schedule)
+
+	// Validate struct with "validate" and "available" tag
+	errors := validateStruct(schedule, configBag.Config.Version)
+	if len(errors) > 0 {
+		for _, err := range errors {
+			// Convert validator.Error into ConfigError
+			configError := convertValidatorError(err, schedule, extract)
+			configBag.AddErrors(*configError)
+		}
+		return
+	}
+
+	if !cu.verifyCondition(configBag, schedule.When, extract) {
+		return
+	}
+
+	for _, tile := range schedule.Tiles {
+		cu.verifyTile(configBag, &tile, nil)
+	}
+}
+
+// verifyCondition check "when" fields of tile or schedule. Variable is checked during expansion
+func (cu *configUsecase) verifyCondition(configBag *models.ConfigBag, condition *models.TileCondition, extract string) bool {
+	if condition.Time == "" && len(condition.Weekdays) == 0 && condition.Variable == "" {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorMissingRequiredField,
+			Message: `Empty "when" condition. Must contain "time", "weekdays" or "variable" field.`,
+			Data: models.ConfigErrorData{
+				FieldName:     "when",
+				ConfigExtract: extract,
+				Expected:      "time, weekdays, variable",
+			},
+		})
+		return false
+	}
+
+	if condition.Time != "" {
+		if _, _, err := models.ParseTimeRange(condition.Time); err != nil {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorInvalidFieldValue,
+				Message: fmt.Sprintf(`Invalid "time" field in "when" condition, %v.`, err),
+				Data: models.ConfigErrorData{
+					FieldName:     "time",
+					Value:         pkgConfig.Stringify(condition.Time),
+					ConfigExtract: extract,
+					Expected:      `"HH:MM-HH:MM"`,
+				},
+			})
+			return false
+		}
+	}
+
+	for _, weekday := range condition.Weekdays {
+		if _, err := models.ParseWeekday(weekday); err != nil {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorInvalidFieldValue,
+				Message: fmt.Sprintf(`Invalid "weekdays" field in "when" condition, %v.`, err),
+				Data: models.ConfigErrorData{
+					FieldName:     "weekdays",
+					Value:         pkgConfig.Stringify(weekday),
+					ConfigExtract: extract,
+					Expected:      models.WeekdayNames,
+				},
+			})
+			return false
+		}
+	}
+
+	if condition.Equals != nil && condition.Variable == "" {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnauthorizedField,
+			Message: `Unauthorized "equals" field in "when" condition without "variable" field.`,
+			Data: models.ConfigErrorData{
+				FieldName:     "equals",
+				ConfigExtract: extract,
+			},
+		})
+		return false
+	}
+
+	return true
 }
 
 func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.TileConfig, groupTile *models.TileConfig) {
@@ -68,12 +274,16 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 	if len(errors) > 0 {
 		for _, err := range errors {
 			// Convert validator.Error into ConfigError
-			configError := convertValidatorError(err, tile, pkgConfig.Stringify(tile))
+			configError := convertValidatorError(err, tile, tile.Extract())
 			configBag.AddErrors(*configError)
 		}
 		return
 	}
 
+	if tile.When != nil && !cu.verifyCondition(configBag, tile.When, tile.Extract()) {
+		return
+	}
+
 	// Empty tile, skip
 	if tile.Type == EmptyTileType {
 		if groupTile != nil {
@@ -81,8 +291,8 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				ID:      models.ConfigErrorUnauthorizedSubtileType,
 				Message: fmt.Sprintf(`Unauthorized %q type in %s tile.`, EmptyTileType, GroupTileType),
 				Data: models.ConfigErrorData{
-					ConfigExtract:          pkgConfig.Stringify(groupTile),
-					ConfigExtractHighlight: pkgConfig.Stringify(tile),
+					ConfigExtract:          groupTile.Extract(),
+					ConfigExtractHighlight: tile.Extract(),
 				},
 			})
 		}
@@ -96,8 +306,8 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				ID:      models.ConfigErrorUnauthorizedSubtileType,
 				Message: fmt.Sprintf(`Unauthorized %q type in %s tile.`, GroupTileType, GroupTileType),
 				Data: models.ConfigErrorData{
-					ConfigExtract:          pkgConfig.Stringify(groupTile),
-					ConfigExtractHighlight: pkgConfig.Stringify(tile),
+					ConfigExtract:          groupTile.Extract(),
+					ConfigExtractHighlight: tile.Extract(),
 				},
 			})
 			return
@@ -109,7 +319,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unauthorized "params" key in %s tile definition.`, tile.Type),
 				Data: models.ConfigErrorData{
 					FieldName:     "params",
-					ConfigExtract: pkgConfig.Stringify(tile),
+					ConfigExtract: tile.Extract(),
 				},
 			})
 			return
@@ -121,7 +331,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Missing "tiles" field in %s tile definition. Must be a non-empty array.`, tile.Type),
 				Data: models.ConfigErrorData{
 					FieldName:     "tiles",
-					ConfigExtract: pkgConfig.Stringify(tile),
+					ConfigExtract: tile.Extract(),
 				},
 			})
 		} else if len(tile.Tiles) == 0 {
@@ -130,7 +340,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Invalid "tiles" field in %s tile definition. Must be a non-empty array.`, tile.Type),
 				Data: models.ConfigErrorData{
 					FieldName:     "tiles",
-					ConfigExtract: pkgConfig.Stringify(tile),
+					ConfigExtract: tile.Extract(),
 				},
 			})
 			return
@@ -160,7 +370,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unknown %q generator type in tile definition. Must be %s`, tile.Type, pkgConfig.Keys(cu.registry.GeneratorMetadata)),
 				Data: models.ConfigErrorData{
 					FieldName:     "type",
-					ConfigExtract: pkgConfig.Stringify(tile),
+					ConfigExtract: tile.Extract(),
 					Expected:      pkgConfig.Keys(cu.registry.GeneratorMetadata),
 				},
 			})
@@ -175,7 +385,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unknown %q generator type in tile definition. Must be %s`, tile.Type, pkgConfig.Keys(cu.registry.TileMetadata)),
 				Data: models.ConfigErrorData{
 					FieldName:     "type",
-					ConfigExtract: pkgConfig.Stringify(tile),
+					ConfigExtract: tile.Extract(),
 					Expected:      pkgConfig.Keys(cu.registry.TileMetadata),
 				},
 			})
@@ -191,7 +401,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				tile.Type, configBag.Config.Version, string(metadataExplorer.GetMinimalVersion())),
 			Data: models.ConfigErrorData{
 				FieldName:     "type",
-				ConfigExtract: pkgConfig.Stringify(tile),
+				ConfigExtract: tile.Extract(),
 				Expected:      fmt.Sprintf(`version >= %q`, string(metadataExplorer.GetMinimalVersion())),
 			},
 		})
@@ -209,7 +419,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				FieldName:     "configVariant",
 				Value:         pkgConfig.Stringify(tile.ConfigVariant),
 				Expected:      pkgConfig.Stringify(metadataExplorer.GetVariantsNames()),
-				ConfigExtract: pkgConfig.Stringify(tile),
+				ConfigExtract: tile.Extract(),
 			},
 		})
 		return
@@ -223,7 +433,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 			Data: models.ConfigErrorData{
 				FieldName:     "configVariant",
 				Value:         pkgConfig.Stringify(tile.ConfigVariant),
-				ConfigExtract: pkgConfig.Stringify(tile),
+				ConfigExtract: tile.Extract(),
 			},
 		})
 		return
@@ -236,7 +446,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 			Message: fmt.Sprintf(`Missing "params" key in %s tile definition.`, tile.Type),
 			Data: models.ConfigErrorData{
 				FieldName:     "params",
-				ConfigExtract: pkgConfig.Stringify(tile),
+				ConfigExtract: tile.Extract(),
 			},
 		})
 		return
@@ -254,7 +464,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 			Message: unmarshalErr.Error(),
 			Data: models.ConfigErrorData{
 				FieldName:     "params",
-				ConfigExtract: pkgConfig.Stringify(tile),
+				ConfigExtract: tile.Extract(),
 			},
 		})
 		return
@@ -273,7 +483,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unknown %q tile params field.`, field),
 				Data: models.ConfigErrorData{
 					FieldName:     field,
-					ConfigExtract: pkgConfig.Stringify(tile),
+					ConfigExtract: tile.Extract(),
 					Expected:      pkgConfig.Keys(structParams),
 				},
 			})
@@ -287,7 +497,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 	errors = append(errors, castedParams.Validate()...)
 
 	for _, vError := range errors {
-		configError := convertValidatorError(vError, rInstance, pkgConfig.Stringify(tile))
+		configError := convertValidatorError(vError, rInstance, tile.Extract())
 
 		// UX HACK: if params is empty, inject "params:{}" to help users
 		if len(tile.Params) == 0 {
@@ -460,3 +460,136 @@ func TestUsecase_VerifyTile_FieldMinimalVersion(t *testing.T) {
 		assert.Equal(t, `{"type":"TEST","params":{"field1":"server.com"},"configVariant":"default"}`, conf.Errors[0].Data.ConfigExtract)
 	}
 }
+
+func TestUsecase_Verify_WithCondition_Failed(t *testing.T) {
+	for _, testcase := range []struct {
+		config    string
+		errorID   models.ConfigErrorID
+		fieldName string
+	}{
+		{
+			config:    `"timezone": "Mars/Olympus", "tiles": [{ "type": "EMPTY" }]`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			fieldName: "timezone",
+		},
+		{
+			config:    `"tiles": [{ "type": "EMPTY", "when": {} }]`,
+			errorID:   models.ConfigErrorMissingRequiredField,
+			fieldName: "when",
+		},
+		{
+			config:    `"tiles": [{ "type": "EMPTY", "when": { "time": "8h-19h" } }]`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			fieldName: "time",
+		},
+		{
+			config:    `"tiles": [{ "type": "GROUP", "tiles": [{ "type": "PING", "when": { "weekdays": ["lundi"] }, "params": { "hostname": "server.com" } }]}]`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			fieldName: "weekdays",
+		},
+		{
+			config:    `"tiles": [{ "type": "EMPTY", "when": { "time": "08:00-19:00", "equals": "test" } }]`,
+			errorID:   models.ConfigErrorUnauthorizedField,
+			fieldName: "equals",
+		},
+		{
+			config:    `"tiles": [{ "type": "EMPTY" }], "schedule": [{ "tiles": [{ "type": "EMPTY" }] }]`,
+			errorID:   models.ConfigErrorMissingRequiredField,
+			fieldName: "when",
+		},
+		{
+			config:    `"tiles": [{ "type": "EMPTY" }], "schedule": [{ "when": { "time": "19:00-08:00" }, "tiles": [] }]`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			fieldName: "tiles",
+		},
+		{
+			config:    `"tiles": [{ "type": "EMPTY" }], "schedule": [{ "when": { "time": "19:00-08:00" }, "tiles": [{ "type": "UNKNOWN" }] }]`,
+			errorID:   models.ConfigErrorUnknownTileType,
+			fieldName: "type",
+		},
+	} {
+		conf, err := readConfig(fmt.Sprintf(`{ "version": %q, "columns": 4, %s }`, versions.CurrentVersion, testcase.config))
+		if assert.NoError(t, err, testcase.config) {
+			usecase := initConfigUsecase(nil)
+			usecase.Verify(conf)
+
+			if assert.Len(t, conf.Errors, 1, testcase.config) {
+				assert.Equal(t, testcase.errorID, conf.Errors[0].ID, testcase.config)
+				assert.Equal(t, testcase.fieldName, conf.Errors[0].Data.FieldName, testcase.config)
+			}
+		}
+	}
+}
+
+func TestUsecase_Verify_WithPages(t *testing.T) {
+	rawConfig := fmt.Sprintf(`
+{
+  "version": %q,
+  "columns": 4,
+  "pages": [
+    { "tiles": [{ "type": "PING", "params": { "hostname": "server.com" } }] },
+    { "columns": 2, "zoom": 1.5, "duration": 60, "tiles": [{ "type": "EMPTY" }] }
+  ]
+}
+`, versions.CurrentVersion)
+
+	conf, err := readConfig(rawConfig)
+	if assert.NoError(t, err) {
+		usecase := initConfigUsecase(nil)
+		usecase.Verify(conf)
+
+		assert.Len(t, conf.Errors, 0)
+	}
+}
+
+func TestUsecase_Verify_WithPages_Failed(t *testing.T) {
+	for _, testcase := range []struct {
+		config    string
+		errorID   models.ConfigErrorID
+		fieldName string
+		message   string
+	}{
+		{
+			config:    `"pages": [{ "tiles": [{ "type": "EMPTY" }] }]`,
+			errorID:   models.ConfigErrorMissingRequiredField,
+			fieldName: "columns",
+			message:   `pages[0]: Required "columns" field is missing. Must be defined in page or in config.`,
+		},
+		{
+			config:    `"columns": 4, "tiles": [{ "type": "EMPTY" }], "pages": [{ "tiles": [{ "type": "EMPTY" }] }]`,
+			errorID:   models.ConfigErrorUnauthorizedField,
+			fieldName: "tiles",
+			message:   `Unauthorized "tiles" field with "pages" field. Tiles must be defined in pages.`,
+		},
+		{
+			config:    `"columns": 4, "pages": [{ "tiles": [{ "type": "EMPTY" }] }, { "duration": 0, "tiles": [{ "type": "EMPTY" }] }]`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			fieldName: "duration",
+			message:   `pages[1]: Invalid "duration" field. Must be greater than 0.`,
+		},
+		{
+			config:    `"columns": 4, "pages": [{ "tiles": [{ "type": "EMPTY" }] }, { "tiles": [{ "type": "PING", "params": {} }] }]`,
+			errorID:   models.ConfigErrorMissingRequiredField,
+			fieldName: "hostname",
+			message:   `pages[1]: Required "hostname" field is missing.`,
+		},
+		{
+			config:    `"tiles": [{ "type": "EMPTY" }]`,
+			errorID:   models.ConfigErrorMissingRequiredField,
+			fieldName: "columns",
+			message:   `Required "columns" field is missing.`,
+		},
+	} {
+		conf, err := readConfig(fmt.Sprintf(`{ "version": %q, %s }`, versions.CurrentVersion, testcase.config))
+		if assert.NoError(t, err, testcase.config) {
+			usecase := initConfigUsecase(nil)
+			usecase.Verify(conf)
+
+			if assert.Len(t, conf.Errors, 1, testcase.config) {
+				assert.Equal(t, testcase.errorID, conf.Errors[0].ID, testcase.config)
+				assert.Equal(t, testcase.fieldName, conf.Errors[0].Data.FieldName, testcase.config)
+				assert.Equal(t, testcase.message, conf.Errors[0].Message, testcase.config)
+			}
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/labstack/gommon/log"
+)
+
+// GetConfigHash return hash of named config content. UI poll it to reload config when it change.
+// When watcher is running, hash is returned from memory, otherwise content is read again
+func (cu *configUsecase) GetConfigHash(params *models.ConfigParams) (*models.ConfigHash, *models.ConfigError) {
+	name := params.Config
+	if name == "" {
+		name = string(coreConfig.DefaultConfigName)
+	}
+	configName := coreConfig.ConfigName(strings.ToLower(name))
+
+	if _, ok := cu.namedConfigs[configName]; !ok {
+		configError := cu.unknownNamedConfigError(name)
+		return nil, &configError
+	}
+
+	if configHash := cu.watchedConfigHash(configName); configHash != nil {
+		return configHash, nil
+	}
+
+	configHash, err := cu.refreshConfigHash(configName)
+	if err != nil {
+		configBag := &models.ConfigBag{}
+		cu.addRepositoryError(configBag, err)
+		return nil, &configBag.Errors[0]
+	}
+
+	return configHash, nil
+}
+
+// StartWatching compute hash of every named configs, then watch config files and poll config urls / git repositories to update them
+func (cu *configUsecase) StartWatching() {
+	cu.watchLock.Lock()
+	defer cu.watchLock.Unlock()
+
+	if cu.watchDone != nil {
+		return
+	}
+	cu.watchDone = make(chan struct{})
+
+	// Files are replaced on save (see SaveConfigToPath), parent directories are watched to follow them
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Warnf("unable to watch config files, changes will not be detected. %v", err)
+	} else {
+		directories := make(map[string]bool)
+		for _, namedConfig := range cu.namedConfigs {
+			if !isLocalConfig(namedConfig) {
+				continue
+			}
+
+			directory := filepath.Dir(path.ToAbsolute(path.MonitororBaseDir, namedConfig))
+			if directories[directory] {
+				continue
+			}
+			directories[directory] = true
+
+			if err := watcher.Add(directory); err != nil {
+				log.Warnf("unable to watch %s, changes of its config files will not be detected. %v", directory, err)
+			}
+		}
+	}
+
+	go cu.watch(watcher, cu.watchDone)
+}
+
+// StopWatching stop watcher started by StartWatching
+func (cu *configUsecase) StopWatching() {
+	cu.watchLock.Lock()
+	defer cu.watchLock.Unlock()
+
+	if cu.watchDone == nil {
+		return
+	}
+	close(cu.watchDone)
+	cu.watchDone = nil
+}
+
+func (cu *configUsecase) watch(watcher *fsnotify.Watcher, done chan struct{}) {
+	// Channels stay nil (never selected) when files can't be watched
+	var events chan fsnotify.Event
+	var watchErrors chan error
+	if watcher != nil {
+		defer watcher.Close()
+		events = watcher.Events
+		watchErrors = watcher.Errors
+	}
+
+	ticker := time.NewTicker(cu.pollInterval)
+	defer ticker.Stop()
+
+	for configName := range cu.namedConfigs {
+		cu.logRefreshConfigHash(configName)
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case event := <-events:
+			for configName, namedConfig := range cu.namedConfigs {
+				if isLocalConfig(namedConfig) && path.ToAbsolute(path.MonitororBaseDir, namedConfig) == filepath.Clean(event.Name) {
+					cu.logRefreshConfigHash(configName)
+				}
+			}
+		case err := <-watchErrors:
+			log.Warnf("error while watching config files, %v", err)
+		case <-ticker.C:
+			for configName, namedConfig := range cu.namedConfigs {
+				if !isLocalConfig(namedConfig) {
+					cu.logRefreshConfigHash(configName)
+				}
+			}
+		}
+	}
+}
+
+func (cu *configUsecase) logRefreshConfigHash(configName coreConfig.ConfigName) {
+	// Previous hash is kept on error (like removed file or unavailable url)
+	if _, err := cu.refreshConfigHash(configName); err != nil {
+		log.Debugf("unable to check changes of %q named config, %v", configName, err)
+	}
+}
+
+// isLocalConfig return true for named configs loaded from file, watched instead of being polled
+func isLocalConfig(namedConfig string) bool {
+	return !urlRegex.MatchString(namedConfig) && !models.IsGitSource(namedConfig)
+}
+
+// refreshConfigHash read content of named config and update its hash.
+// Remote configs are requested with ETag / Last-Modified of previous content to avoid downloading unchanged configs
+func (cu *configUsecase) refreshConfigHash(configName coreConfig.ConfigName) (*models.ConfigHash, error) {
+	namedConfig := cu.namedConfigs[configName]
+
+	var content *models.ConfigContent
+	var err error
+	if urlRegex.MatchString(namedConfig) {
+		cu.watchLock.RLock()
+		previous := cu.remoteContents[configName]
+		cu.watchLock.RUnlock()
+
+		content, err = cu.repository.GetContentFromURL(namedConfig, configName, previous)
+	} else if models.IsGitSource(namedConfig) {
+		// Repository is fetched at most once by poll interval
+		content, err = cu.repository.GetContentFromGit(namedConfig)
+	} else {
+		content, err = cu.repository.GetContentFromPath(path.MonitororBaseDir, namedConfig)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return cu.setConfigHash(configName, content), nil
+}
+
+// setConfigHash store hash of content, UpdatedAt is only changed when hash change
+func (cu *configUsecase) setConfigHash(configName coreConfig.ConfigName, content *models.ConfigContent) *models.ConfigHash {
+	cu.watchLock.Lock()
+	defer cu.watchLock.Unlock()
+
+	if content.ETag != "" || content.LastModified != "" {
+		cu.remoteContents[configName] = content
+	} else {
+		delete(cu.remoteContents, configName)
+	}
+
+	contentHash := hash(content.Content)
+	if previous, ok := cu.configHashes[configName]; ok {
+		if previous.Hash == contentHash {
+			return previous
+		}
+		log.Infof("%q named config changed", configName)
+	}
+
+	configHash := &models.ConfigHash{Config: string(configName), Hash: contentHash, UpdatedAt: time.Now()}
+	cu.configHashes[configName] = configHash
+
+	return configHash
+}
+
+// watchedConfigHash return hash in memory, nil when watcher isn't running or when hash isn't computed yet
+func (cu *configUsecase) watchedConfigHash(configName coreConfig.ConfigName) *models.ConfigHash {
+	cu.watchLock.RLock()
+	defer cu.watchLock.RUnlock()
+
+	if cu.watchDone == nil {
+		return nil
+	}
+	return cu.configHashes[configName]
+}
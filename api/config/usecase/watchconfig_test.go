@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config/mocks"
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/repository"
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/path"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_GetConfigHash_File(t *testing.T) {
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetContentFromPath", path.MonitororBaseDir, "./screen1.json").
+		Return(&models.ConfigContent{Content: []byte(`{"columns": 4}`)}, nil)
+
+	usecase := initConfigUsecase(mockRepo)
+	usecase.namedConfigs = map[coreConfig.ConfigName]string{"screen1": "./screen1.json"}
+
+	configHash, configError := usecase.GetConfigHash(&models.ConfigParams{Config: "SCREEN1"})
+	if assert.Nil(t, configError) {
+		assert.Equal(t, "screen1", configHash.Config)
+		assert.Equal(t, hash([]byte(`{"columns": 4}`)), configHash.Hash)
+	}
+
+	// Watcher isn't running, content is read again
+	_, _ = usecase.GetConfigHash(&models.ConfigParams{Config: "screen1"})
+	mockRepo.AssertNumberOfCalls(t, "GetContentFromPath", 2)
+}
+
+func TestUsecase_GetConfigHash_URL(t *testing.T) {
+	url := "https://example.com/config.json"
+	content := &models.ConfigContent{Content: []byte(`{"columns": 4}`), ETag: `"v1"`}
+
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetContentFromURL", url, coreConfig.ConfigName("remote"), (*models.ConfigContent)(nil)).Return(content, nil)
+	mockRepo.On("GetContentFromURL", url, coreConfig.ConfigName("remote"), content).Return(content, nil)
+
+	usecase := initConfigUsecase(mockRepo)
+	usecase.namedConfigs = map[coreConfig.ConfigName]string{"remote": url}
+
+	first, configError := usecase.GetConfigHash(&models.ConfigParams{Config: "remote"})
+	assert.Nil(t, configError)
+
+	// Conditional request with previous content, unchanged hash keep its date
+	second, configError := usecase.GetConfigHash(&models.ConfigParams{Config: "remote"})
+	if assert.Nil(t, configError) {
+		assert.Equal(t, first.Hash, second.Hash)
+		assert.Equal(t, first.UpdatedAt, second.UpdatedAt)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUsecase_GetConfigHash_Error(t *testing.T) {
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetContentFromPath", path.MonitororBaseDir, "./screen1.json").
+		Return(nil, &models.ConfigFileNotFoundError{Err: errors.New("boom"), PathOrURL: "./screen1.json"})
+
+	usecase := initConfigUsecase(mockRepo)
+	usecase.namedConfigs = map[coreConfig.ConfigName]string{"screen1": "./screen1.json"}
+
+	_, configError := usecase.GetConfigHash(&models.ConfigParams{Config: "unknown"})
+	if assert.NotNil(t, configError) {
+		assert.Equal(t, models.ConfigErrorUnknownNamedConfig, configError.ID)
+	}
+
+	_, configError = usecase.GetConfigHash(&models.ConfigParams{Config: "screen1"})
+	if assert.NotNil(t, configError) {
+		assert.Equal(t, models.ConfigErrorConfigNotFound, configError.ID)
+	}
+}
+
+func TestUsecase_StartWatching(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-config-StartWatching-")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	configPath := filepath.Join(dir, "screen1.json")
+	assert.NoError(t, ioutil.WriteFile(configPath, []byte(`{"version": "2.0", "columns": 4, "tiles": [{"type": "EMPTY"}]}`), 0644))
+
+	usecase := initConfigUsecase(repository.NewConfigRepository())
+	usecase.namedConfigs = map[coreConfig.ConfigName]string{"screen1": configPath}
+
+	usecase.StartWatching()
+	defer usecase.StopWatching()
+
+	var previous string
+	assert.True(t, eventually(func() bool {
+		configBag := usecase.GetConfig(&models.ConfigParams{Config: "screen1"})
+		previous = configBag.Hash
+		return previous != ""
+	}))
+	assert.Equal(t, 30000, usecase.GetConfig(&models.ConfigParams{Config: "screen1"}).HashPollInterval)
+
+	// Change is detected without reading file on every call
+	assert.NoError(t, ioutil.WriteFile(configPath, []byte(`{"version": "2.0", "columns": 2, "tiles": [{"type": "EMPTY"}]}`), 0644))
+	assert.True(t, eventually(func() bool {
+		configHash, _ := usecase.GetConfigHash(&models.ConfigParams{Config: "screen1"})
+		return configHash != nil && configHash.Hash != previous
+	}))
+
+	usecase.StopWatching()
+	configBag := usecase.GetConfig(&models.ConfigParams{Config: "screen1"})
+	assert.Empty(t, configBag.Hash)
+	assert.Empty(t, configBag.HashPollInterval)
+}
+
+// eventually run condition until it's true, one call at a time (assert.Eventually can run it concurrently)
+func eventually(condition func() bool) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond * 10) {
+		if condition() {
+			return true
+		}
+	}
+	return false
+}
@@ -10,3 +10,14 @@ type ConfigVersionFormatError struct {
 func (e *ConfigVersionFormatError) Error() string {
 	return fmt.Sprintf(`json: cannot unmarshal %s into Go struct field Config.Version of type string and X.y format`, e.WrongVersion)
 }
+
+// ConfigMigrationError
+type ConfigMigrationError struct {
+	From, To RawVersion
+	Err      error
+}
+
+func (e *ConfigMigrationError) Error() string {
+	return fmt.Sprintf(`Unable to migrate config from version %q to %q, %v`, e.From, e.To, e.Err)
+}
+func (e *ConfigMigrationError) Unwrap() error { return e.Err }
@@ -1,6 +1,7 @@
 package versions
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,3 +11,9 @@ func TestConfigVersionFormatError(t *testing.T) {
 	err := &ConfigVersionFormatError{WrongVersion: "10"}
 	assert.Equal(t, "json: cannot unmarshal 10 into Go struct field Config.Version of type string and X.y format", err.Error())
 }
+
+func TestConfigMigrationError(t *testing.T) {
+	err := &ConfigMigrationError{From: "1.0", To: "2.0", Err: errors.New("boom")}
+	assert.Equal(t, `Unable to migrate config from version "1.0" to "2.0", boom`, err.Error())
+	assert.Equal(t, "boom", err.Unwrap().Error())
+}
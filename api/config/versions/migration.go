@@ -0,0 +1,191 @@
+package versions
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MigrationFunc upgrade config to next version. config is the root mapping node of raw config (JSON or YAML),
+// working on nodes keep keys order and YAML comments. Version field is updated by Migrate
+type MigrationFunc func(config *yaml.Node) error
+
+type migration struct {
+	to      RawVersion
+	migrate MigrationFunc
+}
+
+// migrations by source version
+var migrations = make(map[RawVersion]migration)
+
+// RegisterMigration register step upgrading config from a version to the next one
+// Like:
+//
+//	RegisterMigration(Version2000, Version2100, func(config *yaml.Node) error {
+//		return WalkTiles(config, func(tile *yaml.Node) error {
+//			RenameField(tile, "columnSpan", "colSpan")
+//			return nil
+//		})
+//	})
+func RegisterMigration(from, to RawVersion, migrate MigrationFunc) {
+	if !to.ToConfigVersion().IsGreaterThan(from) {
+		panic(fmt.Sprintf("invalid migration from %q to %q, target version must be greater", from, to))
+	}
+	if _, exists := migrations[from]; exists {
+		panic(fmt.Sprintf("migration from %q is already registered", from))
+	}
+	migrations[from] = migration{to: to, migrate: migrate}
+}
+
+// HasMigrations return true when at least one migration is registered
+func HasMigrations() bool {
+	return len(migrations) > 0
+}
+
+// Migrate apply migrations on config until CurrentVersion, and return applied versions (source version first).
+// Config without valid version, or without migration for its version, is left unchanged (rejected by Verify)
+func Migrate(config *yaml.Node) ([]RawVersion, error) {
+	if config.Kind == yaml.DocumentNode && len(config.Content) > 0 {
+		config = config.Content[0]
+	}
+
+	versionNode := FieldNode(config, "version")
+	if versionNode == nil || versionNode.Kind != yaml.ScalarNode {
+		return nil, nil
+	}
+	version := &ConfigVersion{}
+	if err := version.UnmarshalJSON([]byte(fmt.Sprintf("%q", versionNode.Value))); err != nil {
+		return nil, nil
+	}
+
+	var steps []RawVersion
+	current := version.ToRawVersion()
+	for current.ToConfigVersion().IsLessThan(CurrentVersion) {
+		m, ok := migrations[current]
+		if !ok {
+			break
+		}
+
+		if err := m.migrate(config); err != nil {
+			return nil, &ConfigMigrationError{From: current, To: m.to, Err: err}
+		}
+
+		if len(steps) == 0 {
+			steps = append(steps, current)
+		}
+		steps = append(steps, m.to)
+		current = m.to
+	}
+
+	if len(steps) > 0 {
+		versionNode.Value = string(current)
+		versionNode.Tag = "!!str"
+		versionNode.Style = yaml.DoubleQuotedStyle
+	}
+
+	return steps, nil
+}
+
+// ----------------------------------------------------------------
+// -------------------------- HELPERS -----------------------------
+// Used by migrations to edit config nodes
+
+// FieldNode return value of key in mapping node, nil if missing
+func FieldNode(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// SetField replace value of key in mapping node, key is added at the end when missing
+func SetField(mapping *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content[i+1] = value
+			return
+		}
+	}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// RemoveField remove key of mapping node and return its value, nil if missing
+func RemoveField(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return value
+		}
+	}
+	return nil
+}
+
+// RenameField rename key of mapping node, keeping its position. Return false if key is missing
+func RenameField(mapping *yaml.Node, oldKey, newKey string) bool {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == oldKey {
+			mapping.Content[i].Value = newKey
+			return true
+		}
+	}
+	return false
+}
+
+// WalkTiles call fn on every tile of config: tiles, grouped tiles, tiles of pages, scheduled tiles and templates
+func WalkTiles(config *yaml.Node, fn func(tile *yaml.Node) error) error {
+	var walk func(tiles *yaml.Node) error
+	walk = func(tiles *yaml.Node) error {
+		if tiles == nil || tiles.Kind != yaml.SequenceNode {
+			return nil
+		}
+		for _, tile := range tiles.Content {
+			if tile.Kind != yaml.MappingNode {
+				continue
+			}
+			if err := fn(tile); err != nil {
+				return err
+			}
+			if err := walk(FieldNode(tile, "tiles")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(FieldNode(config, "tiles")); err != nil {
+		return err
+	}
+
+	// Pages and schedule entries contain their own tiles
+	for _, key := range []string{"pages", "schedule"} {
+		entries := FieldNode(config, key)
+		if entries == nil || entries.Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, entry := range entries.Content {
+			if entry.Kind != yaml.MappingNode {
+				continue
+			}
+			if err := walk(FieldNode(entry, "tiles")); err != nil {
+				return err
+			}
+		}
+	}
+
+	if templates := FieldNode(config, "templates"); templates != nil && templates.Kind == yaml.MappingNode {
+		for i := 1; i < len(templates.Content); i += 2 {
+			template := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{templates.Content[i]}}
+			if err := walk(template); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
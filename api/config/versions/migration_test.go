@@ -0,0 +1,164 @@
+package versions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func withMigrations(t *testing.T, registered map[RawVersion]migration) func() {
+	previous := migrations
+	migrations = registered
+	return func() { migrations = previous }
+}
+
+func parseNode(t *testing.T, input string) *yaml.Node {
+	var node yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(input), &node))
+	return &node
+}
+
+func marshalNode(t *testing.T, node *yaml.Node) string {
+	bytes, err := yaml.Marshal(node)
+	assert.NoError(t, err)
+	return string(bytes)
+}
+
+func TestMigrate(t *testing.T) {
+	defer withMigrations(t, make(map[RawVersion]migration))()
+
+	RegisterMigration("1.0", "1.5", func(config *yaml.Node) error {
+		return WalkTiles(config, func(tile *yaml.Node) error {
+			RenameField(tile, "colSpan", "columnSpan")
+			return nil
+		})
+	})
+	RegisterMigration("1.5", Version2000, func(config *yaml.Node) error {
+		SetField(config, "columns", RemoveField(config, "width"))
+		return nil
+	})
+	assert.True(t, HasMigrations())
+
+	node := parseNode(t, `
+# Comment
+version: "1.0"
+width: 4
+tiles:
+  - type: GROUP
+    colSpan: 2
+    tiles:
+      - { type: PING, colSpan: 1 }
+templates:
+  ping: { type: PING, colSpan: 3 }
+`)
+
+	steps, err := Migrate(node)
+	assert.NoError(t, err)
+	assert.Equal(t, []RawVersion{"1.0", "1.5", Version2000}, steps)
+	assert.Equal(t, `# Comment
+version: "2.0"
+tiles:
+    - type: GROUP
+      columnSpan: 2
+      tiles:
+        - {type: PING, columnSpan: 1}
+templates:
+    ping: {type: PING, columnSpan: 3}
+columns: 4
+`, marshalNode(t, node))
+}
+
+func TestMigrate_Unchanged(t *testing.T) {
+	defer withMigrations(t, make(map[RawVersion]migration))()
+	assert.False(t, HasMigrations())
+
+	for _, input := range []string{
+		`{"version": "2.0", "columns": 4}`, // Current version
+		`{"version": "1.0", "columns": 4}`, // Missing migration
+		`{"version": 10, "columns": 4}`,    // Invalid version
+		`{"columns": 4}`,                   // Missing version
+	} {
+		node := parseNode(t, input)
+		steps, err := Migrate(node)
+		assert.NoError(t, err)
+		assert.Nil(t, steps)
+	}
+}
+
+func TestMigrate_Error(t *testing.T) {
+	defer withMigrations(t, make(map[RawVersion]migration))()
+
+	RegisterMigration("1.0", Version2000, func(config *yaml.Node) error { return errors.New("boom") })
+
+	_, err := Migrate(parseNode(t, `{"version": "1.0"}`))
+	if assert.Error(t, err) {
+		assert.IsType(t, &ConfigMigrationError{}, err)
+		assert.Equal(t, `Unable to migrate config from version "1.0" to "2.0", boom`, err.Error())
+	}
+}
+
+func TestRegisterMigration_Panic(t *testing.T) {
+	defer withMigrations(t, make(map[RawVersion]migration))()
+
+	migrate := func(config *yaml.Node) error { return nil }
+	assert.Panics(t, func() { RegisterMigration(Version2000, "1.0", migrate) })
+
+	RegisterMigration("1.0", Version2000, migrate)
+	assert.Panics(t, func() { RegisterMigration("1.0", Version2000, migrate) })
+}
+
+func TestFieldHelpers(t *testing.T) {
+	node := parseNode(t, `{"a": 1, "b": 2}`).Content[0]
+
+	assert.Equal(t, "1", FieldNode(node, "a").Value)
+	assert.Nil(t, FieldNode(node, "missing"))
+	assert.Nil(t, FieldNode(nil, "a"))
+
+	assert.True(t, RenameField(node, "a", "c"))
+	assert.False(t, RenameField(node, "a", "c"))
+	assert.Equal(t, "1", FieldNode(node, "c").Value)
+
+	SetField(node, "b", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "3"})
+	SetField(node, "d", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "4"})
+	assert.Equal(t, "3", FieldNode(node, "b").Value)
+
+	assert.Equal(t, "1", RemoveField(node, "c").Value)
+	assert.Nil(t, RemoveField(node, "c"))
+
+	assert.Equal(t, "{\"b\": 3, d: 4}\n", marshalNode(t, node))
+}
+
+func TestWalkTiles(t *testing.T) {
+	node := parseNode(t, `
+tiles:
+  - type: GROUP
+    tiles:
+      - { type: PING, label: grouped }
+pages:
+  - tiles:
+      - { type: HTTP-STATUS, label: page }
+schedule:
+  - when: { time: "20:00-08:00" }
+    tiles:
+      - { type: PORT, label: scheduled }
+templates:
+  ping: { type: PING, label: template }
+`).Content[0]
+
+	var types []string
+	assert.NoError(t, WalkTiles(node, func(tile *yaml.Node) error {
+		types = append(types, FieldNode(tile, "type").Value)
+		return nil
+	}))
+	assert.Equal(t, []string{"GROUP", "PING", "HTTP-STATUS", "PORT", "PING"}, types)
+
+	err := WalkTiles(node, func(tile *yaml.Node) error {
+		if FieldNode(tile, "type").Value == "PORT" {
+			return errors.New("boom")
+		}
+		return nil
+	})
+	assert.Error(t, err)
+}
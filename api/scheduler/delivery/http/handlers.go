@@ -0,0 +1,50 @@
+package http
+
+import (
+	"net/http"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service/scheduler"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	SchedulerDelivery struct {
+		scheduler *scheduler.Scheduler
+		canRead   ReadAccessFunc
+	}
+
+	// ReadAccessFunc return true when request can read named config (see AuthMiddleware.CanRead)
+	ReadAccessFunc func(ctx echo.Context, configName coreConfig.ConfigName) bool
+)
+
+func NewSchedulerDelivery(scheduler *scheduler.Scheduler, canRead ReadAccessFunc) *SchedulerDelivery {
+	return &SchedulerDelivery{scheduler: scheduler, canRead: canRead}
+}
+
+// GetJobs return state of scheduled jobs of configs readable by user
+func (h *SchedulerDelivery) GetJobs(c echo.Context) error {
+	return c.JSON(http.StatusOK, filterJobs(h.scheduler.GetJobs(), func(configName coreConfig.ConfigName) bool {
+		return h.canRead(c, configName)
+	}))
+}
+
+// filterJobs keep jobs used by at least one readable config, other configs are removed from job
+func filterJobs(jobStates []scheduler.JobState, canRead func(configName coreConfig.ConfigName) bool) []scheduler.JobState {
+	filtered := []scheduler.JobState{}
+	for _, jobState := range jobStates {
+		var configs []coreConfig.ConfigName
+		for _, configName := range jobState.Configs {
+			if canRead(configName) {
+				configs = append(configs, configName)
+			}
+		}
+
+		if len(configs) > 0 {
+			jobState.Configs = configs
+			filtered = append(filtered, jobState)
+		}
+	}
+	return filtered
+}
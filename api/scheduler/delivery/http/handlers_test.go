@@ -0,0 +1,27 @@
+package http
+
+import (
+	"testing"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service/scheduler"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterJobs(t *testing.T) {
+	jobStates := []scheduler.JobState{
+		{URL: "/test/1", Configs: []coreConfig.ConfigName{"public"}},
+		{URL: "/test/2", Configs: []coreConfig.ConfigName{"public", "private"}},
+		{URL: "/test/3", Configs: []coreConfig.ConfigName{"private"}},
+	}
+
+	filtered := filterJobs(jobStates, func(configName coreConfig.ConfigName) bool { return configName == "public" })
+	assert.Equal(t, []scheduler.JobState{
+		{URL: "/test/1", Configs: []coreConfig.ConfigName{"public"}},
+		{URL: "/test/2", Configs: []coreConfig.ConfigName{"public"}},
+	}, filtered)
+
+	assert.Empty(t, filterJobs(jobStates, func(configName coreConfig.ConfigName) bool { return false }))
+	assert.Len(t, filterJobs(jobStates, func(configName coreConfig.ConfigName) bool { return true }), 3)
+}
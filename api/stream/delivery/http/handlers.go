@@ -0,0 +1,83 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	configDelivery "github.com/monitoror/monitoror/api/config/delivery/http"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	ConfigEventName = "config"
+	TileEventName   = "tile"
+
+	// KeepAliveInterval is used to avoid proxies closing idle connections
+	KeepAliveInterval = 30 * time.Second
+
+	MIMETextEventStream = "text/event-stream"
+)
+
+type StreamDelivery struct {
+	streamUsecase stream.Usecase
+}
+
+func NewStreamDelivery(su stream.Usecase) *StreamDelivery {
+	return &StreamDelivery{su}
+}
+
+// GetStream push hydrated config then every tile changes as Server-Sent Events
+func (h *StreamDelivery) GetStream(c echo.Context) error {
+	// Bind / check Params
+	params := &configModels.ConfigParams{}
+	_ = c.Bind(params) // can't throw any error with this Params
+	// Decode params
+	params.Config, _ = url.QueryUnescape(params.Config)
+
+	subscription, configBag := h.streamUsecase.Subscribe(params)
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, MIMETextEventStream)
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.WriteHeader(http.StatusOK)
+
+	writeEvent(response, ConfigEventName, configBag)
+
+	// Config contains errors, nothing will be streamed
+	if subscription == nil {
+		return nil
+	}
+	defer h.streamUsecase.Unsubscribe(subscription)
+
+	keepAlive := time.NewTicker(KeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return nil
+			}
+			writeEvent(response, TileEventName, event)
+		case <-keepAlive.C:
+			_, _ = fmt.Fprint(response, ": keep-alive\n\n")
+			response.Flush()
+		}
+	}
+}
+
+func writeEvent(response *echo.Response, event string, data interface{}) {
+	encoded, _ := configDelivery.JSONMarshal(data) // Ignoring error, assuming there is no function or channel inside this struct
+
+	_, _ = fmt.Fprintf(response, "event: %s\ndata: %s\n\n", event, bytes.TrimSpace(encoded))
+	response.Flush()
+}
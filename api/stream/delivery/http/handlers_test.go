@@ -0,0 +1,77 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream/mocks"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func initEcho() (ctx echo.Context, res *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(echo.GET, "/api/v1/stream", nil)
+	res = httptest.NewRecorder()
+	ctx = e.NewContext(req, res)
+	ctx.QueryParams().Set("config", "default")
+
+	return
+}
+
+func TestStreamDelivery_GetStream_Success(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+
+	tile := coreModels.NewTile("TEST")
+	tile.Status = coreModels.SuccessStatus
+
+	subscription := &models.Subscription{ConfigName: "default", Events: make(chan *models.TileEvent, 1)}
+	subscription.Events <- &models.TileEvent{URL: "/test", Tile: tile}
+	close(subscription.Events)
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Subscribe", Anything).Return(subscription, &configModels.ConfigBag{})
+	mockUsecase.On("Unsubscribe", subscription)
+
+	handler := NewStreamDelivery(mockUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetStream(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, MIMETextEventStream, res.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "event: config\ndata: {}\n\nevent: tile\ndata: {\"url\":\"/test\",\"tile\":{\"type\":\"TEST\",\"status\":\"SUCCESS\"}}\n\n", res.Body.String())
+
+		mockUsecase.AssertNumberOfCalls(t, "Subscribe", 1)
+		mockUsecase.AssertNumberOfCalls(t, "Unsubscribe", 1)
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
+func TestStreamDelivery_GetStream_ConfigError(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+
+	configBag := &configModels.ConfigBag{}
+	configBag.AddErrors(configModels.ConfigError{ID: configModels.ConfigErrorUnknownNamedConfig, Message: "boom"})
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Subscribe", Anything).Return(nil, configBag)
+
+	handler := NewStreamDelivery(mockUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetStream(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "event: config\ndata: {\"errors\":[{\"id\":\"ERROR_UNKNOWN_NAMED_CONFIG\",\"message\":\"boom\",\"data\":{}}]}\n\n", res.Body.String())
+
+		mockUsecase.AssertNumberOfCalls(t, "Subscribe", 1)
+		mockUsecase.AssertNotCalled(t, "Unsubscribe", Anything)
+	}
+}
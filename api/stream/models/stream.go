@@ -0,0 +1,20 @@
+package models
+
+import (
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+type (
+	// Subscription is given to each stream client. Events receive every tile changes of the subscribed config
+	Subscription struct {
+		ConfigName string
+		Events     chan *TileEvent
+	}
+
+	// TileEvent is pushed to stream clients when a tile change
+	TileEvent struct {
+		// URL is the hydrated tile url, used by clients to identify tiles
+		URL  string           `json:"url"`
+		Tile *coreModels.Tile `json:"tile"`
+	}
+)
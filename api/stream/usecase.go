@@ -0,0 +1,21 @@
+//go:generate mockery -name Usecase|TileProvider
+
+package stream
+
+import (
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+type (
+	Usecase interface {
+		Subscribe(params *configModels.ConfigParams) (*models.Subscription, *configModels.ConfigBag)
+		Unsubscribe(subscription *models.Subscription)
+	}
+
+	// TileProvider is used to execute monitorable routes on server side
+	TileProvider interface {
+		GetTile(url string) (*coreModels.Tile, error)
+	}
+)
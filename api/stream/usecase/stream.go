@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/gommon/log"
+)
+
+// Subscribe to tile changes of given config. Subscription is nil when config contains errors
+func (su *streamUsecase) Subscribe(params *configModels.ConfigParams) (*models.Subscription, *configModels.ConfigBag) {
+	configBag := su.loadConfig(params)
+	if len(configBag.Errors) > 0 {
+		return nil, configBag
+	}
+
+	subscription := &models.Subscription{
+		ConfigName: strings.ToLower(params.Config),
+		Events:     make(chan *models.TileEvent, EventsBufferSize),
+	}
+
+	su.feedsLock.Lock()
+	defer su.feedsLock.Unlock()
+
+	f, exists := su.feeds[subscription.ConfigName]
+	if !exists {
+		f = &feed{
+			params:      params,
+			subscribers: make(map[*models.Subscription]bool),
+			tiles:       make(map[string]*coreModels.Tile),
+			done:        make(chan struct{}),
+		}
+		su.feeds[subscription.ConfigName] = f
+
+		go su.run(f)
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	// Send last known tiles to the new subscriber, the next events will only contain changes
+	for url, tile := range f.tiles {
+		send(subscription, &models.TileEvent{URL: url, Tile: tile})
+	}
+	f.subscribers[subscription] = true
+
+	return subscription, configBag
+}
+
+// Unsubscribe remove subscription from its feed. Feed is stopped when nobody is listening anymore
+func (su *streamUsecase) Unsubscribe(subscription *models.Subscription) {
+	su.feedsLock.Lock()
+	defer su.feedsLock.Unlock()
+
+	f, exists := su.feeds[subscription.ConfigName]
+	if !exists {
+		return
+	}
+
+	f.Lock()
+	defer f.Unlock()
+
+	if _, ok := f.subscribers[subscription]; !ok {
+		return
+	}
+
+	delete(f.subscribers, subscription)
+	close(subscription.Events)
+
+	if len(f.subscribers) == 0 {
+		close(f.done)
+		delete(su.feeds, subscription.ConfigName)
+	}
+}
+
+func (su *streamUsecase) run(f *feed) {
+	ticker := time.NewTicker(su.interval)
+	defer ticker.Stop()
+
+	for {
+		su.refresh(f)
+
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh reload config (to follow changes and generated tiles) and push changed tiles to subscribers
+func (su *streamUsecase) refresh(f *feed) {
+	configBag := su.loadConfig(f.params)
+	if len(configBag.Errors) > 0 {
+		log.Warnf("unable to refresh %q stream, config contains errors", f.params.Config)
+		return
+	}
+
+	wg := sync.WaitGroup{}
+	for _, url := range configBag.Config.GetTileURLs() {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+
+			tile, err := su.tileProvider.GetTile(url)
+			if err != nil {
+				log.Debugf("unable to refresh %s tile, %v", url, err)
+				return
+			}
+
+			f.Lock()
+			defer f.Unlock()
+
+			if !hasChanged(f.tiles[url], tile) {
+				return
+			}
+
+			f.tiles[url] = tile
+			for subscription := range f.subscribers {
+				send(subscription, &models.TileEvent{URL: url, Tile: tile})
+			}
+		}(url)
+	}
+	wg.Wait()
+}
+
+func (su *streamUsecase) loadConfig(params *configModels.ConfigParams) *configModels.ConfigBag {
+	configBag := su.configUsecase.GetConfig(params)
+
+	if len(configBag.Errors) == 0 {
+		su.configUsecase.Verify(configBag)
+	}
+	if len(configBag.Errors) == 0 {
+		su.configUsecase.Hydrate(configBag)
+	}
+
+	return configBag
+}
+
+// send event without blocking the feed. Event is dropped if subscriber is too slow
+func send(subscription *models.Subscription, event *models.TileEvent) {
+	select {
+	case subscription.Events <- event:
+	default:
+		log.Debugf("stream subscriber of %q config is too slow, dropping %s event", subscription.ConfigName, event.URL)
+	}
+}
+
+// hasChanged check if status, message, metrics or build of tile changed since previous refresh
+func hasChanged(previous, current *coreModels.Tile) bool {
+	if previous == nil {
+		return true
+	}
+
+	return previous.Status != current.Status ||
+		previous.Message != current.Message ||
+		!reflect.DeepEqual(previous.Metrics, current.Metrics) ||
+		!reflect.DeepEqual(previous.Build, current.Build)
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	configMocks "github.com/monitoror/monitoror/api/config/mocks"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream/mocks"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/store"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func initStreamUsecase(configUsecase *configMocks.Usecase, tileProvider *mocks.TileProvider) *streamUsecase {
+	s := &store.Store{
+		CoreConfig: &coreConfig.CoreConfig{StreamInterval: 10},
+	}
+	return NewStreamUsecase(configUsecase, tileProvider, s).(*streamUsecase)
+}
+
+func initConfigUsecaseMock(configBag *configModels.ConfigBag) *configMocks.Usecase {
+	mockConfigUsecase := new(configMocks.Usecase)
+	mockConfigUsecase.On("GetConfig", Anything).Return(configBag)
+	mockConfigUsecase.On("Verify", Anything)
+	mockConfigUsecase.On("Hydrate", Anything)
+	return mockConfigUsecase
+}
+
+func newTile(status coreModels.TileStatus) *coreModels.Tile {
+	tile := coreModels.NewTile("TEST")
+	tile.Status = status
+	return tile
+}
+
+func waitEvent(t *testing.T, subscription *models.Subscription) *models.TileEvent {
+	select {
+	case event := <-subscription.Events:
+		return event
+	case <-time.After(time.Second):
+		assert.FailNow(t, "timeout while waiting event")
+	}
+	return nil
+}
+
+func TestStreamUsecase_Subscribe_ConfigError(t *testing.T) {
+	configBag := &configModels.ConfigBag{}
+	configBag.AddErrors(configModels.ConfigError{ID: configModels.ConfigErrorUnknownNamedConfig})
+
+	mockConfigUsecase := initConfigUsecaseMock(configBag)
+	usecase := initStreamUsecase(mockConfigUsecase, new(mocks.TileProvider))
+
+	subscription, result := usecase.Subscribe(&configModels.ConfigParams{Config: "unknown"})
+	assert.Nil(t, subscription)
+	assert.Equal(t, configBag, result)
+	assert.Len(t, usecase.feeds, 0)
+	mockConfigUsecase.AssertNotCalled(t, "Verify", Anything)
+}
+
+func TestStreamUsecase_Subscribe(t *testing.T) {
+	configBag := &configModels.ConfigBag{Config: &configModels.Config{
+		Tiles: []configModels.TileConfig{
+			{Type: "TEST", URL: "/test/1"},
+			{Type: "GROUP", Tiles: []configModels.TileConfig{{Type: "TEST", URL: "/test/2"}}},
+		},
+	}}
+
+	mockTileProvider := new(mocks.TileProvider)
+	mockTileProvider.On("GetTile", "/test/1").Return(newTile(coreModels.SuccessStatus), nil).Once()
+	mockTileProvider.On("GetTile", "/test/1").Return(newTile(coreModels.FailedStatus), nil)
+	mockTileProvider.On("GetTile", "/test/2").Return(nil, errors.New("boom"))
+
+	usecase := initStreamUsecase(initConfigUsecaseMock(configBag), mockTileProvider)
+
+	subscription, result := usecase.Subscribe(&configModels.ConfigParams{Config: "Default"})
+	if assert.NotNil(t, subscription) {
+		assert.Equal(t, configBag, result)
+		assert.Equal(t, "default", subscription.ConfigName)
+
+		event := waitEvent(t, subscription)
+		assert.Equal(t, "/test/1", event.URL)
+		assert.Equal(t, coreModels.SuccessStatus, event.Tile.Status)
+
+		event = waitEvent(t, subscription)
+		assert.Equal(t, "/test/1", event.URL)
+		assert.Equal(t, coreModels.FailedStatus, event.Tile.Status)
+
+		// Second subscriber share the same feed and receive last known tiles
+		subscription2, _ := usecase.Subscribe(&configModels.ConfigParams{Config: "default"})
+		assert.Len(t, usecase.feeds, 1)
+		event = waitEvent(t, subscription2)
+		assert.Equal(t, coreModels.FailedStatus, event.Tile.Status)
+
+		usecase.Unsubscribe(subscription)
+		assert.Len(t, usecase.feeds, 1)
+		usecase.Unsubscribe(subscription2)
+		assert.Len(t, usecase.feeds, 0)
+
+		// Already unsubscribed
+		assert.NotPanics(t, func() { usecase.Unsubscribe(subscription) })
+	}
+}
+
+func TestHasChanged(t *testing.T) {
+	tile := newTile(coreModels.SuccessStatus)
+	assert.True(t, hasChanged(nil, tile))
+	assert.False(t, hasChanged(tile, newTile(coreModels.SuccessStatus)))
+	assert.True(t, hasChanged(tile, newTile(coreModels.FailedStatus)))
+
+	tileWithMessage := newTile(coreModels.SuccessStatus)
+	tileWithMessage.Message = "message"
+	assert.True(t, hasChanged(tile, tileWithMessage))
+
+	tileWithMetrics := newTile(coreModels.SuccessStatus).WithMetrics(coreModels.NumberUnit)
+	tileWithMetrics.Metrics.Values = []string{"10"}
+	assert.True(t, hasChanged(tile, tileWithMetrics))
+
+	tileWithBuild := newTile(coreModels.SuccessStatus).WithBuild()
+	assert.True(t, hasChanged(tile, tileWithBuild))
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/store"
+)
+
+// EventsBufferSize is the number of events kept for a subscriber before dropping the next ones
+const EventsBufferSize = 256
+
+type (
+	streamUsecase struct {
+		configUsecase config.Usecase
+		tileProvider  stream.TileProvider
+
+		// interval between two refreshes of a feed
+		interval time.Duration
+
+		feedsLock sync.Mutex
+		feeds     map[string]*feed
+	}
+
+	// feed is shared by every subscriber of the same config. It owns the ticker used to refresh tiles
+	feed struct {
+		sync.Mutex
+
+		params      *configModels.ConfigParams
+		subscribers map[*models.Subscription]bool
+		tiles       map[string]*coreModels.Tile
+
+		done chan struct{}
+	}
+)
+
+func NewStreamUsecase(configUsecase config.Usecase, tileProvider stream.TileProvider, store *store.Store) stream.Usecase {
+	return &streamUsecase{
+		configUsecase: configUsecase,
+		tileProvider:  tileProvider,
+		interval:      time.Millisecond * time.Duration(store.CoreConfig.StreamInterval),
+		feeds:         make(map[string]*feed),
+	}
+}
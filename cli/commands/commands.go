@@ -2,14 +2,23 @@ package commands
 
 import (
 	"github.com/monitoror/monitoror/cli"
+	configCmd "github.com/monitoror/monitoror/cli/commands/config"
 	initCmd "github.com/monitoror/monitoror/cli/commands/init"
+	"github.com/monitoror/monitoror/cli/commands/schema"
+	"github.com/monitoror/monitoror/cli/commands/validate"
 	"github.com/monitoror/monitoror/cli/commands/version"
 )
 
 func AddCommands(cli *cli.MonitororCli) {
 	cli.RootCmd.AddCommand(
+		// CONFIG
+		configCmd.NewConfigCommand(cli),
 		// INIT
 		initCmd.NewInitCommand(cli),
+		// SCHEMA
+		schema.NewSchemaCommand(cli),
+		// VALIDATE
+		validate.NewValidateCommand(cli),
 		// VERSION
 		version.NewVersionCommand(cli),
 	)
@@ -15,6 +15,9 @@ func TestAddCommands(t *testing.T) {
 
 	AddCommands(cli)
 
-	assert.Equal(t, "init", command.Commands()[0].Use)
-	assert.Equal(t, "version", command.Commands()[1].Use)
+	assert.Equal(t, "config", command.Commands()[0].Use)
+	assert.Equal(t, "init", command.Commands()[1].Use)
+	assert.Equal(t, "schema", command.Commands()[2].Use)
+	assert.Equal(t, "validate", command.Commands()[3].Name())
+	assert.Equal(t, "version", command.Commands()[4].Use)
 }
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"github.com/monitoror/monitoror/cli"
+
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCommand(monitororCli *cli.MonitororCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage config files",
+	}
+
+	cmd.AddCommand(
+		// MIGRATE
+		NewMigrateCommand(monitororCli),
+	)
+
+	return cmd
+}
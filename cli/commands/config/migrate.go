@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/monitoror/monitoror/api/config/repository"
+	"github.com/monitoror/monitoror/api/config/versions"
+	"github.com/monitoror/monitoror/cli"
+
+	"github.com/labstack/gommon/color"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+var colorer = color.New()
+
+func NewMigrateCommand(monitororCli *cli.MonitororCli) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate <file>",
+		Short: "Upgrade config file to current version and show changes (JSON comments are not kept)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrate(monitororCli, args[0], dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show changes without writing file")
+
+	return cmd
+}
+
+func runMigrate(monitororCli *cli.MonitororCli, filePath string, dryRun bool) error {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	migrated, steps, err := repository.MigrateConfig(content, repository.FormatFromPath(filePath))
+	if err != nil {
+		return err
+	}
+
+	if len(steps) == 0 {
+		_, _ = fmt.Fprintf(monitororCli.Output, "%s %s is up to date (current version is %s)\n", colorer.Green("✓"), filePath, versions.CurrentVersion)
+		return nil
+	}
+
+	diff, _ := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(content)),
+		B:        splitLines(string(migrated)),
+		FromFile: filePath,
+		ToFile:   filePath + " (migrated)",
+		Context:  3,
+	})
+	for _, line := range splitLines(diff) {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			line = colorer.Green(line)
+		case strings.HasPrefix(line, "-"):
+			line = colorer.Red(line)
+		case strings.HasPrefix(line, "@@"):
+			line = colorer.Blue(line)
+		}
+		_, _ = fmt.Fprint(monitororCli.Output, line)
+	}
+
+	path := make([]string, len(steps))
+	for i, step := range steps {
+		path[i] = string(step)
+	}
+
+	if dryRun {
+		_, _ = fmt.Fprintf(monitororCli.Output, "\n%s %s can be migrated from version %s\n", colorer.Yellow("!"), filePath, strings.Join(path, " -> "))
+		return nil
+	}
+
+	if _, err := repository.NewConfigRepository().SaveConfigToPath("", filePath, migrated); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(monitororCli.Output, "\n%s %s migrated from version %s\n", colorer.Green("✓"), filePath, strings.Join(path, " -> "))
+	return nil
+}
+
+// splitLines split text in lines, every line end with a line break
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/versions"
+	"github.com/monitoror/monitoror/cli"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	// Fake migration, renaming "width" in "columns"
+	versions.RegisterMigration("1.0", versions.CurrentVersion, func(config *yaml.Node) error {
+		if versions.FieldNode(config, "error") != nil {
+			return errors.New("boom")
+		}
+		versions.RenameField(config, "width", "columns")
+		return nil
+	})
+}
+
+func writeConfig(t *testing.T, dir, content string) string {
+	filePath := filepath.Join(dir, "config.yaml")
+	assert.NoError(t, ioutil.WriteFile(filePath, []byte(content), 0644))
+	return filePath
+}
+
+func TestRunMigrate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-migrate-")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	filePath := writeConfig(t, dir, "version: 1.0\nwidth: 4\ntiles: []\n")
+
+	// Dry run
+	output := &bytes.Buffer{}
+	assert.NoError(t, runMigrate(&cli.MonitororCli{Output: output}, filePath, true))
+	assert.Equal(t, `--- `+filePath+`
++++ `+filePath+` (migrated)
+@@ -1,3 +1,3 @@
+-version: 1.0
+-width: 4
++version: "2.0"
++columns: 4
+ tiles: []
+
+! `+filePath+` can be migrated from version 1.0 -> 2.0
+`, output.String())
+
+	content, _ := ioutil.ReadFile(filePath)
+	assert.Equal(t, "version: 1.0\nwidth: 4\ntiles: []\n", string(content))
+
+	// Migrate
+	output = &bytes.Buffer{}
+	assert.NoError(t, runMigrate(&cli.MonitororCli{Output: output}, filePath, false))
+	assert.Contains(t, output.String(), "✓ "+filePath+" migrated from version 1.0 -> 2.0")
+
+	content, _ = ioutil.ReadFile(filePath)
+	assert.Equal(t, "version: \"2.0\"\ncolumns: 4\ntiles: []\n", string(content))
+
+	// Up to date
+	output = &bytes.Buffer{}
+	assert.NoError(t, runMigrate(&cli.MonitororCli{Output: output}, filePath, false))
+	assert.Equal(t, "✓ "+filePath+" is up to date (current version is 2.0)\n", output.String())
+}
+
+func TestRunMigrate_Error(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-migrate-")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	assert.Error(t, runMigrate(&cli.MonitororCli{Output: &bytes.Buffer{}}, filepath.Join(dir, "missing.json"), false))
+
+	filePath := writeConfig(t, dir, "version: 1.0\nerror: true\n")
+	assert.Error(t, runMigrate(&cli.MonitororCli{Output: &bytes.Buffer{}}, filePath, false))
+}
@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"encoding/json"
+
+	"github.com/monitoror/monitoror/api/config"
+	"github.com/monitoror/monitoror/cli"
+	"github.com/monitoror/monitoror/service"
+
+	"github.com/spf13/cobra"
+)
+
+func NewSchemaCommand(monitororCli *cli.MonitororCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Print JSON Schema of config, with params of tile types enabled by current environment",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// UI isn't needed, monitorables are enabled like on server to describe their params
+			monitororCli.Store.CoreConfig.DisableUI = true
+			server := service.Init(monitororCli.Store)
+
+			return runSchema(monitororCli, server.ConfigUsecase)
+		},
+	}
+	return cmd
+}
+
+func runSchema(monitororCli *cli.MonitororCli, configUsecase config.Usecase) error {
+	encoder := json.NewEncoder(monitororCli.Output)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(configUsecase.GetSchema())
+}
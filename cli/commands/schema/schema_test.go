@@ -0,0 +1,22 @@
+package schema
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/mocks"
+	"github.com/monitoror/monitoror/cli"
+	"github.com/monitoror/monitoror/internal/pkg/jsonschema"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunSchema(t *testing.T) {
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetSchema").Return(&jsonschema.Schema{Schema: jsonschema.Draft07, Type: "object"})
+
+	output := &bytes.Buffer{}
+	assert.NoError(t, runSchema(&cli.MonitororCli{Output: output}, mockUsecase))
+	assert.Equal(t, "{\n  \"$schema\": \"http://json-schema.org/draft-07/schema#\",\n  \"type\": \"object\"\n}\n", output.String())
+	mockUsecase.AssertExpectations(t)
+}
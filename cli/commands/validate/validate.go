@@ -0,0 +1,166 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/monitoror/monitoror/api/config"
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/cli"
+	validateUtils "github.com/monitoror/monitoror/internal/pkg/validator/validate"
+	"github.com/monitoror/monitoror/pkg/templates"
+	"github.com/monitoror/monitoror/service"
+
+	"github.com/spf13/cobra"
+)
+
+const validateTemplate = `{{ if not .Errors -}}
+{{ "✓" | green }} {{ .Source }} is valid
+{{- else -}}
+{{ "x" | red }} {{ .Source }} is invalid
+{{- range .Errors }}
+
+  {{ .ID | red }} {{ .Message }}
+  {{- range .Extract }}
+    {{ .Text | grey }}
+    {{- with .Marker }}
+    {{ . | red }}
+    {{- end }}
+  {{- end }}
+  {{- with .Expected }}
+    Expected: {{ . | green }}
+  {{- end }}
+{{- end }}
+{{- end }}
+`
+
+type (
+	validateInfo struct {
+		Source string
+		Errors []errorInfo
+	}
+
+	errorInfo struct {
+		ID       models.ConfigErrorID
+		Message  string
+		Extract  []extractLine
+		Expected string
+	}
+
+	extractLine struct {
+		Text   string
+		Marker string // "^^^" under highlighted part of text
+	}
+)
+
+var (
+	parsedTemplate *template.Template
+	urlRegex       = regexp.MustCompile(validateUtils.HTTPRegex)
+)
+
+func init() {
+	// Print this error when you want to debug template
+	parsedTemplate, _ = templates.New("validate").Parse(validateTemplate)
+}
+
+func NewValidateCommand(monitororCli *cli.MonitororCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <file|url>",
+		Short: "Validate config like it would be loaded by UI, exit with error when config is invalid",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// UI isn't needed, monitorables are enabled like on server to verify and hydrate config
+			monitororCli.Store.CoreConfig.DisableUI = true
+			server := service.Init(monitororCli.Store)
+
+			return runValidate(monitororCli, server.ConfigUsecase, args[0])
+		},
+	}
+	return cmd
+}
+
+func runValidate(monitororCli *cli.MonitororCli, configUsecase config.Usecase, source string) error {
+	var configBag *models.ConfigBag
+	if urlRegex.MatchString(source) {
+		configBag = configUsecase.GetConfig(&models.ConfigParams{Config: source})
+	} else {
+		location, err := filepath.Abs(source)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(location)
+		if err != nil {
+			return err
+		}
+		configBag = configUsecase.GetConfigFromContent(content, location)
+	}
+
+	if len(configBag.Errors) == 0 {
+		configUsecase.Verify(configBag)
+	}
+	if len(configBag.Errors) == 0 {
+		configUsecase.Hydrate(configBag)
+	}
+
+	vi := &validateInfo{Source: source}
+	for _, configError := range configBag.Errors {
+		vi.Errors = append(vi.Errors, errorInfo{
+			ID:       configError.ID,
+			Message:  configError.Message,
+			Extract:  extract(configError.Data),
+			Expected: configError.Data.Expected,
+		})
+	}
+
+	if err := parsedTemplate.Execute(monitororCli.Output, vi); err != nil {
+		return err
+	}
+
+	if len(vi.Errors) > 0 {
+		return fmt.Errorf("%d error(s) found in %s", len(vi.Errors), source)
+	}
+	return nil
+}
+
+// extract return lines of config extract to print, with highlighted part (or field) marked.
+// For multi-line extracts (raw config), only the highlighted line is returned
+func extract(data models.ConfigErrorData) []extractLine {
+	if data.ConfigExtract == "" {
+		return nil
+	}
+
+	highlight := data.ConfigExtractHighlight
+	if highlight == "" && data.FieldName != "" {
+		highlight = fmt.Sprintf("%q", data.FieldName)
+	}
+
+	lines := strings.Split(strings.TrimRight(data.ConfigExtract, "\n"), "\n")
+	for i, line := range lines {
+		index := -1
+		if highlight != "" {
+			index = strings.Index(line, highlight)
+		}
+
+		if len(lines) == 1 {
+			if index == -1 {
+				return []extractLine{{Text: line}}
+			}
+			return []extractLine{{Text: line, Marker: marker(index, highlight)}}
+		}
+
+		if index != -1 {
+			prefix := fmt.Sprintf("%d | ", i+1)
+			return []extractLine{{Text: prefix + line, Marker: marker(len(prefix)+index, highlight)}}
+		}
+	}
+
+	return nil
+}
+
+func marker(index int, highlight string) string {
+	return strings.Repeat(" ", index) + strings.Repeat("^", len([]rune(highlight)))
+}
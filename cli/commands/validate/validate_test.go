@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/mocks"
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/cli"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func TestRunValidate_Valid(t *testing.T) {
+	tmpFile, err := ioutil.TempFile("", "monitoror-validate-*.json")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.Remove(tmpFile.Name())
+	_, _ = tmpFile.WriteString(`{"columns": 4}`)
+	_ = tmpFile.Close()
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetConfigFromContent", []byte(`{"columns": 4}`), tmpFile.Name()).Return(&models.ConfigBag{Config: &models.Config{}})
+	mockUsecase.On("Verify", Anything)
+	mockUsecase.On("Hydrate", Anything)
+
+	output := &bytes.Buffer{}
+	assert.NoError(t, runValidate(&cli.MonitororCli{Output: output}, mockUsecase, tmpFile.Name()))
+	assert.Equal(t, "✓ "+tmpFile.Name()+" is valid\n", output.String())
+	mockUsecase.AssertExpectations(t)
+}
+
+func TestRunValidate_Invalid(t *testing.T) {
+	configBag := &models.ConfigBag{Config: &models.Config{}}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetConfig", &models.ConfigParams{Config: "https://example.com/config.json"}).Return(configBag)
+	mockUsecase.On("Verify", Anything).Run(func(args Arguments) {
+		configBag.AddErrors(
+			models.ConfigError{
+				ID:      models.ConfigErrorMissingRequiredField,
+				Message: `Required "hostname" field is missing.`,
+				Data: models.ConfigErrorData{
+					ConfigExtract:          `{"type":"PING","params":{}}`,
+					ConfigExtractHighlight: `"params"`,
+					Expected:               "hostname",
+				},
+			},
+			models.ConfigError{
+				ID:      models.ConfigErrorUnknownField,
+				Message: `json: unknown field "unknown"`,
+				Data: models.ConfigErrorData{
+					FieldName:     "unknown",
+					ConfigExtract: "{\n  \"columns\": 4,\n  \"unknown\": true\n}",
+				},
+			},
+		)
+	})
+
+	expected := `x https://example.com/config.json is invalid
+
+  ERROR_MISSING_REQUIRED_FIELD Required "hostname" field is missing.
+    {"type":"PING","params":{}}
+                   ^^^^^^^^
+    Expected: hostname
+
+  ERROR_UNKNOWN_FIELD json: unknown field "unknown"
+    3 |   "unknown": true
+          ^^^^^^^^^
+`
+
+	output := &bytes.Buffer{}
+	err := runValidate(&cli.MonitororCli{Output: output}, mockUsecase, "https://example.com/config.json")
+	if assert.Error(t, err) {
+		assert.Equal(t, "2 error(s) found in https://example.com/config.json", err.Error())
+	}
+	assert.Equal(t, expected, output.String())
+	mockUsecase.AssertNotCalled(t, "Hydrate", Anything)
+}
+
+func TestRunValidate_MissingFile(t *testing.T) {
+	assert.Error(t, runValidate(&cli.MonitororCli{Output: &bytes.Buffer{}}, new(mocks.Usecase), "/monitoror-missing-file.json"))
+}
+
+func TestExtract(t *testing.T) {
+	assert.Nil(t, extract(models.ConfigErrorData{}))
+	assert.Equal(t, []extractLine{{Text: `{"type":"PING"}`}}, extract(models.ConfigErrorData{ConfigExtract: `{"type":"PING"}`}))
+	assert.Nil(t, extract(models.ConfigErrorData{ConfigExtract: "{\n}", FieldName: "unknown"}))
+}
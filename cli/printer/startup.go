@@ -71,7 +71,7 @@ Check the documentation to know how to enabled them:
 
 {{ "MONITOROR IS RUNNING AT:" | green }}
 {{- range .DisplayedAddresses }}
-  {{ printf "http://%s:%d" . $.LookupPort | blue }}
+  {{ printf "%s://%s:%d" $.LookupScheme . $.LookupPort | blue }}
 {{- end }}
 
 ─────────────────────────────────────────────────
@@ -82,6 +82,7 @@ type (
 	startupInfo struct {
 		Version       string // From ldflags
 		BuildTags     string // From ldflagsl
+		LookupScheme  string // From .env (http or https)
 		LookupPort    int    // From .env
 		LookupAddress string // From .env
 		DisableUI     bool   // From .env
@@ -118,10 +119,15 @@ func PrintStartupLog(monitororCli *cli.MonitororCli) error {
 		Version:       version.Version,
 		BuildTags:     version.BuildTags,
 		DisableUI:     monitororCli.Store.CoreConfig.DisableUI,
+		LookupScheme:  "http",
 		LookupPort:    monitororCli.Store.CoreConfig.Port,
 		LookupAddress: monitororCli.Store.CoreConfig.Address,
 	}
 
+	if monitororCli.Store.CoreConfig.IsTLSEnabled() {
+		monitororInfo.LookupScheme = "https"
+	}
+
 	// Named config Info
 	for name, config := range monitororCli.Store.CoreConfig.NamedConfigs {
 		monitororInfo.NamedConfigs = append(monitororInfo.NamedConfigs, namedConfigInfo{Name: string(name), Value: config})
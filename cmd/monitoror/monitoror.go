@@ -2,14 +2,15 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"time"
 
 	"github.com/monitoror/monitoror/cli"
 	"github.com/monitoror/monitoror/cli/commands"
 	"github.com/monitoror/monitoror/cli/printer"
 	"github.com/monitoror/monitoror/cli/version"
 	"github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/env"
 	"github.com/monitoror/monitoror/internal/pkg/path"
 	"github.com/monitoror/monitoror/registry"
 	"github.com/monitoror/monitoror/service"
@@ -33,7 +34,18 @@ func newMonitororRootCommand(monitororCli *cli.MonitororCli) {
 			if err := printer.PrintStartupLog(monitororCli); err != nil {
 				return err
 			}
-			return server.Start()
+
+			// Graceful shutdown on SIGINT / SIGTERM, reload on SIGHUP
+			go handleSignals(monitororCli, server)
+
+			err := server.Start()
+
+			// Release persistent cache (database file lock)
+			if closer, ok := monitororCli.Store.CacheStore.(io.Closer); ok {
+				_ = closer.Close()
+			}
+
+			return err
 		},
 		Version:       fmt.Sprintf("%s, build %s", version.Version, version.GitCommit),
 		SilenceUsage:  true,
@@ -51,6 +63,22 @@ func newMonitororRootCommand(monitororCli *cli.MonitororCli) {
 	commands.AddCommands(monitororCli)
 }
 
+// environment before loading .env, used to reload .env
+var environment env.Snapshot
+
+func loadDotEnv() {
+	_ = godotenv.Load(".env")
+	_ = godotenv.Load(path.ToAbsolute(path.MonitororBaseDir, ".env"))
+}
+
+func newStore(coreConfig *config.CoreConfig, cacheStore cache.Store) *store.Store {
+	return &store.Store{
+		CoreConfig: coreConfig,
+		Registry:   registry.NewRegistry(),
+		CacheStore: cacheStore,
+	}
+}
+
 func main() {
 	// Setup logger
 	log.SetPrefix("")
@@ -58,15 +86,21 @@ func main() {
 	log.SetLevel(log.INFO)
 
 	// Load .env
-	_ = godotenv.Load(".env")
-	_ = godotenv.Load(path.ToAbsolute(path.MonitororBaseDir, ".env"))
+	environment = env.NewSnapshot()
+	loadDotEnv()
 
 	// Setup Store
-	store := &store.Store{
-		CoreConfig: config.InitConfig(),
-		Registry:   registry.NewRegistry(),
-		CacheStore: cache.NewGoCacheStore(time.Minute*5, time.Second), // Default value, Always override
+	coreConfig := config.InitConfig()
+	if err := coreConfig.Validate(); err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	cacheStore, err := store.NewCacheStore(coreConfig)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
 	}
+	store := newStore(coreConfig, cacheStore)
 
 	// Init CLI
 	monitororCli := cli.NewMonitororCli(store)
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/monitoror/monitoror/cli"
+	"github.com/monitoror/monitoror/cli/printer"
+	"github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service"
+
+	"github.com/labstack/gommon/log"
+)
+
+// handleSignals shutdown server gracefully on SIGINT / SIGTERM and reload .env and config on SIGHUP
+func handleSignals(monitororCli *cli.MonitororCli, server *service.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+	for sig := range signals {
+		if sig == syscall.SIGHUP {
+			reload(monitororCli, server)
+			continue
+		}
+
+		log.Infof("%s received, shutting down", sig)
+		signal.Stop(signals)
+
+		ctx, cancel := context.WithTimeout(context.Background(), service.ShutdownTimeout)
+		if err := server.Shutdown(ctx); err != nil {
+			log.Warnf("unable to drain every requests, %v", err)
+		}
+		cancel()
+
+		return
+	}
+}
+
+// reload .env and config, then swap server routes. CacheStore is kept
+func reload(monitororCli *cli.MonitororCli, server *service.Server) {
+	log.Info("SIGHUP received, reloading configuration")
+
+	environment.Restore()
+	loadDotEnv()
+
+	coreConfig := config.InitConfig()
+	coreConfig.InheritAuthSecret(monitororCli.Store.CoreConfig)
+	if err := coreConfig.Validate(); err != nil {
+		log.Errorf("unable to reload configuration, keeping previous one. %v", err)
+		return
+	}
+	if isCacheBackendChanged(monitororCli.Store.CoreConfig, coreConfig) {
+		log.Warn("cache backend settings changed, restart monitoror to apply them")
+	}
+
+	store := newStore(coreConfig, monitororCli.Store.CacheStore)
+	if err := server.Reload(store); err != nil {
+		log.Errorf("unable to reload configuration, keeping previous one. %v", err)
+		return
+	}
+	monitororCli.Store = store
+
+	_ = printer.PrintStartupLog(monitororCli)
+}
+
+func isCacheBackendChanged(previous, current *config.CoreConfig) bool {
+	return previous.CacheBackend != current.CacheBackend ||
+		previous.CacheDiskPath != current.CacheDiskPath ||
+		previous.CacheRedisAddress != current.CacheRedisAddress ||
+		previous.CacheRedisPassword != current.CacheRedisPassword
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"strings"
@@ -9,6 +11,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/monitoror/monitoror/internal/pkg/env"
+	pkgConfig "github.com/monitoror/monitoror/internal/pkg/monitorable/config"
+	"github.com/monitoror/monitoror/models"
 )
 
 const (
@@ -17,6 +21,10 @@ const (
 	ConfigPrefix      = "CONFIG"
 
 	DefaultConfigName ConfigName = "default"
+
+	CacheBackendMemory = "memory"
+	CacheBackendDisk   = "disk"
+	CacheBackendRedis  = "redis"
 )
 
 type (
@@ -27,6 +35,15 @@ type (
 		Address   string
 		DisableUI bool
 
+		// --- TLS Configuration ---
+		// TLSCertFile and TLSKeyFile enable HTTPS when both are defined. Files are reloaded on change
+		TLSCertFile string
+		TLSKeyFile  string
+		// TLSClientCAFile enable mTLS, clients need a certificate signed by this CA
+		TLSClientCAFile string
+		// HTTPRedirectPort start a HTTP listener redirecting every requests to HTTPS (disabled when 0)
+		HTTPRedirectPort int
+
 		// --- Cache Configuration ---
 		// UpstreamCacheExpiration is used to respond before executing the request. Avoid overloading services.
 		UpstreamCacheExpiration int
@@ -36,6 +53,48 @@ type (
 		// InitialMaxDelay is used to add delay on first method to avoid bursting x requests in same time on start
 		InitialMaxDelay int // in Millisecond
 
+		// CacheBackend select CacheStore implementation: "memory", "disk" (embedded database) or "redis"
+		// Disk and redis backends keep caches and builds history between restarts
+		CacheBackend string
+		// CacheDiskPath is the database file used by disk backend
+		CacheDiskPath string
+		// CacheRedisAddress and CacheRedisPassword are used by redis backend
+		CacheRedisAddress  string
+		CacheRedisPassword string
+
+		// --- Auth Configuration ---
+		// Auth is enabled as soon as users, tokens or proxy header are defined
+		// AuthUsers contains basic auth users, like: "user1:password1,user2:password2"
+		AuthUsers string
+		// AuthTokens contains named bearer tokens, like: "ci:token1,tv:token2"
+		AuthTokens string
+		// AuthProxyHeader contains header set by a trusted reverse proxy with user name, like: "X-Forwarded-User"
+		AuthProxyHeader string
+		// AuthTrustedProxies restrict AuthProxyHeader to these IPs or CIDRs, like: "10.0.0.1,192.168.0.0/16"
+		// Required with AuthProxyHeader, header is ignored when sent by any other address
+		AuthTrustedProxies string
+		// AuthSecret is used to sign tile URLs. Random when empty (signed URLs change on every restart, kept on reload)
+		AuthSecret string
+		// authSecretGenerated is true when AuthSecret is random, see InheritAuthSecret
+		authSecretGenerated bool `structs:"-"`
+
+		// --- Stream Configuration ---
+		// StreamInterval is the delay between two refreshes of tiles pushed through /api/v1/stream
+		StreamInterval int // in Millisecond
+
+		// --- Config Revisions ---
+		// ConfigRevisionDir contains revisions of named configs saved through the API (relative to monitoror directory)
+		ConfigRevisionDir string
+		// ConfigRevisionLimit is the number of revisions kept by named config
+		ConfigRevisionLimit int
+
+		// --- Config Changes ---
+		// ConfigPollInterval is the delay between two checks of named configs loaded from url or git, files are watched
+		ConfigPollInterval int // in Millisecond
+		// ConfigGitDir contains clones of git repositories used by named configs (relative to monitoror directory)
+		// Like: MO_CONFIG_SCREEN1=git+https://github.com/example/dashboards.git#main:screen1.json
+		ConfigGitDir string
+
 		// NamedConfig can contains ui config (path or url)
 		// Can contains default or named config file
 		// Like:
@@ -45,6 +104,71 @@ type (
 		//
 		// Note: it's the only way to load config file outside of monitoror directory
 		NamedConfigs map[ConfigName]string
+
+		// RemoteConfig contains options used to fetch named configs loaded from url, by named config.
+		// Includes of a named config hosted on its server use the same options, urls loaded with ?config= never use them
+		// Like:
+		//		MO_REMOTECONFIG_TIMEOUT=5000					(default settings, used by named configs without their own settings)
+		//		MO_REMOTECONFIG_SCREEN2_TOKEN=xxx
+		//		MO_REMOTECONFIG_SCREEN2_HEADERS=X-Api-Key:xxx,X-Team:ops
+		//		MO_REMOTECONFIG_SCREEN2_SSLVERIFY=false
+		RemoteConfig map[ConfigName]*RemoteConfig
+
+		// Scheduler contains background scheduler settings by named config
+		// Like:
+		//		MO_SCHEDULER_ENABLED=true				(default settings, used by named configs without their own settings)
+		//		MO_SCHEDULER_SCREEN1_ENABLED=true
+		//		MO_SCHEDULER_SCREEN1_INTERVAL=30000
+		Scheduler map[ConfigName]*Scheduler
+
+		// Notifier contains webhooks called when a tile status change, by variant
+		// Like:
+		//		MO_NOTIFIER_URL=https://hooks.example.com/monitoror
+		//		MO_NOTIFIER_SLACK_URL=https://hooks.slack.com/services/xxx
+		//		MO_NOTIFIER_SLACK_TEMPLATE={"text": "{{ .Label }} is now {{ .Status }}"}
+		Notifier map[models.VariantName]*Notifier
+
+		// Access contains access list by named config, only used when auth is enabled
+		// Like:
+		//		MO_ACCESS_USERS=*						(default settings, every authenticated users)
+		//		MO_ACCESS_WELCOME_PUBLIC=true
+		//		MO_ACCESS_SCREENEXEC_USERS=alice,bob
+		//		MO_ACCESS_SCREEN1_WRITERS=alice		(users allowed to update config through the API)
+		Access map[ConfigName]*Access
+	}
+
+	// Scheduler contains settings used to refresh tiles of a named config in background
+	Scheduler struct {
+		Enabled  bool
+		Interval int // in Millisecond, up to 10% of random jitter is added on every run
+	}
+
+	// Access contains users allowed to read and update a named config
+	Access struct {
+		Public  bool   // no authentication required
+		Users   string // comma separated users / tokens names, "*" for every authenticated users
+		Writers string // same format as Users, nobody can update config when empty
+	}
+
+	// RemoteConfig contains options used to fetch a named config loaded from url
+	RemoteConfig struct {
+		Token     string // sent as bearer token
+		Username  string // basic auth, used when Token is empty
+		Password  string
+		Headers   string // comma separated headers, like: "X-Api-Key:xxx,X-Team:ops"
+		SSLVerify bool
+		Timeout   int // in Millisecond
+		// StaleIfError is the delay during which last fetched config is used when url can't be fetched (disabled when 0)
+		StaleIfError int // in Millisecond
+	}
+
+	// Notifier contains settings of a webhook called when a tile status change
+	Notifier struct {
+		URL       string
+		Template  string // Go template of JSON payload, default payload is used when empty
+		Timeout   int    // in Millisecond
+		SSLVerify bool
+		Debounce  int // in Millisecond, new status need to be stable during this delay before notifying
 	}
 
 	//nolint:golint
@@ -58,6 +182,43 @@ var defaultConfig = &CoreConfig{
 	UpstreamCacheExpiration:   10000,
 	DownstreamCacheExpiration: 120000,
 	InitialMaxDelay:           1700,
+	CacheBackend:              CacheBackendMemory,
+	CacheDiskPath:             "./monitoror.db",
+	CacheRedisAddress:         "localhost:6379",
+	StreamInterval:            10000,
+	ConfigRevisionDir:         "./revisions",
+	ConfigRevisionLimit:       20,
+	ConfigPollInterval:        30000,
+	ConfigGitDir:              "./git",
+}
+
+var DefaultScheduler = &Scheduler{
+	Enabled:  false,
+	Interval: 10000,
+}
+
+var DefaultAccess = &Access{
+	Public:  false,
+	Users:   "*",
+	Writers: "",
+}
+
+var DefaultRemoteConfig = &RemoteConfig{
+	Token:        "",
+	Username:     "",
+	Password:     "",
+	Headers:      "",
+	SSLVerify:    true,
+	Timeout:      5000,
+	StaleIfError: 86400000,
+}
+
+var DefaultNotifier = &Notifier{
+	URL:       "",
+	Template:  "",
+	Timeout:   2000,
+	SSLVerify: true,
+	Debounce:  30000,
 }
 
 // InitConfig from configuration file / env / default value
@@ -80,9 +241,89 @@ func InitConfig() *CoreConfig {
 	// Setup NamedConfig without viper
 	loadNamedConfig(coreConfig)
 
+	// Setup RemoteConfig by named config
+	loadRemoteConfig(coreConfig)
+
+	// Setup Scheduler by named config
+	loadScheduler(coreConfig)
+
+	// Setup Notifier by variant
+	loadNotifier(coreConfig)
+
+	// Setup Access by named config
+	loadAccess(coreConfig)
+
+	// Generate random secret to sign URLs
+	if coreConfig.IsAuthEnabled() && coreConfig.AuthSecret == "" {
+		coreConfig.AuthSecret = randomSecret()
+		coreConfig.authSecretGenerated = true
+	}
+
 	return coreConfig
 }
 
+// GetScheduler return scheduler settings of given named config, or default settings if missing
+func (c *CoreConfig) GetScheduler(configName ConfigName) *Scheduler {
+	if scheduler, ok := c.Scheduler[configName]; ok {
+		return scheduler
+	}
+	if scheduler, ok := c.Scheduler[DefaultConfigName]; ok {
+		return scheduler
+	}
+	return DefaultScheduler
+}
+
+// GetRemoteConfig return fetch options of given named config, or default options if missing
+func (c *CoreConfig) GetRemoteConfig(configName ConfigName) *RemoteConfig {
+	if remoteConfig, ok := c.RemoteConfig[configName]; ok {
+		return remoteConfig
+	}
+	if remoteConfig, ok := c.RemoteConfig[DefaultConfigName]; ok {
+		return remoteConfig
+	}
+	return DefaultRemoteConfig
+}
+
+// GetAccess return access list of given named config, or default access list if missing
+func (c *CoreConfig) GetAccess(configName ConfigName) *Access {
+	if access, ok := c.Access[configName]; ok {
+		return access
+	}
+	if access, ok := c.Access[DefaultConfigName]; ok {
+		return access
+	}
+	return DefaultAccess
+}
+
+// IsTLSEnabled return true when certificate and key are defined
+func (c *CoreConfig) IsTLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// InheritAuthSecret keep secret of previous config when secret isn't set explicitly (reload),
+// tile URLs already signed and displayed by UI stay valid
+func (c *CoreConfig) InheritAuthSecret(previous *CoreConfig) {
+	if c.authSecretGenerated && previous != nil && previous.AuthSecret != "" {
+		c.AuthSecret = previous.AuthSecret
+		c.authSecretGenerated = previous.authSecretGenerated
+	}
+}
+
+// IsAuthEnabled return true when at least one authentication method is defined
+func (c *CoreConfig) IsAuthEnabled() bool {
+	return c.AuthUsers != "" || c.AuthTokens != "" || c.AuthProxyHeader != ""
+}
+
+// Validate check settings which can't be used together
+func (c *CoreConfig) Validate() error {
+	// Any client could send proxy header to authenticate itself
+	if c.AuthProxyHeader != "" && strings.TrimSpace(c.AuthTrustedProxies) == "" {
+		return fmt.Errorf("%s_AUTHPROXYHEADER needs %s_AUTHTRUSTEDPROXIES, header is only trusted from these proxies", EnvPrefix, EnvPrefix)
+	}
+
+	return nil
+}
+
 // loadUiConfig load NamedConfig
 // Note: it's to "hacky" and complicated with viper so i do it manually
 func loadNamedConfig(config *CoreConfig) {
@@ -93,7 +334,8 @@ func loadNamedConfig(config *CoreConfig) {
 	env.InitEnvDefaultLabel(envPrefix, "", string(DefaultConfigName))
 
 	for _, env := range os.Environ() {
-		if strings.HasPrefix(env, envPrefix) {
+		// Avoid core settings starting with same prefix, like MO_CONFIGREVISIONDIR
+		if strings.HasPrefix(env, envPrefix+"=") || strings.HasPrefix(env, envPrefix+"_") {
 			splittedEnv := strings.SplitN(env, "=", 2)
 
 			configName := strings.TrimPrefix(splittedEnv[0], envPrefix)
@@ -105,3 +347,33 @@ func loadNamedConfig(config *CoreConfig) {
 		}
 	}
 }
+
+// loadRemoteConfig load RemoteConfig using named config as variant
+func loadRemoteConfig(config *CoreConfig) {
+	config.RemoteConfig = make(map[ConfigName]*RemoteConfig)
+	pkgConfig.LoadConfigWithVariant(EnvPrefix, models.VariantName(DefaultConfigName), &config.RemoteConfig, DefaultRemoteConfig)
+}
+
+// loadScheduler load Scheduler settings using named config as variant
+func loadScheduler(config *CoreConfig) {
+	config.Scheduler = make(map[ConfigName]*Scheduler)
+	pkgConfig.LoadConfigWithVariant(EnvPrefix, models.VariantName(DefaultConfigName), &config.Scheduler, DefaultScheduler)
+}
+
+// loadNotifier load Notifier for every variant
+func loadNotifier(config *CoreConfig) {
+	config.Notifier = make(map[models.VariantName]*Notifier)
+	pkgConfig.LoadConfigWithVariant(EnvPrefix, models.DefaultVariantName, &config.Notifier, DefaultNotifier)
+}
+
+// loadAccess load Access using named config as variant
+func loadAccess(config *CoreConfig) {
+	config.Access = make(map[ConfigName]*Access)
+	pkgConfig.LoadConfigWithVariant(EnvPrefix, models.VariantName(DefaultConfigName), &config.Access, DefaultAccess)
+}
+
+func randomSecret() string {
+	b := make([]byte, 32)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
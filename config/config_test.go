@@ -4,12 +4,17 @@ import (
 	"os"
 	"testing"
 
+	"github.com/monitoror/monitoror/models"
+
 	"github.com/stretchr/testify/assert"
 )
 
 func TestInitConfig_Default(t *testing.T) {
 	config := InitConfig()
 	assert.Equal(t, 8080, config.Port)
+	assert.Equal(t, CacheBackendMemory, config.CacheBackend)
+	assert.Equal(t, "./revisions", config.ConfigRevisionDir)
+	assert.Equal(t, 20, config.ConfigRevisionLimit)
 }
 
 func TestInitConfig_WithEnv(t *testing.T) {
@@ -17,6 +22,7 @@ func TestInitConfig_WithEnv(t *testing.T) {
 	assert.NoError(t, os.Setenv(EnvPrefix+"_CONFIG", "default"))
 	assert.NoError(t, os.Setenv(EnvPrefix+"_CONFIG_SCREEN1", "1"))
 	assert.NoError(t, os.Setenv(EnvPrefix+"_CONFIG_SCREEN2", "http://example.com?screen=2"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_CONFIGREVISIONDIR", "./history"))
 
 	config := InitConfig()
 
@@ -25,4 +31,130 @@ func TestInitConfig_WithEnv(t *testing.T) {
 	assert.Equal(t, "default", config.NamedConfigs["default"])
 	assert.Equal(t, "1", config.NamedConfigs["screen1"])
 	assert.Equal(t, "http://example.com?screen=2", config.NamedConfigs["screen2"])
+	assert.Equal(t, "./history", config.ConfigRevisionDir)
+	assert.NotContains(t, config.NamedConfigs, ConfigName("revisiondir"))
+}
+
+func TestInitConfig_WithRemoteConfig(t *testing.T) {
+	assert.NoError(t, os.Setenv(EnvPrefix+"_REMOTECONFIG_TOKEN", "token"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_REMOTECONFIG_SCREEN2_SSLVERIFY", "false"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_REMOTECONFIG_SCREEN2_HEADERS", "X-Api-Key:xxx"))
+
+	config := InitConfig()
+
+	assert.Equal(t, "token", config.GetRemoteConfig(DefaultConfigName).Token)
+	assert.Equal(t, true, config.GetRemoteConfig(DefaultConfigName).SSLVerify)
+	assert.Equal(t, "token", config.GetRemoteConfig("screen1").Token)
+	assert.Equal(t, "", config.GetRemoteConfig("screen2").Token)
+	assert.Equal(t, false, config.GetRemoteConfig("screen2").SSLVerify)
+	assert.Equal(t, "X-Api-Key:xxx", config.GetRemoteConfig("screen2").Headers)
+	assert.Equal(t, DefaultRemoteConfig.Timeout, config.GetRemoteConfig("screen2").Timeout)
+
+	config.RemoteConfig = nil
+	assert.Equal(t, DefaultRemoteConfig, config.GetRemoteConfig("screen2"))
+}
+
+func TestInitConfig_WithScheduler(t *testing.T) {
+	assert.NoError(t, os.Setenv(EnvPrefix+"_SCHEDULER_ENABLED", "true"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_SCHEDULER_SCREEN1_INTERVAL", "30000"))
+
+	config := InitConfig()
+
+	assert.Equal(t, true, config.GetScheduler(DefaultConfigName).Enabled)
+	assert.Equal(t, 10000, config.GetScheduler(DefaultConfigName).Interval)
+	assert.Equal(t, false, config.GetScheduler("screen1").Enabled)
+	assert.Equal(t, 30000, config.GetScheduler("screen1").Interval)
+	assert.Equal(t, true, config.GetScheduler("screen2").Enabled)
+
+	config.Scheduler = nil
+	assert.Equal(t, DefaultScheduler, config.GetScheduler("screen2"))
+}
+
+func TestInitConfig_WithNotifier(t *testing.T) {
+	assert.NoError(t, os.Setenv(EnvPrefix+"_NOTIFIER_URL", "https://example.com/hook"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_NOTIFIER_SLACK_URL", "https://hooks.slack.com/services/xxx"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_NOTIFIER_SLACK_DEBOUNCE", "0"))
+
+	config := InitConfig()
+
+	assert.Len(t, config.Notifier, 2)
+	assert.Equal(t, "https://example.com/hook", config.Notifier[models.DefaultVariantName].URL)
+	assert.Equal(t, DefaultNotifier.Debounce, config.Notifier[models.DefaultVariantName].Debounce)
+	assert.Equal(t, "https://hooks.slack.com/services/xxx", config.Notifier["slack"].URL)
+	assert.Equal(t, 0, config.Notifier["slack"].Debounce)
+	assert.Equal(t, DefaultNotifier.Timeout, config.Notifier["slack"].Timeout)
+}
+
+func TestInitConfig_WithAuth(t *testing.T) {
+	config := InitConfig()
+	assert.False(t, config.IsAuthEnabled())
+	assert.Empty(t, config.AuthSecret)
+
+	assert.NoError(t, os.Setenv(EnvPrefix+"_AUTHUSERS", "alice:secret"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_ACCESS_WELCOME_PUBLIC", "true"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_ACCESS_SCREENEXEC_USERS", "alice,bob"))
+	assert.NoError(t, os.Setenv(EnvPrefix+"_ACCESS_SCREENEXEC_WRITERS", "alice"))
+	defer func() {
+		_ = os.Unsetenv(EnvPrefix + "_AUTHUSERS")
+		_ = os.Unsetenv(EnvPrefix + "_ACCESS_WELCOME_PUBLIC")
+		_ = os.Unsetenv(EnvPrefix + "_ACCESS_SCREENEXEC_USERS")
+		_ = os.Unsetenv(EnvPrefix + "_ACCESS_SCREENEXEC_WRITERS")
+	}()
+
+	config = InitConfig()
+	assert.True(t, config.IsAuthEnabled())
+	assert.Len(t, config.AuthSecret, 64)
+	assert.Equal(t, "alice:secret", config.AuthUsers)
+
+	assert.True(t, config.GetAccess("welcome").Public)
+	assert.False(t, config.GetAccess("screenexec").Public)
+	assert.Equal(t, "alice,bob", config.GetAccess("screenexec").Users)
+	assert.Equal(t, "*", config.GetAccess("screen1").Users)
+	assert.Equal(t, "alice", config.GetAccess("screenexec").Writers)
+	assert.Empty(t, config.GetAccess("screen1").Writers)
+
+	config.Access = nil
+	assert.Equal(t, DefaultAccess, config.GetAccess("screen1"))
+}
+
+func TestCoreConfig_InheritAuthSecret(t *testing.T) {
+	assert.NoError(t, os.Setenv(EnvPrefix+"_AUTHUSERS", "alice:secret"))
+	defer func() {
+		_ = os.Unsetenv(EnvPrefix + "_AUTHUSERS")
+		_ = os.Unsetenv(EnvPrefix + "_AUTHSECRET")
+	}()
+
+	// Generated secret is kept on reload
+	previous := InitConfig()
+	config := InitConfig()
+	assert.NotEqual(t, previous.AuthSecret, config.AuthSecret)
+	config.InheritAuthSecret(previous)
+	assert.Equal(t, previous.AuthSecret, config.AuthSecret)
+
+	// Explicit secret is kept, even when removed on reload
+	assert.NoError(t, os.Setenv(EnvPrefix+"_AUTHSECRET", "explicit"))
+	previous = InitConfig()
+	assert.NoError(t, os.Unsetenv(EnvPrefix+"_AUTHSECRET"))
+	config = InitConfig()
+	config.InheritAuthSecret(previous)
+	assert.Equal(t, "explicit", config.AuthSecret)
+
+	// New explicit secret is used
+	assert.NoError(t, os.Setenv(EnvPrefix+"_AUTHSECRET", "changed"))
+	config = InitConfig()
+	config.InheritAuthSecret(previous)
+	assert.Equal(t, "changed", config.AuthSecret)
+}
+
+func TestCoreConfig_IsTLSEnabled(t *testing.T) {
+	assert.False(t, (&CoreConfig{}).IsTLSEnabled())
+	assert.False(t, (&CoreConfig{TLSCertFile: "cert.pem"}).IsTLSEnabled())
+	assert.True(t, (&CoreConfig{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"}).IsTLSEnabled())
+}
+
+func TestCoreConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&CoreConfig{}).Validate())
+	assert.NoError(t, (&CoreConfig{AuthProxyHeader: "X-Forwarded-User", AuthTrustedProxies: "10.0.0.1"}).Validate())
+	assert.Error(t, (&CoreConfig{AuthProxyHeader: "X-Forwarded-User"}).Validate())
+	assert.Error(t, (&CoreConfig{AuthProxyHeader: "X-Forwarded-User", AuthTrustedProxies: " "}).Validate())
 }
@@ -0,0 +1,25 @@
+package env
+
+import (
+	"os"
+	"strings"
+)
+
+// Snapshot keep a copy of process environment.
+// Used to reload .env files from a clean environment (InitEnvDefaultLabel rename variables when loading config)
+type Snapshot []string
+
+func NewSnapshot() Snapshot {
+	return os.Environ()
+}
+
+// Restore replace current environment by snapshot
+func (s Snapshot) Restore() {
+	os.Clearenv()
+	for _, env := range s {
+		splitedEnv := strings.SplitN(env, "=", 2)
+		if len(splitedEnv) == 2 {
+			_ = os.Setenv(splitedEnv[0], splitedEnv[1])
+		}
+	}
+}
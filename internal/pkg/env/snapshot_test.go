@@ -0,0 +1,28 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot_Restore(t *testing.T) {
+	_ = os.Setenv("TEST_SNAPSHOT_KEPT", "value=1")
+	_ = os.Setenv("TEST_SNAPSHOT_REMOVED", "value")
+	defer os.Unsetenv("TEST_SNAPSHOT_KEPT")
+	defer os.Unsetenv("TEST_SNAPSHOT_REMOVED")
+
+	snapshot := NewSnapshot()
+
+	_ = os.Unsetenv("TEST_SNAPSHOT_REMOVED")
+	_ = os.Setenv("TEST_SNAPSHOT_KEPT", "changed")
+	_ = os.Setenv("TEST_SNAPSHOT_ADDED", "value")
+
+	snapshot.Restore()
+
+	assert.Equal(t, "value=1", os.Getenv("TEST_SNAPSHOT_KEPT"))
+	assert.Equal(t, "value", os.Getenv("TEST_SNAPSHOT_REMOVED"))
+	_, exists := os.LookupEnv("TEST_SNAPSHOT_ADDED")
+	assert.False(t, exists)
+}
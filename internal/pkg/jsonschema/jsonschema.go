@@ -0,0 +1,310 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/monitoror/monitoror/api/config/versions"
+	"github.com/monitoror/monitoror/internal/pkg/validator/available"
+	validateUtils "github.com/monitoror/monitoror/internal/pkg/validator/validate"
+	pkgStructs "github.com/monitoror/monitoror/pkg/structs"
+
+	"github.com/fatih/structs"
+)
+
+// -------------------------------------------------------
+// This file generate JSON Schema (draft-07) from structs used in config and monitorable params
+// Like validators, it reads official tags:
+//	- "json" for field names
+//	- "validate" for constraints (required, oneof, gt, ...)
+//	- "available" to only keep fields available in a given config version
+// -------------------------------------------------------
+
+const Draft07 = "http://json-schema.org/draft-07/schema#"
+
+type (
+	// Schema is a JSON Schema (draft-07), only with keywords used by monitoror
+	Schema struct {
+		Schema      string `json:"$schema,omitempty"`
+		Ref         string `json:"$ref,omitempty"`
+		Title       string `json:"title,omitempty"`
+		Description string `json:"description,omitempty"`
+
+		Type                 string             `json:"type,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		AdditionalProperties interface{}        `json:"additionalProperties,omitempty"` // bool or *Schema
+		Items                *Schema            `json:"items,omitempty"`
+
+		Enum             []interface{} `json:"enum,omitempty"`
+		Const            interface{}   `json:"const,omitempty"`
+		Pattern          string        `json:"pattern,omitempty"`
+		Format           string        `json:"format,omitempty"`
+		Minimum          *float64      `json:"minimum,omitempty"`
+		Maximum          *float64      `json:"maximum,omitempty"`
+		ExclusiveMinimum *float64      `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum *float64      `json:"exclusiveMaximum,omitempty"`
+		MinLength        *int          `json:"minLength,omitempty"`
+		MaxLength        *int          `json:"maxLength,omitempty"`
+		MinItems         *int          `json:"minItems,omitempty"`
+		MaxItems         *int          `json:"maxItems,omitempty"`
+
+		Not   *Schema   `json:"not,omitempty"`
+		AllOf []*Schema `json:"allOf,omitempty"`
+		AnyOf []*Schema `json:"anyOf,omitempty"`
+		If    *Schema   `json:"if,omitempty"`
+		Then  *Schema   `json:"then,omitempty"`
+
+		Definitions map[string]*Schema `json:"definitions,omitempty"`
+	}
+
+	// Reflector generate schemas of structs. Nested structs are added in definitions and referenced with "$ref"
+	Reflector struct {
+		// Version is used to remove fields unavailable in this config version
+		Version *versions.ConfigVersion
+		// Types contains schemas used instead of reflection for some types (ex: ConfigVersion)
+		Types map[reflect.Type]*Schema
+		// Alternative, when defined, is also accepted for number, integer and boolean fields (ex: "${variable}")
+		Alternative *Schema
+
+		Definitions map[string]*Schema
+	}
+)
+
+func NewReflector(version *versions.ConfigVersion) *Reflector {
+	return &Reflector{
+		Version:     version,
+		Types:       make(map[reflect.Type]*Schema),
+		Definitions: make(map[string]*Schema),
+	}
+}
+
+// Reflect return schema of value type. Structs are described inline, nested structs are referenced
+func (r *Reflector) Reflect(value interface{}) *Schema {
+	t := reflect.TypeOf(value)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() == reflect.Struct {
+		return r.reflectStruct(t)
+	}
+	return r.reflectType(t)
+}
+
+// Ref return schema referencing definition of struct type
+func Ref(name string) *Schema {
+	return &Schema{Ref: "#/definitions/" + name}
+}
+
+func (r *Reflector) reflectType(t reflect.Type) *Schema {
+	if schema, ok := r.Types[t]; ok {
+		return schema
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return r.reflectType(t.Elem())
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: r.reflectType(t.Elem())}
+	case reflect.Map:
+		schema := &Schema{Type: "object"}
+		if t.Elem().Kind() != reflect.Interface {
+			schema.AdditionalProperties = r.reflectType(t.Elem())
+		}
+		return schema
+	case reflect.Struct:
+		name := t.Name()
+		if _, exists := r.Definitions[name]; !exists {
+			// Added before reflection to handle recursive structs
+			r.Definitions[name] = &Schema{}
+			*r.Definitions[name] = *r.reflectStruct(t)
+		}
+		return Ref(name)
+	default:
+		// interface{}, any value
+		return &Schema{}
+	}
+}
+
+func (r *Reflector) reflectStruct(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: false}
+
+	for _, field := range structs.Fields(reflect.New(t).Interface()) {
+		fieldType, _ := t.FieldByName(field.Name())
+		name := pkgStructs.GetJSONFieldName(field)
+
+		// Fields of embedded structs are inlined, like in encoding/json (even when struct type is unexported)
+		if name == "" && field.IsEmbedded() && fieldType.Type.Kind() == reflect.Struct {
+			embedded := r.reflectStruct(fieldType.Type)
+			for embeddedName, embeddedSchema := range embedded.Properties {
+				schema.Properties[embeddedName] = embeddedSchema
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		if !r.isAvailable(field.Tag("available")) {
+			continue
+		}
+
+		fieldSchema := r.reflectType(fieldType.Type)
+		if applyValidateTag(field.Tag("validate"), fieldType.Type, &fieldSchema) {
+			schema.Required = append(schema.Required, name)
+		}
+
+		if r.Alternative != nil && isScalar(fieldSchema.Type) {
+			fieldSchema = &Schema{AnyOf: []*Schema{fieldSchema, r.Alternative}}
+		}
+
+		schema.Properties[name] = fieldSchema
+	}
+
+	return schema
+}
+
+// isAvailable check "available" tag (ex: "since=2.1,until=3.0") against reflector version
+func (r *Reflector) isAvailable(tag string) bool {
+	if tag == "" || r.Version == nil {
+		return true
+	}
+
+	since, until := available.ParseTag(tag)
+	if since != "" && r.Version.IsLessThan(since) {
+		return false
+	}
+	if until != "" && r.Version.IsGreaterThan(until) {
+		return false
+	}
+
+	return true
+}
+
+// applyValidateTag add constraints of "validate" tag to schema, return true when field is required.
+// Referenced schemas are copied before being changed
+func applyValidateTag(tag string, t reflect.Type, schema **Schema) bool {
+	if tag == "" {
+		return false
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	required := false
+	s := &Schema{}
+	*s = **schema
+
+	for _, rule := range strings.Split(tag, ",") {
+		name, param := rule, ""
+		if index := strings.Index(rule, "="); index != -1 {
+			name, param = rule[:index], rule[index+1:]
+		}
+
+		switch name {
+		case "required":
+			required = true
+		case "oneof":
+			for _, value := range strings.Fields(param) {
+				s.Enum = append(s.Enum, parseValue(value, t))
+			}
+		case "eq":
+			s.Const = parseValue(param, t)
+		case "ne":
+			s.Not = &Schema{Const: parseValue(param, t)}
+		case "gt", "gte", "lt", "lte":
+			applyLimit(s, t, name, param)
+		case "notempty":
+			applyLimit(s, t, "gte", "1")
+		case "url":
+			s.Format = "uri"
+		case "http":
+			s.Pattern = validateUtils.HTTPRegex
+		case "regex":
+			s.Format = "regex"
+		}
+	}
+
+	*schema = s
+	return required
+}
+
+// applyLimit set minimum / maximum of numbers, length of strings or number of items of arrays (like validator)
+func applyLimit(s *Schema, t reflect.Type, name, param string) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("unsupported %s=%s validate tag param", name, param))
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		length := int(value)
+		switch name {
+		case "gt":
+			length++
+			name = "gte"
+		case "lt":
+			length--
+			name = "lte"
+		}
+
+		switch {
+		case t.Kind() == reflect.String && name == "gte":
+			s.MinLength = &length
+		case t.Kind() == reflect.String:
+			s.MaxLength = &length
+		case name == "gte":
+			s.MinItems = &length
+		default:
+			s.MaxItems = &length
+		}
+	default:
+		switch name {
+		case "gt":
+			s.ExclusiveMinimum = &value
+		case "gte":
+			s.Minimum = &value
+		case "lt":
+			s.ExclusiveMaximum = &value
+		case "lte":
+			s.Maximum = &value
+		}
+	}
+}
+
+// parseValue convert tag param in field type
+func parseValue(value string, t reflect.Type) interface{} {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+func isScalar(schemaType string) bool {
+	return schemaType == "integer" || schemaType == "number" || schemaType == "boolean"
+}
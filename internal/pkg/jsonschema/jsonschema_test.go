@@ -0,0 +1,107 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/versions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	embeddedStruct struct {
+		Embedded string `json:"embedded" validate:"required"`
+	}
+
+	nestedStruct struct {
+		Value *int `json:"value,omitempty" validate:"omitempty,gte=0,lt=10"`
+	}
+
+	testStruct struct {
+		embeddedStruct
+
+		Required string            `json:"required" validate:"required,notempty"`
+		OneOf    string            `json:"oneOf,omitempty" validate:"omitempty,oneof=a b"`
+		Count    int               `json:"count,omitempty" validate:"gt=0"`
+		Enabled  *bool             `json:"enabled,omitempty"`
+		URL      string            `json:"url,omitempty" validate:"omitempty,url"`
+		List     []string          `json:"list,omitempty" validate:"omitempty,notempty"`
+		Headers  map[string]string `json:"headers,omitempty"`
+		Nested   *nestedStruct     `json:"nested,omitempty"`
+		Any      interface{}       `json:"any,omitempty"`
+
+		Since string `json:"since,omitempty" available:"since=3.0"`
+		Until string `json:"until,omitempty" available:"until=1.0"`
+
+		Ignored  string `json:"-"`
+		internal string
+	}
+)
+
+func TestReflector_Reflect(t *testing.T) {
+	reflector := NewReflector(versions.RawVersion("2.0").ToConfigVersion())
+	schema := reflector.Reflect(&testStruct{})
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, false, schema.AdditionalProperties)
+	assert.ElementsMatch(t, []string{"embedded", "required"}, schema.Required)
+	assert.Len(t, schema.Properties, 10)
+
+	assert.Equal(t, &Schema{Type: "string"}, schema.Properties["embedded"])
+	assert.Equal(t, 1, *schema.Properties["required"].MinLength)
+	assert.Equal(t, []interface{}{"a", "b"}, schema.Properties["oneOf"].Enum)
+	assert.Equal(t, float64(0), *schema.Properties["count"].ExclusiveMinimum)
+	assert.Equal(t, &Schema{Type: "boolean"}, schema.Properties["enabled"])
+	assert.Equal(t, "uri", schema.Properties["url"].Format)
+	assert.Equal(t, 1, *schema.Properties["list"].MinItems)
+	assert.Equal(t, &Schema{Type: "string"}, schema.Properties["headers"].AdditionalProperties)
+	assert.Equal(t, &Schema{}, schema.Properties["any"])
+	assert.Equal(t, Ref("nestedStruct"), schema.Properties["nested"])
+
+	nested := reflector.Definitions["nestedStruct"]
+	if assert.NotNil(t, nested) {
+		assert.Equal(t, float64(0), *nested.Properties["value"].Minimum)
+		assert.Equal(t, float64(10), *nested.Properties["value"].ExclusiveMaximum)
+	}
+
+	assert.NotContains(t, schema.Properties, "since")
+	assert.NotContains(t, schema.Properties, "until")
+	assert.NotContains(t, schema.Properties, "Ignored")
+	assert.NotContains(t, schema.Properties, "internal")
+}
+
+func TestReflector_Reflect_WithAlternative(t *testing.T) {
+	reflector := NewReflector(nil)
+	reflector.Alternative = Ref("variable")
+	reflector.Types[reflect.TypeOf(nestedStruct{})] = &Schema{Type: "string"}
+
+	schema := reflector.Reflect(testStruct{})
+
+	assert.Equal(t, &Schema{AnyOf: []*Schema{{Type: "boolean"}, Ref("variable")}}, schema.Properties["enabled"])
+	assert.Equal(t, &Schema{Type: "string"}, schema.Properties["nested"])
+	assert.Contains(t, schema.Properties, "since")
+	assert.Contains(t, schema.Properties, "until")
+	assert.Empty(t, reflector.Definitions)
+}
+
+func TestSchema_MarshalJSON(t *testing.T) {
+	minimum := float64(1)
+	schema := &Schema{
+		Schema:     Draft07,
+		Type:       "object",
+		Properties: map[string]*Schema{"count": {Type: "integer", Minimum: &minimum, Const: 0}},
+		Required:   []string{"count"},
+	}
+
+	bytes, err := json.Marshal(schema)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {"count": {"type": "integer", "minimum": 1, "const": 0}},
+			"required": ["count"]
+		}`, string(bytes))
+	}
+}
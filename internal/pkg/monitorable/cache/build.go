@@ -2,38 +2,47 @@ package cache
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/monitoror/monitoror/models"
-	cmap "github.com/orcaman/concurrent-map"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
+const BuildCacheStoreKeyPrefix = "monitoror.build.store"
+
+// BuildCache keep small history of builds in store. History is kept between restarts with a persistent store
 type BuildCache struct {
-	maxSize        int
-	previousBuilds cmap.ConcurrentMap
+	store     cache.Store
+	keyPrefix string
+	maxSize   int
+
+	// lock protect history update
+	lock sync.Mutex
 }
 
+// build is serialized in store, fields need to be exported
 type build struct {
-	id       string
-	status   models.TileStatus
-	duration time.Duration
+	ID       string
+	Status   models.TileStatus
+	Duration time.Duration
 }
 
-func NewBuildCache(size int) *BuildCache {
-	return &BuildCache{maxSize: size, previousBuilds: cmap.New()}
+// NewBuildCache create a BuildCache saving history in store. keyPrefix avoid collision between monitorables / variants
+func NewBuildCache(store cache.Store, keyPrefix string, size int) *BuildCache {
+	return &BuildCache{store: store, keyPrefix: keyPrefix, maxSize: size}
 }
 
 func (c *BuildCache) GetEstimatedDuration(key interface{}) *time.Duration {
-	k := fmt.Sprint(key)
-	value, ok := c.previousBuilds.Get(k)
+	builds, ok := c.get(key)
 	if !ok {
 		return nil
 	}
-	builds := value.([]build)
 
 	var total int64
 	for _, c := range builds {
-		total += int64(c.duration)
+		total += int64(c.Duration)
 	}
 	average := total / int64(len(builds))
 	duration := time.Duration(average)
@@ -42,45 +51,51 @@ func (c *BuildCache) GetEstimatedDuration(key interface{}) *time.Duration {
 
 // Get Previous Status excludes current status in case of multiple call with the same current build
 func (c *BuildCache) GetPreviousStatus(key interface{}, id string) *models.TileStatus {
-	k := fmt.Sprint(key)
-	value, ok := c.previousBuilds.Get(k)
+	builds, ok := c.get(key)
 	if !ok {
 		return nil
 	}
-	builds := value.([]build)
 
 	previous := builds[0]
-	if previous.id == id {
+	if previous.ID == id {
 		if len(builds) == 1 {
 			return nil
 		}
 		previous = builds[1]
 	}
 
-	return &previous.status
+	return &previous.Status
 }
 
 func (c *BuildCache) Add(key interface{}, id string, s models.TileStatus, d time.Duration) {
-	k := fmt.Sprint(key)
-	// If cache is not found, create it
-	var builds []build
-	if tmp, ok := c.previousBuilds.Get(k); !ok {
-		c.previousBuilds.Set(k, builds)
-	} else {
-		builds = tmp.([]build)
-	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	builds, _ := c.get(key)
 
 	// if id already exist, skip
 	for _, value := range builds {
-		if value.id == id {
+		if value.ID == id {
 			return
 		}
 	}
 
 	// Remove old elements
-	if len(builds) == c.maxSize {
-		builds = builds[:len(builds)-1]
+	if len(builds) >= c.maxSize {
+		builds = builds[:c.maxSize-1]
 	}
 
-	c.previousBuilds.Set(k, append([]build{{id, s, d}}, builds...))
+	_ = c.store.Set(c.storeKey(key), append([]build{{id, s, d}}, builds...), cache.NEVER)
+}
+
+func (c *BuildCache) get(key interface{}) ([]build, bool) {
+	var builds []build
+	if err := c.store.Get(c.storeKey(key), &builds); err != nil || len(builds) == 0 {
+		return nil, false
+	}
+	return builds, true
+}
+
+func (c *BuildCache) storeKey(key interface{}) string {
+	return fmt.Sprintf("%s:%s_%s", BuildCacheStoreKeyPrefix, c.keyPrefix, fmt.Sprint(key))
 }
@@ -1,15 +1,25 @@
 package cache
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/pkg/diskcache"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 )
 
+func newStore() cache.Store {
+	return cache.NewGoCacheStore(time.Minute, time.Second)
+}
+
 func Test(t *testing.T) {
-	cache := NewBuildCache(4)
+	cache := NewBuildCache(newStore(), "test", 4)
 
 	cache.Add("key", "0", models.SuccessStatus, time.Second*1)
 	cache.Add("key", "1", models.SuccessStatus, time.Second*1)
@@ -23,14 +33,14 @@ func Test(t *testing.T) {
 }
 
 func Test_Empty(t *testing.T) {
-	cache := NewBuildCache(2)
+	cache := NewBuildCache(newStore(), "test", 2)
 
 	assert.Nil(t, cache.GetPreviousStatus("key", "1"))
 	assert.Nil(t, cache.GetEstimatedDuration("key"))
 }
 
 func Test_AlreadyInCache(t *testing.T) {
-	cache := NewBuildCache(4)
+	cache := NewBuildCache(newStore(), "test", 4)
 
 	cache.Add("key", "1", models.SuccessStatus, time.Second)
 	cache.Add("key", "1", models.SuccessStatus, time.Second)
@@ -40,3 +50,37 @@ func Test_AlreadyInCache(t *testing.T) {
 	cache.Add("key", "2", models.SuccessStatus, time.Second)
 	assert.Equal(t, models.SuccessStatus, *cache.GetPreviousStatus("key", "2"))
 }
+
+func TestBuildCache_KeyPrefix(t *testing.T) {
+	store := newStore()
+	cache1 := NewBuildCache(store, "jenkins.default", 4)
+	cache2 := NewBuildCache(store, "jenkins.other", 4)
+
+	cache1.Add("key", "1", models.SuccessStatus, time.Second)
+	assert.Nil(t, cache2.GetEstimatedDuration("key"))
+	assert.Equal(t, time.Second, *NewBuildCache(store, "jenkins.default", 4).GetEstimatedDuration("key"))
+}
+
+func TestBuildCache_Persistent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := diskcache.NewDiskStore(filepath.Join(dir, "cache.db"), time.Minute, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	cache := NewBuildCache(store, "test", 4)
+	cache.Add("key", "1", models.FailedStatus, time.Second)
+	cache.Add("key", "2", models.SuccessStatus, time.Second*3)
+	assert.NoError(t, store.Close())
+
+	store, err = diskcache.NewDiskStore(filepath.Join(dir, "cache.db"), time.Minute, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+	cache = NewBuildCache(store, "test", 4)
+	assert.Equal(t, models.FailedStatus, *cache.GetPreviousStatus("key", "2"))
+	assert.Equal(t, time.Second*2, *cache.GetEstimatedDuration("key"))
+}
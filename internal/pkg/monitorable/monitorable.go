@@ -7,6 +7,7 @@ import (
 
 	coreConfig "github.com/monitoror/monitoror/config"
 	pkgConfig "github.com/monitoror/monitoror/internal/pkg/monitorable/config"
+	"github.com/monitoror/monitoror/internal/pkg/monitorable/params"
 	"github.com/monitoror/monitoror/internal/pkg/validator/validate"
 	"github.com/monitoror/monitoror/models"
 	coreModels "github.com/monitoror/monitoror/models"
@@ -20,13 +21,17 @@ func LoadConfig(conf interface{}, defaultConf interface{}) {
 func ValidateConfig(conf interface{}, variantName coreModels.VariantName) []error {
 	var result []error
 
-	if errors := validate.Struct(conf); len(errors) > 0 {
-		for _, err := range errors {
-			// Replace fieldName by env variable
-			err.SetFieldName(buildMonitorableEnvKey(conf, variantName, strings.ToUpper(err.GetFieldName())))
+	errors := validate.Struct(conf)
+	// Custom validation of config, like params
+	if validator, ok := conf.(params.Validator); ok {
+		errors = append(errors, validator.Validate()...)
+	}
+
+	for _, err := range errors {
+		// Replace fieldName by env variable
+		err.SetFieldName(buildMonitorableEnvKey(conf, variantName, strings.ToUpper(err.GetFieldName())))
 
-			result = append(result, err)
-		}
+		result = append(result, err)
 	}
 
 	return result
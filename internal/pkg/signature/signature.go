@@ -0,0 +1,48 @@
+package signature
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+)
+
+// QueryParam is the query parameter containing URL signature
+const QueryParam = "signature"
+
+// SignURL add signature of path and query in rawURL. Returned URL has its query sorted by key
+func SignURL(secret, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := u.Query()
+	query.Del(QueryParam)
+	query.Set(QueryParam, sign(secret, u.Path, query))
+
+	return u.Path + "?" + query.Encode(), nil
+}
+
+// VerifyURL check signature contained in rawURL
+func VerifyURL(secret, rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	query := u.Query()
+	signature := query.Get(QueryParam)
+	if signature == "" {
+		return false
+	}
+	query.Del(QueryParam)
+
+	return hmac.Equal([]byte(signature), []byte(sign(secret, u.Path, query)))
+}
+
+func sign(secret, path string, query url.Values) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(path + "?" + query.Encode()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,39 @@
+package signature
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignURL(t *testing.T) {
+	signedURL, err := SignURL("secret", "/api/v1/ping/default/ping?hostname=monitoror.example.com")
+	if assert.NoError(t, err) {
+		assert.Regexp(t, `^/api/v1/ping/default/ping\?hostname=monitoror.example.com&signature=[0-9a-f]{64}$`, signedURL)
+
+		assert.True(t, VerifyURL("secret", signedURL))
+		assert.False(t, VerifyURL("other", signedURL))
+		assert.False(t, VerifyURL("secret", signedURL+"&hostname=other.example.com"))
+		assert.False(t, VerifyURL("secret", "/api/v1/port/default/port?hostname=monitoror.example.com"))
+
+		// Signing twice replace signature
+		resignedURL, err := SignURL("secret", signedURL)
+		if assert.NoError(t, err) {
+			assert.Equal(t, signedURL, resignedURL)
+		}
+	}
+
+	_, err = SignURL("secret", "%zz")
+	assert.Error(t, err)
+}
+
+func TestVerifyURL_Error(t *testing.T) {
+	for _, rawURL := range []string{
+		"%zz",
+		"/api/v1/ping/default/ping?hostname=monitoror.example.com",
+		fmt.Sprintf("/api/v1/ping/default/ping?hostname=monitoror.example.com&%s=abcd", QueryParam),
+	} {
+		assert.False(t, VerifyURL("secret", rawURL))
+	}
+}
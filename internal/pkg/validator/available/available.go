@@ -43,30 +43,38 @@ func Struct(s interface{}, version *versions.ConfigVersion) []pkgValidator.Error
 	for _, field := range structs.Fields(s) {
 		// Lookup for available tag
 		if tagValue := field.Tag(availableTag); tagValue != "" {
-			for _, subTag := range strings.Split(tagValue, subTagSeparator) {
-				// Lookup for since tag
-				if sinceSubTagRegexp.MatchString(subTag) {
-					tagVersion := sinceSubTagRegexp.FindStringSubmatch(subTag)[1]
-					if version.IsLessThan(versions.RawVersion(tagVersion)) {
-						errors = append(errors, &availableError{pkgValidator.ErrorSince, field.Name(), tagVersion})
-					}
-					continue
-				}
-
-				// Lookup for until tag
-				if untilSubTagRegexp.MatchString(subTag) {
-					tagVersion := untilSubTagRegexp.FindStringSubmatch(subTag)[1]
-					if version.IsGreaterThan(versions.RawVersion(tagVersion)) {
-						errors = append(errors, &availableError{pkgValidator.ErrorUntil, field.Name(), tagVersion})
-					}
-					continue
-				}
-
-				// Unknown subtag or unsupported version inside validate
-				panic(fmt.Sprintf("unknown subtag or unsupported version inside validate. %s", subTag))
+			since, until := ParseTag(tagValue)
+			if since != "" && version.IsLessThan(since) {
+				errors = append(errors, &availableError{pkgValidator.ErrorSince, field.Name(), string(since)})
+			}
+			if until != "" && version.IsGreaterThan(until) {
+				errors = append(errors, &availableError{pkgValidator.ErrorUntil, field.Name(), string(until)})
 			}
 		}
 	}
 
 	return errors
 }
+
+// ParseTag return versions of "available" tag (ex: "since=2.1,until=3.0"), empty when subtag is missing.
+// Used by Struct and by JSON Schema generation to agree on available fields
+func ParseTag(tagValue string) (since, until versions.RawVersion) {
+	for _, subTag := range strings.Split(tagValue, subTagSeparator) {
+		// Lookup for since tag
+		if sinceSubTagRegexp.MatchString(subTag) {
+			since = versions.RawVersion(sinceSubTagRegexp.FindStringSubmatch(subTag)[1])
+			continue
+		}
+
+		// Lookup for until tag
+		if untilSubTagRegexp.MatchString(subTag) {
+			until = versions.RawVersion(untilSubTagRegexp.FindStringSubmatch(subTag)[1])
+			continue
+		}
+
+		// Unknown subtag or unsupported version inside validate
+		panic(fmt.Sprintf("unknown subtag or unsupported version inside validate. %s", subTag))
+	}
+
+	return
+}
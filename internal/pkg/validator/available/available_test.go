@@ -44,6 +44,24 @@ func TestStruct(t *testing.T) {
 	}
 }
 
+func TestParseTag(t *testing.T) {
+	for _, testcase := range []struct {
+		tag          string
+		since, until RawVersion
+	}{
+		{tag: "since=1.0", since: "1.0"},
+		{tag: "until=3.2", until: "3.2"},
+		{tag: "since=1.0,until=3.2", since: "1.0", until: "3.2"},
+	} {
+		since, until := ParseTag(testcase.tag)
+		assert.Equal(t, testcase.since, since, testcase.tag)
+		assert.Equal(t, testcase.until, until, testcase.tag)
+	}
+
+	assert.Panics(t, func() { ParseTag("since=2.0,unknown") })
+	assert.Panics(t, func() { ParseTag("until=2017-01-09") })
+}
+
 func TestStruct_Panic(t *testing.T) {
 	assert.Panics(t, func() {
 		Struct(&erroredStruct{}, RawVersion("0.1").ToConfigVersion())
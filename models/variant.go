@@ -3,3 +3,6 @@ package models
 type VariantName string
 
 var DefaultVariantName VariantName = "default"
+
+// VariantNameContextKey is used to find variant name of monitorable route in echo.context
+const VariantNameContextKey = "monitoror.variant.name"
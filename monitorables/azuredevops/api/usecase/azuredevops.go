@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
+	monitorableCache "github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api"
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
 	"github.com/monitoror/monitoror/pkg/git"
 
 	"github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -20,16 +21,16 @@ type (
 		repository api.Repository
 
 		// builds cache. used for save small history of build for stats
-		buildsCache *cache.BuildCache
+		buildsCache *monitorableCache.BuildCache
 	}
 )
 
 const buildCacheSize = 5
 
-func NewAzureDevOpsUsecase(repository api.Repository) api.Usecase {
+func NewAzureDevOpsUsecase(repository api.Repository, store cache.Store, variantName coreModels.VariantName) api.Usecase {
 	return &azureDevOpsUsecase{
 		repository,
-		cache.NewBuildCache(buildCacheSize),
+		monitorableCache.NewBuildCache(store, fmt.Sprintf("azuredevops.%s", variantName), buildCacheSize),
 	}
 }
 
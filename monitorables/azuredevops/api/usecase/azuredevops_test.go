@@ -11,6 +11,7 @@ import (
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
 
 	. "github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,7 +20,7 @@ func TestAzureDevOpsUsecase_Build_ErrorOnGetBuild(t *testing.T) {
 	mockRepository := new(mocks.Repository)
 	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("GetBuildError"))
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Build(&models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")})
 
 	if assert.Error(t, err) {
@@ -34,7 +35,7 @@ func TestAzureDevOpsUsecase_Build_ErrorNoBuildFound(t *testing.T) {
 	mockRepository := new(mocks.Repository)
 	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Build(&models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")})
 
 	if assert.Error(t, err) {
@@ -77,7 +78,7 @@ func TestAzureDevOpsUsecase_Build_Success(t *testing.T) {
 
 	params := &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Build(params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
@@ -123,7 +124,7 @@ func TestAzureDevOpsUsecase_Build_Failed(t *testing.T) {
 
 	params := &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Build(params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
@@ -149,7 +150,7 @@ func TestAzureDevOpsUsecase_Build_Running(t *testing.T) {
 	mockRepository := new(mocks.Repository)
 	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
 
-	au := NewAzureDevOpsUsecase(mockRepository)
+	au := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	aUsecase, ok := au.(*azureDevOpsUsecase)
 	if assert.True(t, ok, "enable to case au into azureDevOpsUsecase") {
 		expected := coreModels.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
@@ -201,7 +202,7 @@ func TestAzureDevOpsUsecase_Build_Queued(t *testing.T) {
 	mockRepository := new(mocks.Repository)
 	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
 
-	au := NewAzureDevOpsUsecase(mockRepository)
+	au := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	expected := coreModels.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
 	expected.Label = "test (definitionName)"
 	expected.Build.ID = ToString("1")
@@ -225,7 +226,7 @@ func TestAzureDevOpsUsecase_Release_ErrorOnGetRelease(t *testing.T) {
 	mockRepository := new(mocks.Repository)
 	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(nil, errors.New("GetReleaseError"))
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Release(&models.ReleaseParams{Project: "test", Definition: ToInt(1)})
 
 	if assert.Error(t, err) {
@@ -240,7 +241,7 @@ func TestAzureDevOpsUsecase_Release_ErrorNoBuildFound(t *testing.T) {
 	mockRepository := new(mocks.Repository)
 	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(nil, nil)
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Release(&models.ReleaseParams{Project: "test", Definition: ToInt(1)})
 
 	if assert.Error(t, err) {
@@ -280,7 +281,7 @@ func TestAzureDevOpsUsecase_Release_Success(t *testing.T) {
 
 	params := &models.ReleaseParams{Project: "test", Definition: ToInt(1)}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Release(params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
@@ -323,7 +324,7 @@ func TestAzureDevOpsUsecase_Release_Failed(t *testing.T) {
 
 	params := &models.ReleaseParams{Project: "test", Definition: ToInt(1)}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
+	usecase := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tile, err := usecase.Release(params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
@@ -347,7 +348,7 @@ func TestAzureDevOpsUsecase_Release_Running(t *testing.T) {
 	mockRepository := new(mocks.Repository)
 	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(release, nil)
 
-	au := NewAzureDevOpsUsecase(mockRepository)
+	au := NewAzureDevOpsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	aUsecase, ok := au.(*azureDevOpsUsecase)
 	if assert.True(t, ok, "enable to case au into azureDevOpsUsecase") {
 		expected := coreModels.NewTile(api.AzureDevOpsReleaseTileType).WithBuild()
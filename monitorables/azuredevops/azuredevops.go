@@ -69,7 +69,7 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	conf := m.config[variantName]
 
 	repository := azuredevopsRepository.NewAzureDevOpsRepository(conf)
-	usecase := azuredevopsUsecase.NewAzureDevOpsUsecase(repository)
+	usecase := azuredevopsUsecase.NewAzureDevOpsUsecase(repository, m.store.CacheStore, variantName)
 	delivery := azuredevopsDelivery.NewAzureDevOpsDelivery(usecase)
 
 	// EnableTile route to echo
@@ -8,7 +8,7 @@ import (
 	"time"
 
 	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
-	"github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
+	monitorableCache "github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/github/api"
 	"github.com/monitoror/monitoror/monitorables/github/api/models"
@@ -16,6 +16,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/hash"
 
 	"github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -23,7 +24,7 @@ type (
 		repository api.Repository
 
 		// builds cache. used for save small history of build for stats
-		buildsCache *cache.BuildCache
+		buildsCache *monitorableCache.BuildCache
 	}
 )
 
@@ -41,10 +42,10 @@ var orderedTileStatus = map[coreModels.TileStatus]int{
 
 const buildCacheSize = 5
 
-func NewGithubUsecase(repository api.Repository) api.Usecase {
+func NewGithubUsecase(repository api.Repository, store cache.Store, variantName coreModels.VariantName) api.Usecase {
 	return &githubUsecase{
 		repository,
-		cache.NewBuildCache(buildCacheSize),
+		monitorableCache.NewBuildCache(store, fmt.Sprintf("github.%s", variantName), buildCacheSize),
 	}
 }
 
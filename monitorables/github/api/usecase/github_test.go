@@ -13,6 +13,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/hash"
 
 	. "github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	. "github.com/stretchr/testify/mock"
 )
@@ -22,7 +23,7 @@ func TestCount_Error(t *testing.T) {
 	mockRepository.On("GetCount", AnythingOfType("string")).
 		Return(0, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := gu.Count(&models.CountParams{Query: "test"})
 	if assert.Error(t, err) {
@@ -39,7 +40,7 @@ func TestCount_Success(t *testing.T) {
 	mockRepository.On("GetCount", AnythingOfType("string")).
 		Return(10, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	expected := coreModels.NewTile(api.GithubCountTileType).WithMetrics(coreModels.NumberUnit)
 	expected.Label = "GitHub count"
@@ -61,7 +62,7 @@ func TestChecks_Error(t *testing.T) {
 	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := gu.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 	if assert.Error(t, err) {
@@ -78,7 +79,7 @@ func TestChecks_NoChecks(t *testing.T) {
 	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Checks{}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := gu.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 	if assert.Error(t, err) {
@@ -109,7 +110,7 @@ func TestChecks_Success(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	expected.Label = "test"
@@ -155,7 +156,7 @@ func TestChecks_Failure(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	expected.Label = "test"
@@ -197,7 +198,7 @@ func TestChecks_Queued(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	expected.Label = "test"
@@ -233,7 +234,7 @@ func TestChecks_Running(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	gUsecase, ok := gu.(*githubUsecase)
 	if assert.True(t, ok) {
 		expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
@@ -274,7 +275,7 @@ func TestPullRequest_ErrorOnPullRequest(t *testing.T) {
 	mockRepository.On("GetPullRequest", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("int")).
 		Return(nil, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := gu.PullRequest(&models.PullRequestParams{Owner: "test", Repository: "test", ID: ToInt(10)})
 	if assert.Error(t, err) {
@@ -293,7 +294,7 @@ func TestPullRequest_ErrorOnChecks(t *testing.T) {
 	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := gu.PullRequest(&models.PullRequestParams{Owner: "test", Repository: "test", ID: ToInt(10)})
 	if assert.Error(t, err) {
@@ -313,7 +314,7 @@ func TestPullRequest_NoChecks(t *testing.T) {
 	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Checks{}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	expected := coreModels.NewTile(api.GithubPullRequestTileType).WithBuild()
 	expected.Label = "test"
@@ -367,7 +368,7 @@ func TestPullRequest_Success(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	expected := coreModels.NewTile(api.GithubPullRequestTileType).WithBuild()
 	expected.Label = "test"
@@ -398,7 +399,7 @@ func TestPullRequestsGenerator_Error(t *testing.T) {
 	mockRepository.On("GetPullRequests", AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	results, err := gu.PullRequestsGenerator(&models.PullRequestGeneratorParams{Owner: "test", Repository: "test"})
 	if assert.Error(t, err) {
@@ -428,7 +429,7 @@ func TestPullRequestsGenerator_Success(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	results, err := gu.PullRequestsGenerator(&models.PullRequestGeneratorParams{Owner: "test", Repository: "test"})
 	if assert.NoError(t, err) {
@@ -79,7 +79,7 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	countCacheExpiration := time.Millisecond * time.Duration(conf.CountCacheExpiration)
 
 	repository := githubRepository.NewGithubRepository(conf)
-	usecase := githubUsecase.NewGithubUsecase(repository)
+	usecase := githubUsecase.NewGithubUsecase(repository, m.store.CacheStore, variantName)
 	delivery := githubDelivery.NewGithubDelivery(usecase)
 
 	// EnableTile route to echo
@@ -42,12 +42,12 @@ const (
 	GitlabMergeRequestStoreKeyPrefix = "monitoror.gitlab.mergeRequest.store"
 )
 
-func NewGitlabUsecase(repository api.Repository, store cache.Store) api.Usecase {
+func NewGitlabUsecase(repository api.Repository, store cache.Store, variantName coreModels.VariantName) api.Usecase {
 	return &gitlabUsecase{
 		repository:    repository,
 		repositoryUID: uuid.NewV4().String(),
 		store:         store,
-		buildsCache:   monitorableCache.NewBuildCache(buildCacheSize),
+		buildsCache:   monitorableCache.NewBuildCache(store, fmt.Sprintf("gitlab.%s", variantName), buildCacheSize),
 	}
 }
 
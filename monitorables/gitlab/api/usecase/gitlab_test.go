@@ -19,7 +19,7 @@ import (
 
 func initUsecase(mockRepository api.Repository) *gitlabUsecase {
 	store := cache.NewGoCacheStore(time.Minute*5, time.Second)
-	gu := NewGitlabUsecase(mockRepository, store)
+	gu := NewGitlabUsecase(mockRepository, store, coreModels.DefaultVariantName)
 	castedGu := gu.(*gitlabUsecase)
 	return castedGu
 }
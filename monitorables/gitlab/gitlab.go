@@ -73,7 +73,7 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	conf := m.config[variantName]
 
 	repository := gitlabRepository.NewGitlabRepository(conf)
-	usecase := gitlabUsecase.NewGitlabUsecase(repository, m.store.CacheStore)
+	usecase := gitlabUsecase.NewGitlabUsecase(repository, m.store.CacheStore, variantName)
 	delivery := gitlabDelivery.NewGitlabDelivery(usecase)
 
 	// EnableTile route to echo
@@ -73,20 +73,48 @@ func TestQueryParams_HTTPFormattedParams(t *testing.T) {
 	ctx.QueryParams().Set("regex", "test")
 	ctx.QueryParams().Set("statusCodeMin", "300")
 	ctx.QueryParams().Set("statusCodeMax", "400")
+	ctx.QueryParams().Set("warnAbove", "10")
+	ctx.QueryParams().Set("failAbove", "20.5")
+	ctx.QueryParams().Add("expectedValues", "12")
+	ctx.QueryParams().Add("expectedValues", "24")
 
 	mockUsecase := new(mocks.Usecase)
 	mockUsecase.On("HTTPFormatted", &models.HTTPFormattedParams{
-		URL:           "http://monitoror.example.com",
-		Regex:         "test",
-		Key:           "key",
-		Format:        models.JSONFormat,
-		StatusCodeMin: pointer.ToInt(300),
-		StatusCodeMax: pointer.ToInt(400),
+		URL:            "http://monitoror.example.com",
+		Regex:          "test",
+		Key:            "key",
+		Format:         models.JSONFormat,
+		StatusCodeMin:  pointer.ToInt(300),
+		StatusCodeMax:  pointer.ToInt(400),
+		WarnAbove:      pointer.ToFloat64(10),
+		FailAbove:      pointer.ToFloat64(20.5),
+		ExpectedValues: []string{"12", "24"},
 	}).Return(nil, nil)
 	handler := NewHTTPDelivery(mockUsecase)
 	assert.NoError(t, handler.GetHTTPFormatted(ctx))
 }
 
+func TestQueryParams_HTTPLatencyParams(t *testing.T) {
+	ctx, _ := initEcho()
+	ctx.QueryParams().Set("url", "http://monitoror.example.com")
+	ctx.QueryParams().Set("method", "POST")
+	ctx.QueryParams().Add("headers", "X-Api-Key: key")
+	ctx.QueryParams().Add("headers", "Accept: application/json")
+	ctx.QueryParams().Set("warningThreshold", "500")
+	ctx.QueryParams().Set("failureThreshold", "1000")
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("HTTPLatency", &models.HTTPLatencyParams{
+		URL:              "http://monitoror.example.com",
+		Method:           "POST",
+		Headers:          []string{"X-Api-Key: key", "Accept: application/json"},
+		WarningThreshold: pointer.ToInt(500),
+		FailureThreshold: pointer.ToInt(1000),
+	}).Return(nil, nil)
+	handler := NewHTTPDelivery(mockUsecase)
+	assert.NoError(t, handler.GetHTTPLatency(ctx))
+}
+
 func Test_httpHttpDelivery_GetHttp_MissingParams(t *testing.T) {
 	// init tests cases
 	testcases := []handlerFunc{
@@ -99,6 +127,9 @@ func Test_httpHttpDelivery_GetHttp_MissingParams(t *testing.T) {
 		func(handler *HTTPDelivery) func(ctx echo.Context) error {
 			return handler.GetHTTPFormatted
 		},
+		func(handler *HTTPDelivery) func(ctx echo.Context) error {
+			return handler.GetHTTPLatency
+		},
 	}
 
 	// tests
@@ -137,6 +168,12 @@ func Test_httpHttpDelivery_GetHttp_Error(t *testing.T) {
 				return handler.GetHTTPFormatted
 			},
 		},
+		{
+			mockFuncName: "HTTPLatency",
+			handlerFunc: func(handler *HTTPDelivery) func(ctx echo.Context) error {
+				return handler.GetHTTPLatency
+			},
+		},
 	}
 
 	// tests
@@ -187,6 +224,13 @@ func Test_httpHttpDelivery_GetHttp(t *testing.T) {
 				return handler.GetHTTPFormatted
 			},
 		},
+		{
+			tileType:     api.HTTPLatencyTileType,
+			mockFuncName: "HTTPLatency",
+			handlerFunc: func(handler *HTTPDelivery) func(ctx echo.Context) error {
+				return handler.GetHTTPLatency
+			},
+		},
 	}
 
 	// tests
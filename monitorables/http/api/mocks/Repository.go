@@ -12,13 +12,13 @@ type Repository struct {
 	mock.Mock
 }
 
-// Get provides a mock function with given fields: url
-func (_m *Repository) Get(url string) (*models.Response, error) {
-	ret := _m.Called(url)
+// Do provides a mock function with given fields: request
+func (_m *Repository) Do(request *models.Request) (*models.Response, error) {
+	ret := _m.Called(request)
 
 	var r0 *models.Response
-	if rf, ok := ret.Get(0).(func(string) *models.Response); ok {
-		r0 = rf(url)
+	if rf, ok := ret.Get(0).(func(*models.Request) *models.Response); ok {
+		r0 = rf(request)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Response)
@@ -26,8 +26,8 @@ func (_m *Repository) Get(url string) (*models.Response, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(url)
+	if rf, ok := ret.Get(1).(func(*models.Request) error); ok {
+		r1 = rf(request)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -12,15 +12,29 @@ type (
 	HTTPFormattedParams struct {
 		URL           string `json:"url" query:"url" validate:"required,url,http"`
 		Format        Format `json:"format" query:"format" validate:"required,oneof=JSON YAML XML"`
-		Key           string `json:"key" query:"key" validate:"required,ne=."`
+		Key           string `json:"key,omitempty" query:"key" validate:"omitempty,ne=."`
+		Query         string `json:"query,omitempty" query:"query"`
 		Regex         string `json:"regex,omitempty" query:"regex" validate:"regex"`
 		StatusCodeMin *int   `json:"statusCodeMin,omitempty" query:"statusCodeMin"`
 		StatusCodeMax *int   `json:"statusCodeMax,omitempty" query:"statusCodeMax"`
+
+		Method  string   `json:"method,omitempty" query:"method" validate:"omitempty,oneof=GET HEAD POST PUT PATCH DELETE OPTIONS"`
+		Headers []string `json:"headers,omitempty" query:"headers"`
+		Body    string   `json:"body,omitempty" query:"body"`
+
+		WarnAbove      *float64 `json:"warnAbove,omitempty" query:"warnAbove"`
+		FailAbove      *float64 `json:"failAbove,omitempty" query:"failAbove"`
+		FailBelow      *float64 `json:"failBelow,omitempty" query:"failBelow"`
+		Equals         string   `json:"equals,omitempty" query:"equals"`
+		NotEquals      string   `json:"notEquals,omitempty" query:"notEquals"`
+		ExpectedValues []string `json:"expectedValues,omitempty" query:"expectedValues"`
 	}
 )
 
 func (p *HTTPFormattedParams) Validate() []validator.Error {
-	return validateStatusCode(p)
+	errors := append(validateStatusCode(p), validateRequest(p)...)
+	errors = append(errors, validateLookup(p)...)
+	return append(errors, validateRules(p)...)
 }
 
 func (p *HTTPFormattedParams) GetURL() (url string) { return p.URL }
@@ -28,8 +42,24 @@ func (p *HTTPFormattedParams) GetStatusCodes() (min int, max int) {
 	return getStatusCodesWithDefault(p.StatusCodeMin, p.StatusCodeMax)
 }
 
+func (p *HTTPFormattedParams) GetMethod() string    { return getMethodWithDefault(p.Method) }
+func (p *HTTPFormattedParams) GetHeaders() []string { return p.Headers }
+func (p *HTTPFormattedParams) GetBody() string      { return p.Body }
+
 func (p *HTTPFormattedParams) GetRegex() string          { return p.Regex }
 func (p *HTTPFormattedParams) GetRegexp() *regexp.Regexp { return getRegexp(p.GetRegex()) }
 
+func (p *HTTPFormattedParams) GetRules() *Rules {
+	return &Rules{
+		WarnAbove:      p.WarnAbove,
+		FailAbove:      p.FailAbove,
+		FailBelow:      p.FailBelow,
+		Equals:         p.Equals,
+		NotEquals:      p.NotEquals,
+		ExpectedValues: p.ExpectedValues,
+	}
+}
+
 func (p *HTTPFormattedParams) GetKey() string    { return p.Key }
+func (p *HTTPFormattedParams) GetQuery() string  { return p.Query }
 func (p *HTTPFormattedParams) GetFormat() Format { return p.Format }
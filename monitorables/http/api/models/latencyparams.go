@@ -0,0 +1,41 @@
+//+build !faker
+
+package models
+
+import (
+	"github.com/monitoror/monitoror/internal/pkg/validator"
+)
+
+type (
+	HTTPLatencyParams struct {
+		URL           string `json:"url" query:"url" validate:"required,url,http"`
+		StatusCodeMin *int   `json:"statusCodeMin,omitempty" query:"statusCodeMin"`
+		StatusCodeMax *int   `json:"statusCodeMax,omitempty" query:"statusCodeMax"`
+
+		Method  string   `json:"method,omitempty" query:"method" validate:"omitempty,oneof=GET HEAD POST PUT PATCH DELETE OPTIONS"`
+		Headers []string `json:"headers,omitempty" query:"headers"`
+		Body    string   `json:"body,omitempty" query:"body"`
+
+		// In Millisecond, compared to total response time
+		WarningThreshold *int `json:"warningThreshold,omitempty" query:"warningThreshold" validate:"omitempty,gt=0"`
+		FailureThreshold *int `json:"failureThreshold,omitempty" query:"failureThreshold" validate:"omitempty,gt=0"`
+	}
+)
+
+func (p *HTTPLatencyParams) Validate() []validator.Error {
+	errors := append(validateStatusCode(p), validateRequest(p)...)
+	return append(errors, validateThresholds(p)...)
+}
+
+func (p *HTTPLatencyParams) GetURL() (url string) { return p.URL }
+func (p *HTTPLatencyParams) GetStatusCodes() (min int, max int) {
+	return getStatusCodesWithDefault(p.StatusCodeMin, p.StatusCodeMax)
+}
+
+func (p *HTTPLatencyParams) GetMethod() string    { return getMethodWithDefault(p.Method) }
+func (p *HTTPLatencyParams) GetHeaders() []string { return p.Headers }
+func (p *HTTPLatencyParams) GetBody() string      { return p.Body }
+
+func (p *HTTPLatencyParams) GetThresholds() (warning *int, failure *int) {
+	return p.WarningThreshold, p.FailureThreshold
+}
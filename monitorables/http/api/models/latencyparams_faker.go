@@ -0,0 +1,51 @@
+//+build faker
+
+package models
+
+import (
+	"github.com/monitoror/monitoror/internal/pkg/validator"
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+type (
+	HTTPLatencyParams struct {
+		URL           string `json:"url" query:"url" validate:"required,url,http"`
+		StatusCodeMin *int   `json:"statusCodeMin,omitempty" query:"statusCodeMin"`
+		StatusCodeMax *int   `json:"statusCodeMax,omitempty" query:"statusCodeMax"`
+
+		Method  string   `json:"method,omitempty" query:"method" validate:"omitempty,oneof=GET HEAD POST PUT PATCH DELETE OPTIONS"`
+		Headers []string `json:"headers,omitempty" query:"headers"`
+		Body    string   `json:"body,omitempty" query:"body"`
+
+		// In Millisecond, compared to total response time
+		WarningThreshold *int `json:"warningThreshold,omitempty" query:"warningThreshold" validate:"omitempty,gt=0"`
+		FailureThreshold *int `json:"failureThreshold,omitempty" query:"failureThreshold" validate:"omitempty,gt=0"`
+
+		Status      coreModels.TileStatus `json:"status" query:"status"`
+		Message     string                `json:"message" query:"message"`
+		ValueValues []string              `json:"valueValues" query:"valueValues"`
+	}
+)
+
+func (p *HTTPLatencyParams) Validate() []validator.Error {
+	errors := append(validateStatusCode(p), validateRequest(p)...)
+	return append(errors, validateThresholds(p)...)
+}
+
+func (p *HTTPLatencyParams) GetURL() (url string) { return p.URL }
+func (p *HTTPLatencyParams) GetStatusCodes() (min int, max int) {
+	return getStatusCodesWithDefault(p.StatusCodeMin, p.StatusCodeMax)
+}
+
+func (p *HTTPLatencyParams) GetMethod() string    { return getMethodWithDefault(p.Method) }
+func (p *HTTPLatencyParams) GetHeaders() []string { return p.Headers }
+func (p *HTTPLatencyParams) GetBody() string      { return p.Body }
+
+func (p *HTTPLatencyParams) GetThresholds() (warning *int, failure *int) {
+	return p.WarningThreshold, p.FailureThreshold
+}
+
+func (p *HTTPLatencyParams) GetStatus() coreModels.TileStatus        { return p.Status }
+func (p *HTTPLatencyParams) GetMessage() string                      { return p.Message }
+func (p *HTTPLatencyParams) GetValueValues() []string                { return p.ValueValues }
+func (p *HTTPLatencyParams) GetValueUnit() coreModels.TileValuesUnit { return coreModels.MillisecondUnit }
@@ -17,12 +17,38 @@ type (
 		GetRegexp() *regexp.Regexp
 	}
 
+	RequestParamsProvider interface {
+		GetMethod() string
+		GetHeaders() []string
+		GetBody() string
+	}
+
+	ThresholdParamsProvider interface {
+		GetThresholds() (warning *int, failure *int)
+	}
+
+	RulesParamsProvider interface {
+		GetRules() *Rules
+	}
+
 	FormattedParamsProvider interface {
 		GetFormat() Format
 		GetKey() string
+		GetQuery() string
 	}
 
 	Format string
+
+	// Rules compute tile status from extracted values. Every value must respect every defined rule
+	Rules struct {
+		WarnAbove *float64
+		FailAbove *float64
+		FailBelow *float64
+
+		Equals         string
+		NotEquals      string
+		ExpectedValues []string
+	}
 )
 
 const (
@@ -44,6 +70,65 @@ func validateStatusCode(params GenericParamsProvider) []validator.Error {
 	return nil
 }
 
+func validateRequest(params RequestParamsProvider) []validator.Error {
+	var errors []validator.Error
+
+	for _, header := range params.GetHeaders() {
+		if _, _, err := ParseHeader(header); err != nil {
+			errors = append(errors, validator.NewDefaultError("Headers", `a list of "Name: value" headers`))
+			break
+		}
+	}
+
+	return errors
+}
+
+// validateLookup check that only one of key (legacy syntax) and query is defined
+func validateLookup(params FormattedParamsProvider) []validator.Error {
+	if params.GetKey() == "" && params.GetQuery() == "" {
+		return []validator.Error{validator.NewDefaultError("Key", `defined when "query" is empty`)}
+	}
+	if params.GetKey() != "" && params.GetQuery() != "" {
+		return []validator.Error{validator.NewDefaultError("Query", `empty when "key" is defined`)}
+	}
+	if params.GetQuery() != "" {
+		if _, err := ParseQuery(params.GetQuery()); err != nil {
+			return []validator.Error{validator.NewDefaultError("Query", `a valid JMESPath expression (or JSONPath expression starting with "$")`)}
+		}
+	}
+
+	return nil
+}
+
+func validateRules(params RulesParamsProvider) []validator.Error {
+	var errors []validator.Error
+
+	rules := params.GetRules()
+	if rules.WarnAbove != nil && rules.FailAbove != nil && *rules.WarnAbove >= *rules.FailAbove {
+		errors = append(errors, validator.NewDefaultError("WarnAbove", "warnAbove < failAbove"))
+	}
+	if rules.FailBelow != nil && rules.FailAbove != nil && *rules.FailBelow >= *rules.FailAbove {
+		errors = append(errors, validator.NewDefaultError("FailBelow", "failBelow < failAbove"))
+	}
+
+	return errors
+}
+
+func validateThresholds(params ThresholdParamsProvider) []validator.Error {
+	if warning, failure := params.GetThresholds(); warning != nil && failure != nil && *warning >= *failure {
+		return []validator.Error{validator.NewDefaultError("WarningThreshold", "warningThreshold < failureThreshold")}
+	}
+
+	return nil
+}
+
+func getMethodWithDefault(method string) string {
+	if method == "" {
+		return DefaultMethod
+	}
+	return method
+}
+
 func getStatusCodesWithDefault(statusCodeMin, statusCodeMax *int) (min int, max int) {
 	min = DefaultMinStatusCode
 	if statusCodeMin != nil {
@@ -40,6 +40,28 @@ func TestHTTPParams(t *testing.T) {
 		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Key: "key", StatusCodeMin: pointer.ToInt(299), StatusCodeMax: pointer.ToInt(300)}, 0},
 		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Key: "key", Regex: "("}, 1},
 		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Key: "key", Regex: "(.*)"}, 0},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Query: "length(items[?status=='down'])"}, 0},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Query: "$.items[*].status"}, 0},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Query: "items[?"}, 1},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Query: "$.items[?"}, 1},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Key: "key", Query: "key"}, 1},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Key: "key", WarnAbove: pointer.ToFloat64(10), FailAbove: pointer.ToFloat64(20), FailBelow: pointer.ToFloat64(0)}, 0},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Key: "key", WarnAbove: pointer.ToFloat64(20), FailAbove: pointer.ToFloat64(20)}, 1},
+		{&HTTPRawParams{URL: "http://example.com", FailBelow: pointer.ToFloat64(30), FailAbove: pointer.ToFloat64(20)}, 1},
+		{&HTTPRawParams{URL: "http://example.com", WarnAbove: pointer.ToFloat64(30), FailBelow: pointer.ToFloat64(40), Equals: "OK", ExpectedValues: []string{"OK"}}, 0},
+
+		{&HTTPLatencyParams{}, 1},
+		{&HTTPLatencyParams{URL: "http://example.com"}, 0},
+		{&HTTPLatencyParams{URL: "http://example.com", StatusCodeMin: pointer.ToInt(300), StatusCodeMax: pointer.ToInt(299)}, 1},
+		{&HTTPLatencyParams{URL: "http://example.com", WarningThreshold: pointer.ToInt(0)}, 1},
+		{&HTTPLatencyParams{URL: "http://example.com", WarningThreshold: pointer.ToInt(500), FailureThreshold: pointer.ToInt(500)}, 1},
+		{&HTTPLatencyParams{URL: "http://example.com", WarningThreshold: pointer.ToInt(500), FailureThreshold: pointer.ToInt(1000)}, 0},
+		{&HTTPLatencyParams{URL: "http://example.com", FailureThreshold: pointer.ToInt(1000)}, 0},
+
+		{&HTTPStatusParams{URL: "http://example.com", Method: "POST", Headers: []string{"X-Api-Key: key"}, Body: "{}"}, 0},
+		{&HTTPStatusParams{URL: "http://example.com", Method: "post"}, 1},
+		{&HTTPRawParams{URL: "http://example.com", Headers: []string{"X-Api-Key"}}, 1},
+		{&HTTPFormattedParams{URL: "http://example.com", Format: "JSON", Key: "key", Headers: []string{": value"}}, 1},
 	} {
 		test.AssertParams(t, testcase.params, testcase.errorCount)
 		if testcase.errorCount == 0 {
@@ -74,13 +96,70 @@ func TestHTTPFormattedParams_FormattedDataProvider(t *testing.T) {
 		params         FormattedParamsProvider
 		expectedFormat Format
 		expectedKey    string
+		expectedQuery  string
 	}{
-		{&HTTPFormattedParams{}, "", ""},
-		{&HTTPFormattedParams{Format: JSONFormat}, JSONFormat, ""},
-		{&HTTPFormattedParams{Format: YAMLFormat, Key: "key"}, YAMLFormat, "key"},
-		{&HTTPFormattedParams{Format: XMLFormat, Key: "key"}, XMLFormat, "key"},
+		{&HTTPFormattedParams{}, "", "", ""},
+		{&HTTPFormattedParams{Format: JSONFormat}, JSONFormat, "", ""},
+		{&HTTPFormattedParams{Format: YAMLFormat, Key: "key"}, YAMLFormat, "key", ""},
+		{&HTTPFormattedParams{Format: XMLFormat, Key: "key"}, XMLFormat, "key", ""},
+		{&HTTPFormattedParams{Format: JSONFormat, Query: "items[0]"}, JSONFormat, "", "items[0]"},
 	} {
 		assert.Equal(t, testcase.expectedFormat, testcase.params.GetFormat())
 		assert.Equal(t, testcase.expectedKey, testcase.params.GetKey())
+		assert.Equal(t, testcase.expectedQuery, testcase.params.GetQuery())
+	}
+}
+
+func TestHTTPParams_GetMethod(t *testing.T) {
+	assert.Equal(t, DefaultMethod, (&HTTPStatusParams{}).GetMethod())
+	assert.Equal(t, "POST", (&HTTPRawParams{Method: "POST"}).GetMethod())
+	assert.Equal(t, "HEAD", (&HTTPFormattedParams{Method: "HEAD"}).GetMethod())
+}
+
+func TestHTTPParams_GetRules(t *testing.T) {
+	assert.Equal(t, &Rules{}, (&HTTPRawParams{}).GetRules())
+	assert.Equal(t, &Rules{FailAbove: pointer.ToFloat64(10), NotEquals: "KO", ExpectedValues: []string{"OK"}},
+		(&HTTPFormattedParams{FailAbove: pointer.ToFloat64(10), NotEquals: "KO", ExpectedValues: []string{"OK"}}).GetRules())
+}
+
+func TestParseHeader(t *testing.T) {
+	name, value, err := ParseHeader("X-Api-Key:  my:key ")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "X-Api-Key", name)
+		assert.Equal(t, "my:key", value)
+	}
+
+	for _, header := range []string{"", "X-Api-Key", ": value", "X Api Key: value"} {
+		_, _, err := ParseHeader(header)
+		assert.Error(t, err, header)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	data := map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "a", "status": "up", "size": float64(2)},
+			map[string]interface{}{"name": "b", "status": "down", "size": float64(3)},
+		},
+	}
+
+	for query, expected := range map[string]interface{}{
+		"length(items[?status=='down'])":  float64(1),
+		"sum(items[*].size)":              float64(5),
+		"items[?status=='down'].name":     []interface{}{"b"},
+		"$.items[*].status":               []interface{}{"up", "down"},
+		`$.items[?(@.status=="up")].name`: []interface{}{"a"},
+	} {
+		q, err := ParseQuery(query)
+		if assert.NoError(t, err, query) {
+			result, err := q(data)
+			assert.NoError(t, err, query)
+			assert.Equal(t, expected, result, query)
+		}
+	}
+
+	for _, query := range []string{"items[?", "$.items[?"} {
+		_, err := ParseQuery(query)
+		assert.Error(t, err, query)
 	}
 }
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"context"
+	"strings"
+
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/jmespath/go-jmespath"
+)
+
+type (
+	// Query evaluate expression on unmarshalled content (JSON, YAML or XML converted in JSON)
+	Query func(data interface{}) (interface{}, error)
+)
+
+// JSONPathPrefix identify JSONPath queries (ex: "$.items[*].status"), others queries are JMESPath (ex: "length(items[?status=='down'])")
+const JSONPathPrefix = "$"
+
+// ParseQuery compile JSONPath or JMESPath query
+func ParseQuery(query string) (Query, error) {
+	if strings.HasPrefix(query, JSONPathPrefix) {
+		evaluable, err := jsonpath.New(query)
+		if err != nil {
+			return nil, err
+		}
+		return func(data interface{}) (interface{}, error) { return evaluable(context.Background(), data) }, nil
+	}
+
+	jmesPath, err := jmespath.Compile(query)
+	if err != nil {
+		return nil, err
+	}
+	return jmesPath.Search, nil
+}
@@ -16,6 +16,17 @@ type (
 		StatusCodeMin *int   `json:"statusCodeMin,omitempty" query:"statusCodeMin"`
 		StatusCodeMax *int   `json:"statusCodeMax,omitempty" query:"statusCodeMax"`
 
+		Method  string   `json:"method,omitempty" query:"method" validate:"omitempty,oneof=GET HEAD POST PUT PATCH DELETE OPTIONS"`
+		Headers []string `json:"headers,omitempty" query:"headers"`
+		Body    string   `json:"body,omitempty" query:"body"`
+
+		WarnAbove      *float64 `json:"warnAbove,omitempty" query:"warnAbove"`
+		FailAbove      *float64 `json:"failAbove,omitempty" query:"failAbove"`
+		FailBelow      *float64 `json:"failBelow,omitempty" query:"failBelow"`
+		Equals         string   `json:"equals,omitempty" query:"equals"`
+		NotEquals      string   `json:"notEquals,omitempty" query:"notEquals"`
+		ExpectedValues []string `json:"expectedValues,omitempty" query:"expectedValues"`
+
 		Status      coreModels.TileStatus     `json:"status" query:"status"`
 		Message     string                    `json:"message" query:"message"`
 		ValueValues []string                  `json:"valueValues" query:"valueValues"`
@@ -24,7 +35,8 @@ type (
 )
 
 func (p *HTTPRawParams) Validate() []validator.Error {
-	return validateStatusCode(p)
+	errors := append(validateStatusCode(p), validateRequest(p)...)
+	return append(errors, validateRules(p)...)
 }
 
 func (p *HTTPRawParams) GetURL() (url string) { return p.URL }
@@ -32,9 +44,24 @@ func (p *HTTPRawParams) GetStatusCodes() (min int, max int) {
 	return getStatusCodesWithDefault(p.StatusCodeMin, p.StatusCodeMax)
 }
 
+func (p *HTTPRawParams) GetMethod() string    { return getMethodWithDefault(p.Method) }
+func (p *HTTPRawParams) GetHeaders() []string { return p.Headers }
+func (p *HTTPRawParams) GetBody() string      { return p.Body }
+
 func (p *HTTPRawParams) GetRegex() string          { return p.Regex }
 func (p *HTTPRawParams) GetRegexp() *regexp.Regexp { return getRegexp(p.GetRegex()) }
 
+func (p *HTTPRawParams) GetRules() *Rules {
+	return &Rules{
+		WarnAbove:      p.WarnAbove,
+		FailAbove:      p.FailAbove,
+		FailBelow:      p.FailBelow,
+		Equals:         p.Equals,
+		NotEquals:      p.NotEquals,
+		ExpectedValues: p.ExpectedValues,
+	}
+}
+
 func (p *HTTPRawParams) GetStatus() coreModels.TileStatus        { return p.Status }
 func (p *HTTPRawParams) GetMessage() string                      { return p.Message }
 func (p *HTTPRawParams) GetValueValues() []string                { return p.ValueValues }
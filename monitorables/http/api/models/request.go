@@ -0,0 +1,37 @@
+package models
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type (
+	// Request sent by repository. Default headers and authentication of variant are added by repository
+	Request struct {
+		Method  string
+		URL     string
+		Headers []string // "Name: value"
+		Body    string
+
+		// NewConnection don't reuse idle connections, to measure DNS, connect and TLS timings
+		NewConnection bool
+	}
+)
+
+const DefaultMethod = http.MethodGet
+
+// ParseHeader split "Name: value" header
+func ParseHeader(header string) (name string, value string, err error) {
+	index := strings.Index(header, ":")
+	if index <= 0 {
+		return "", "", fmt.Errorf(`invalid %q header, expected "Name: value"`, header)
+	}
+
+	name = strings.TrimSpace(header[:index])
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return "", "", fmt.Errorf(`invalid %q header name`, name)
+	}
+
+	return name, strings.TrimSpace(header[index+1:]), nil
+}
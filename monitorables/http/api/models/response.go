@@ -1,8 +1,34 @@
 package models
 
+import (
+	"fmt"
+	"time"
+)
+
 type (
 	Response struct {
 		StatusCode int
 		Body       []byte
+		Timings    Timings
+	}
+
+	// Timings of request, measured with httptrace. DNS, Connect and TLS are empty when connection is reused
+	Timings struct {
+		DNS     time.Duration
+		Connect time.Duration
+		TLS     time.Duration
+		TTFB    time.Duration // Time to first byte, since request start
+		Total   time.Duration // Until body is read
 	}
 )
+
+// Milliseconds return timings in this order: DNS, connect, TLS, TTFB and total
+func (t Timings) Milliseconds() []int64 {
+	return []int64{t.DNS.Milliseconds(), t.Connect.Milliseconds(), t.TLS.Milliseconds(), t.TTFB.Milliseconds(), t.Total.Milliseconds()}
+}
+
+// String return labeled timings, like "DNS 10ms, connect 20ms, TLS 30ms, TTFB 150ms, total 600ms"
+func (t Timings) String() string {
+	values := t.Milliseconds()
+	return fmt.Sprintf("DNS %dms, connect %dms, TLS %dms, TTFB %dms, total %dms", values[0], values[1], values[2], values[3], values[4])
+}
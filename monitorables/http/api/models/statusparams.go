@@ -11,14 +11,22 @@ type (
 		URL           string `json:"url" query:"url" validate:"required,url,http"`
 		StatusCodeMin *int   `json:"statusCodeMin,omitempty" query:"statusCodeMin"`
 		StatusCodeMax *int   `json:"statusCodeMax,omitempty" query:"statusCodeMax"`
+
+		Method  string   `json:"method,omitempty" query:"method" validate:"omitempty,oneof=GET HEAD POST PUT PATCH DELETE OPTIONS"`
+		Headers []string `json:"headers,omitempty" query:"headers"`
+		Body    string   `json:"body,omitempty" query:"body"`
 	}
 )
 
 func (p *HTTPStatusParams) Validate() []validator.Error {
-	return validateStatusCode(p)
+	return append(validateStatusCode(p), validateRequest(p)...)
 }
 
 func (p *HTTPStatusParams) GetURL() (url string) { return p.URL }
 func (p *HTTPStatusParams) GetStatusCodes() (min int, max int) {
 	return getStatusCodesWithDefault(p.StatusCodeMin, p.StatusCodeMax)
 }
+
+func (p *HTTPStatusParams) GetMethod() string    { return getMethodWithDefault(p.Method) }
+func (p *HTTPStatusParams) GetHeaders() []string { return p.Headers }
+func (p *HTTPStatusParams) GetBody() string      { return p.Body }
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"crypto/tls"
+	"errors"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptrace"
+	"strings"
 	"time"
 
 	"github.com/monitoror/monitoror/monitorables/http/api"
@@ -14,6 +18,9 @@ import (
 type (
 	httpRepository struct {
 		httpClient *http.Client
+		// newConnectionClient never reuse connections (see models.Request.NewConnection)
+		newConnectionClient *http.Client
+		config              *config.HTTP
 	}
 )
 
@@ -23,11 +30,33 @@ func NewHTTPRepository(config *config.HTTP) api.Repository {
 	}
 	client := &http.Client{Transport: tr, Timeout: time.Duration(config.Timeout) * time.Millisecond}
 
-	return &httpRepository{client}
+	newConnectionTr := tr.Clone()
+	newConnectionTr.DisableKeepAlives = true
+	newConnectionClient := &http.Client{Transport: newConnectionTr, Timeout: client.Timeout}
+
+	repository := &httpRepository{client, newConnectionClient, config}
+	client.CheckRedirect = repository.checkRedirect
+	newConnectionClient.CheckRedirect = repository.checkRedirect
+
+	return repository
 }
 
-func (r *httpRepository) Get(url string) (response *models.Response, err error) {
-	resp, err := r.httpClient.Get(url)
+func (r *httpRepository) Do(request *models.Request) (response *models.Response, err error) {
+	req, err := r.newRequest(request)
+	if err != nil {
+		return
+	}
+
+	client := r.httpClient
+	if request.NewConnection {
+		client = r.newConnectionClient
+	}
+
+	trace := newTimingsTrace()
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace.clientTrace()))
+
+	trace.start()
+	resp, err := client.Do(req)
 	if err != nil {
 		return
 	}
@@ -41,7 +70,82 @@ func (r *httpRepository) Get(url string) (response *models.Response, err error)
 	response = &models.Response{
 		StatusCode: resp.StatusCode,
 		Body:       bytes,
+		Timings:    trace.done(),
 	}
 
 	return
 }
+
+// newRequest build http request with default headers and authentication of variant (only for AllowedHosts).
+// Headers of params override them
+func (r *httpRepository) newRequest(request *models.Request) (*http.Request, error) {
+	method := request.Method
+	if method == "" {
+		method = models.DefaultMethod
+	}
+
+	var body io.Reader
+	if request.Body != "" {
+		body = strings.NewReader(request.Body)
+	}
+
+	req, err := http.NewRequest(method, request.URL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.config != nil && r.config.IsAllowedHost(req.URL) {
+		setHeaders(req, r.config.Headers)
+
+		if r.config.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+r.config.Token)
+		} else if r.config.Login != "" {
+			req.SetBasicAuth(r.config.Login, r.config.Password)
+		}
+	}
+
+	setHeaders(req, request.Headers)
+
+	return req, nil
+}
+
+// checkRedirect remove headers and authentication of variant when request is redirected outside of AllowedHosts
+// (same limit as default policy of http.Client)
+func (r *httpRepository) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+
+	if r.config.IsAllowedHost(req.URL) {
+		return nil
+	}
+
+	for _, header := range r.config.Headers {
+		if name, _, err := models.ParseHeader(header); err == nil {
+			req.Header.Del(name)
+		}
+	}
+	if r.config.Token != "" || r.config.Login != "" {
+		req.Header.Del("Authorization")
+	}
+
+	return nil
+}
+
+// setHeaders add "Name: value" headers to request, invalid headers are ignored (already validated)
+func setHeaders(req *http.Request, headers []string) {
+	for _, header := range headers {
+		name, value, err := models.ParseHeader(header)
+		if err != nil {
+			continue
+		}
+
+		// Host header is ignored by http.Client, it's read from request field
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+			continue
+		}
+
+		req.Header.Set(name, value)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"strings"
 	"testing"
 	"testing/iotest"
+	"time"
 
+	"github.com/monitoror/monitoror/monitorables/http/api/models"
 	"github.com/monitoror/monitoror/monitorables/http/config"
 	"github.com/monitoror/monitoror/pkg/test"
 
@@ -18,15 +20,15 @@ import (
 // /!\ this is an integration test /!\
 // Note : It may be necessary to separate them from unit tests
 
-// TestHTTPRepository_Get test if http get works
-func TestHTTPRepository_Get(t *testing.T) {
+// TestHTTPRepository_Do test if http get works
+func TestHTTPRepository_Do(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, _ = fmt.Fprintln(w, "Hello")
 	}))
 	defer ts.Close()
 
 	repository := NewHTTPRepository(&config.HTTP{SSLVerify: false, Timeout: 2000})
-	response, err := repository.Get(ts.URL)
+	response, err := repository.Do(&models.Request{URL: ts.URL})
 
 	if assert.NoError(t, err) {
 		assert.Equal(t, 200, response.StatusCode)
@@ -34,13 +36,13 @@ func TestHTTPRepository_Get(t *testing.T) {
 	}
 }
 
-func TestHTTPRepository_Get_Error(t *testing.T) {
+func TestHTTPRepository_Do_Error(t *testing.T) {
 	repository := NewHTTPRepository(&config.HTTP{SSLVerify: false, Timeout: 2000})
-	_, err := repository.Get("http://monitoror.example.com")
+	_, err := repository.Do(&models.Request{URL: "http://monitoror.example.com"})
 	assert.Error(t, err)
 }
 
-func TestHTTPRepository_Get_ReadAll_Error(t *testing.T) {
+func TestHTTPRepository_Do_ReadAll_Error(t *testing.T) {
 	client := test.NewTestClient(func(req *http.Request) *http.Response {
 		// Test request parameters
 		return &http.Response{
@@ -51,6 +53,76 @@ func TestHTTPRepository_Get_ReadAll_Error(t *testing.T) {
 	})
 	repository := httpRepository{httpClient: client}
 
-	_, err := repository.Get("http://monitoror.example.com")
+	_, err := repository.Do(&models.Request{URL: "http://monitoror.example.com"})
 	assert.Error(t, err)
 }
+
+func TestHTTPRepository_Do_WithRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		_, _ = fmt.Fprintf(w, "%s %s %s %s %s", r.Method, r.Header.Get("Authorization"), r.Header.Get("X-Default"), r.Header.Get("X-Api-Key"), body)
+	}))
+	defer ts.Close()
+
+	repository := NewHTTPRepository(&config.HTTP{Timeout: 2000, Token: "token", Headers: []string{"X-Default: default", "X-Api-Key: default"}, AllowedHosts: []string{"127.0.0.1"}})
+	response, err := repository.Do(&models.Request{Method: "POST", URL: ts.URL, Headers: []string{"X-Api-Key: key"}, Body: "body"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "POST Bearer token default key body", string(response.Body))
+	}
+
+	repository = NewHTTPRepository(&config.HTTP{Timeout: 2000, Login: "login", Password: "password", AllowedHosts: []string{"127.0.0.1"}})
+	response, err = repository.Do(&models.Request{URL: ts.URL})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "GET Basic bG9naW46cGFzc3dvcmQ=   ", string(response.Body))
+	}
+}
+
+func TestHTTPRepository_Do_WithRequest_NotAllowedHost(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s %s %s", r.Header.Get("Authorization"), r.Header.Get("X-Default"), r.Header.Get("X-Api-Key"))
+	}))
+	defer ts.Close()
+
+	// Other host
+	repository := NewHTTPRepository(&config.HTTP{Timeout: 2000, Token: "token", Headers: []string{"X-Default: default"}, AllowedHosts: []string{"api.example.com"}})
+	response, err := repository.Do(&models.Request{URL: ts.URL, Headers: []string{"X-Api-Key: key"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "  key", string(response.Body))
+	}
+
+	// Redirected to other host
+	allowed := httptest.NewServer(http.RedirectHandler(ts.URL, http.StatusFound))
+	defer allowed.Close()
+
+	repository = NewHTTPRepository(&config.HTTP{Timeout: 2000, Login: "login", Password: "password", Headers: []string{"X-Default: default"},
+		AllowedHosts: []string{strings.TrimPrefix(allowed.URL, "http://")}})
+	response, err = repository.Do(&models.Request{URL: allowed.URL})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "  ", string(response.Body))
+	}
+}
+
+func TestHTTPRepository_Do_InvalidRequest(t *testing.T) {
+	repository := NewHTTPRepository(&config.HTTP{Timeout: 2000})
+	_, err := repository.Do(&models.Request{Method: "BAD METHOD", URL: "http://monitoror.example.com"})
+	assert.Error(t, err)
+}
+
+func TestHTTPRepository_Do_Timings(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = fmt.Fprintln(w, "Hello")
+	}))
+	defer ts.Close()
+
+	repository := NewHTTPRepository(&config.HTTP{SSLVerify: false, Timeout: 2000})
+	for i := 0; i < 2; i++ {
+		response, err := repository.Do(&models.Request{URL: ts.URL, NewConnection: true})
+		if assert.NoError(t, err) {
+			assert.NotZero(t, response.Timings.Connect)
+			assert.NotZero(t, response.Timings.TLS)
+			assert.True(t, response.Timings.TTFB >= 10*time.Millisecond)
+			assert.True(t, response.Timings.Total >= response.Timings.TTFB)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/monitorables/http/api/models"
+)
+
+// timingsTrace measure timings of request. Hooks can be called concurrently (parallel dials), fields are locked
+type timingsTrace struct {
+	lock sync.Mutex
+
+	startTime        time.Time
+	dnsStartTime     time.Time
+	connectStartTime time.Time
+	tlsStartTime     time.Time
+
+	timings models.Timings
+}
+
+func newTimingsTrace() *timingsTrace {
+	return &timingsTrace{}
+}
+
+func (t *timingsTrace) start() {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.startTime = time.Now()
+}
+
+// done return timings, total is measured since start
+func (t *timingsTrace) done() models.Timings {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.timings.Total = time.Since(t.startTime)
+	return t.timings
+}
+
+func (t *timingsTrace) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			t.setTime(&t.dnsStartTime)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			t.setDuration(&t.timings.DNS, &t.dnsStartTime)
+		},
+		ConnectStart: func(string, string) {
+			t.setTime(&t.connectStartTime)
+		},
+		ConnectDone: func(_, _ string, err error) {
+			if err == nil {
+				t.setDuration(&t.timings.Connect, &t.connectStartTime)
+			}
+		},
+		TLSHandshakeStart: func() {
+			t.setTime(&t.tlsStartTime)
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, err error) {
+			if err == nil {
+				t.setDuration(&t.timings.TLS, &t.tlsStartTime)
+			}
+		},
+		GotFirstResponseByte: func() {
+			t.setDuration(&t.timings.TTFB, &t.startTime)
+		},
+	}
+}
+
+func (t *timingsTrace) setTime(value *time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	*value = time.Now()
+}
+
+// setDuration set duration since start, only the first time (first successful dial is used)
+func (t *timingsTrace) setDuration(value *time.Duration, start *time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if *value == 0 {
+		*value = time.Since(*start)
+	}
+}
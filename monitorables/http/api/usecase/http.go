@@ -4,6 +4,7 @@ package usecase
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -24,6 +25,8 @@ import (
 type (
 	httpUsecase struct {
 		repository api.Repository
+		// variantName is added to cache key, variants can send different headers / authentication
+		variantName coreModels.VariantName
 
 		// store used for caching request on same url
 		store           cache.Store
@@ -36,8 +39,8 @@ var (
 	ArrayKeyPartRegex = regexp.MustCompile(`^\[(\d*)]$`)
 )
 
-func NewHTTPUsecase(repository api.Repository, store cache.Store, cacheExpiration int) api.Usecase {
-	return &httpUsecase{repository, store, cacheExpiration}
+func NewHTTPUsecase(repository api.Repository, variantName coreModels.VariantName, store cache.Store, cacheExpiration int) api.Usecase {
+	return &httpUsecase{repository, variantName, store, cacheExpiration}
 }
 
 func (hu *httpUsecase) HTTPStatus(params *models.HTTPStatusParams) (*coreModels.Tile, error) {
@@ -52,6 +55,49 @@ func (hu *httpUsecase) HTTPFormatted(params *models.HTTPFormattedParams) (*coreM
 	return hu.httpAll(api.HTTPFormattedTileType, params)
 }
 
+// HTTPLatency check status code and return timings of request (in millisecond): DNS, connect, TLS, TTFB and total.
+// Labeled timings are sent in message. Status depends on total time and thresholds
+func (hu *httpUsecase) HTTPLatency(params *models.HTTPLatencyParams) (*coreModels.Tile, error) {
+	tile := coreModels.NewTile(api.HTTPLatencyTileType)
+	tile.Label = params.GetURL()
+	tile.Status = coreModels.SuccessStatus
+
+	// New connection to measure DNS, connect and TLS timings
+	request := newRequest(params)
+	request.NewConnection = true
+
+	response, err := hu.get(request)
+	if err != nil {
+		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: fmt.Sprintf("unable to get %s", params.GetURL())}
+	}
+
+	if !checkStatusCode(params, response.StatusCode) {
+		tile.Status = coreModels.FailedStatus
+		tile.Message = fmt.Sprintf("status code %d", response.StatusCode)
+		return tile, nil
+	}
+
+	// Values are DNS, connect, TLS, TTFB and total (see Timings.Milliseconds). Total is the last value, displayed by UI
+	timings := response.Timings
+	tile.WithMetrics(coreModels.MillisecondUnit)
+	for _, value := range timings.Milliseconds() {
+		tile.Metrics.Values = append(tile.Metrics.Values, strconv.FormatInt(value, 10))
+	}
+	tile.Message = timings.String()
+
+	total := int(timings.Total.Milliseconds())
+	warning, failure := params.GetThresholds()
+	if failure != nil && total > *failure {
+		tile.Status = coreModels.FailedStatus
+		tile.Message = fmt.Sprintf("response time %dms is above %dms (%s)", total, *failure, timings)
+	} else if warning != nil && total > *warning {
+		tile.Status = coreModels.WarningStatus
+		tile.Message = fmt.Sprintf("response time %dms is above %dms (%s)", total, *warning, timings)
+	}
+
+	return tile, nil
+}
+
 // httpAll handle all http usecase by checking if params match interfaces listed in coreModels.params
 func (hu *httpUsecase) httpAll(tileType coreModels.TileType, params models.GenericParamsProvider) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(tileType)
@@ -59,7 +105,7 @@ func (hu *httpUsecase) httpAll(tileType coreModels.TileType, params models.Gener
 	tile.Status = coreModels.SuccessStatus
 
 	// Download page
-	response, err := hu.get(params.GetURL())
+	response, err := hu.get(newRequest(params))
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: fmt.Sprintf("unable to get %s", params.GetURL())}
 	}
@@ -76,8 +122,7 @@ func (hu *httpUsecase) httpAll(tileType coreModels.TileType, params models.Gener
 	}
 
 	// Unmarshal page
-	var content string
-	var match bool
+	var values []string
 
 	if formattedParamsProvider, ok := params.(models.FormattedParamsProvider); ok {
 		// Convert XML to JSON if Format == XML
@@ -108,51 +153,76 @@ func (hu *httpUsecase) httpAll(tileType coreModels.TileType, params models.Gener
 			return tile, nil
 		}
 
-		// Lookup a key
-		if match, content = lookupKey(formattedParamsProvider, data); !match {
-			tile.Status = coreModels.FailedStatus
-			tile.Message = fmt.Sprintf(`unable to lookup for key %q`, formattedParamsProvider.GetKey())
-			return tile, nil
+		if formattedParamsProvider.GetQuery() != "" {
+			// Evaluate a query, can return several values
+			var match bool
+			if match, values = lookupQuery(formattedParamsProvider, data); !match {
+				tile.Status = coreModels.FailedStatus
+				tile.Message = fmt.Sprintf(`unable to lookup for query %q`, formattedParamsProvider.GetQuery())
+				return tile, nil
+			}
+		} else {
+			// Lookup a key
+			match, content := lookupKey(formattedParamsProvider, data)
+			if !match {
+				tile.Status = coreModels.FailedStatus
+				tile.Message = fmt.Sprintf(`unable to lookup for key %q`, formattedParamsProvider.GetKey())
+				return tile, nil
+			}
+			values = []string{content}
 		}
 	} else {
-		content = string(response.Body)
+		values = []string{string(response.Body)}
 	}
 
-	// Match regex
+	// Match regex on every value
 	if regexParamsProvider, ok := params.(models.RegexParamsProvider); ok {
-		match, matchedContent := matchRegex(regexParamsProvider, content)
-		if match {
-			content = matchedContent
-		} else {
-			tile.Status = coreModels.FailedStatus
+		for i, value := range values {
+			if match, matchedContent := matchRegex(regexParamsProvider, value); match {
+				values[i] = matchedContent
+			} else {
+				tile.Status = coreModels.FailedStatus
+			}
 		}
 	}
 
-	if content != "" {
-		if _, err := strconv.ParseFloat(content, 64); err == nil {
-			tile.WithMetrics(coreModels.NumberUnit)
-		} else {
-			tile.WithMetrics(coreModels.RawUnit)
+	// Empty content is not displayed
+	if len(values) == 1 && values[0] == "" {
+		values = nil
+	}
+
+	if len(values) != 0 {
+		tile.WithMetrics(coreModels.NumberUnit)
+		for _, value := range values {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				tile.WithMetrics(coreModels.RawUnit)
+				break
+			}
 		}
-		tile.Metrics.Values = []string{content}
+		tile.Metrics.Values = values
+	}
+
+	// Compute status with rules (only when content was found / matched)
+	if rulesParamsProvider, ok := params.(models.RulesParamsProvider); ok && tile.Status == coreModels.SuccessStatus {
+		tile.Status, tile.Message = checkRules(rulesParamsProvider.GetRules(), values)
 	}
 
 	return tile, nil
 }
 
-// Adding cache to Repository.Get
-func (hu *httpUsecase) get(url string) (*models.Response, error) {
+// Adding cache to Repository.Do
+func (hu *httpUsecase) get(request *models.Request) (*models.Response, error) {
 	response := &models.Response{}
 
 	// Lookup in cache
-	key := fmt.Sprintf("%s:%s", coreModels.UpstreamStoreKeyPrefix, url)
+	key := hu.cacheKey(request)
 	if err := hu.store.Get(key, response); err == nil {
 		// Cache found, return
 		return response, nil
 	}
 
 	// Download page
-	response, err := hu.repository.Get(url)
+	response, err := hu.repository.Do(request)
 	if err != nil {
 		return nil, err
 	}
@@ -163,6 +233,38 @@ func (hu *httpUsecase) get(url string) (*models.Response, error) {
 	return response, nil
 }
 
+// cacheKey return key of request in store. Headers and body are hashed, they can contain credentials
+func (hu *httpUsecase) cacheKey(request *models.Request) string {
+	key := fmt.Sprintf("%s:%s:%s:%s", coreModels.UpstreamStoreKeyPrefix, hu.variantName, request.Method, request.URL)
+	if request.NewConnection {
+		// Timings of reused connection are incomplete
+		key = fmt.Sprintf("%s:new", key)
+	}
+	if len(request.Headers) == 0 && request.Body == "" {
+		return key
+	}
+
+	hash := sha256.New()
+	for _, header := range request.Headers {
+		_, _ = fmt.Fprintln(hash, header)
+	}
+	_, _ = fmt.Fprintf(hash, "\n%s", request.Body)
+
+	return fmt.Sprintf("%s:%x", key, hash.Sum(nil))
+}
+
+// newRequest return request described by params
+func newRequest(params models.GenericParamsProvider) *models.Request {
+	request := &models.Request{Method: models.DefaultMethod, URL: params.GetURL()}
+	if requestParamsProvider, ok := params.(models.RequestParamsProvider); ok {
+		request.Method = requestParamsProvider.GetMethod()
+		request.Headers = requestParamsProvider.GetHeaders()
+		request.Body = requestParamsProvider.GetBody()
+	}
+
+	return request
+}
+
 // checkStatusCode check if status code is between min / max
 // if min/max are empty, use default value
 func checkStatusCode(params models.GenericParamsProvider, code int) bool {
@@ -189,6 +291,88 @@ func matchRegex(params models.RegexParamsProvider, str string) (bool, string) {
 	return true, substrings[1]
 }
 
+// checkRules check every value with rules, return worst status and its explanation
+// failure rules have priority over warning rules. Without value (empty result, empty match), rules can't be respected
+func checkRules(rules *models.Rules, values []string) (coreModels.TileStatus, string) {
+	status, message := coreModels.SuccessStatus, ""
+
+	if len(values) == 0 && hasRules(rules) {
+		return coreModels.FailedStatus, "no value to check"
+	}
+
+	for _, value := range values {
+		if failure := checkFailureRules(rules, value); failure != "" {
+			return coreModels.FailedStatus, failure
+		}
+
+		if status == coreModels.SuccessStatus && rules.WarnAbove != nil {
+			// Already checked by checkFailureRules, value is a number
+			number, _ := strconv.ParseFloat(value, 64)
+			if number > *rules.WarnAbove {
+				status = coreModels.WarningStatus
+				message = fmt.Sprintf("value %s is above %s", value, humanize.Interface(*rules.WarnAbove))
+			}
+		}
+	}
+
+	return status, message
+}
+
+func hasRules(rules *models.Rules) bool {
+	return rules.WarnAbove != nil || rules.FailAbove != nil || rules.FailBelow != nil ||
+		rules.Equals != "" || rules.NotEquals != "" || len(rules.ExpectedValues) != 0
+}
+
+// checkFailureRules return explanation of first failed rule, empty string when value respect every rule
+func checkFailureRules(rules *models.Rules, value string) string {
+	if rules.Equals != "" && !equalValues(value, rules.Equals) {
+		return fmt.Sprintf("value %q is not equal to %q", value, rules.Equals)
+	}
+	if rules.NotEquals != "" && equalValues(value, rules.NotEquals) {
+		return fmt.Sprintf("value %q is equal to %q", value, rules.NotEquals)
+	}
+	if len(rules.ExpectedValues) != 0 {
+		expected := false
+		for _, expectedValue := range rules.ExpectedValues {
+			if equalValues(value, expectedValue) {
+				expected = true
+				break
+			}
+		}
+		if !expected {
+			return fmt.Sprintf("value %q is not one of [%s]", value, strings.Join(rules.ExpectedValues, ", "))
+		}
+	}
+
+	if rules.WarnAbove == nil && rules.FailAbove == nil && rules.FailBelow == nil {
+		return ""
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Sprintf("value %q is not a number", value)
+	}
+	if rules.FailAbove != nil && number > *rules.FailAbove {
+		return fmt.Sprintf("value %s is above %s", value, humanize.Interface(*rules.FailAbove))
+	}
+	if rules.FailBelow != nil && number < *rules.FailBelow {
+		return fmt.Sprintf("value %s is below %s", value, humanize.Interface(*rules.FailBelow))
+	}
+
+	return ""
+}
+
+// equalValues compare values as numbers when both are numbers ("1.0" equals "1"), as strings otherwise
+func equalValues(value, expected string) bool {
+	number, err := strconv.ParseFloat(value, 64)
+	expectedNumber, expectedErr := strconv.ParseFloat(expected, 64)
+	if err == nil && expectedErr == nil {
+		return number == expectedNumber
+	}
+
+	return value == expected
+}
+
 // extractValue extract value from interface{} (json/yaml/...)
 // the key is in doted format like this ".bloc1."bloc.2".[2].value"
 func lookupKey(params models.FormattedParamsProvider, data interface{}) (bool, string) {
@@ -235,3 +419,30 @@ func lookupKey(params models.FormattedParamsProvider, data interface{}) (bool, s
 
 	return true, humanize.Interface(data)
 }
+
+// lookupQuery evaluate JMESPath / JSONPath query on interface{} (json/yaml/...)
+// arrays are returned as several values (ex: "items[*].status")
+func lookupQuery(params models.FormattedParamsProvider, data interface{}) (bool, []string) {
+	query, err := models.ParseQuery(params.GetQuery())
+	if err != nil {
+		return false, nil
+	}
+
+	result, err := query(data)
+	if err != nil || result == nil {
+		return false, nil
+	}
+
+	array, ok := result.([]interface{})
+	if !ok {
+		return true, []string{humanize.Interface(result)}
+	}
+
+	values := make([]string, len(array))
+	for i, value := range array {
+		if value != nil {
+			values[i] = humanize.Interface(value)
+		}
+	}
+	return true, values
+}
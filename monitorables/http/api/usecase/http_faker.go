@@ -43,6 +43,32 @@ func (hu *httpUsecase) HTTPFormatted(params *models.HTTPFormattedParams) (tile *
 	return hu.httpAll(api.HTTPFormattedTileType, params.URL, params)
 }
 
+// HTTPLatency return random timings (DNS, connect, TLS, TTFB and total)
+func (hu *httpUsecase) HTTPLatency(params *models.HTTPLatencyParams) (tile *coreModels.Tile, err error) {
+	tile = coreModels.NewTile(api.HTTPLatencyTileType)
+	tile.Label = params.URL
+
+	tile.Status = nonempty.Struct(params.GetStatus(), hu.computeStatus(params.URL)).(coreModels.TileStatus)
+	tile.WithMetrics(coreModels.MillisecondUnit)
+	if len(params.GetValueValues()) != 0 {
+		tile.Metrics.Values = params.GetValueValues()
+	} else {
+		total := 0
+		for i := 0; i < 4; i++ {
+			duration := rand.Intn(50)
+			total += duration
+			tile.Metrics.Values = append(tile.Metrics.Values, strconv.Itoa(duration))
+		}
+		tile.Metrics.Values = append(tile.Metrics.Values, strconv.Itoa(total+rand.Intn(50)))
+	}
+
+	if tile.Status == coreModels.FailedStatus {
+		tile.Message = nonempty.String(params.GetMessage(), "Fake error message")
+	}
+
+	return
+}
+
 // httpAll handle all http usecase by checking if params match interfaces listed in coreModels.params
 func (hu *httpUsecase) httpAll(tileType coreModels.TileType, url string, params models.FakerParamsProvider) (tile *coreModels.Tile, err error) {
 	tile = coreModels.NewTile(tileType)
@@ -20,13 +20,13 @@ import (
 
 func TestHTTPStatus_WithError(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("Get", AnythingOfType("string")).Return(nil, context.DeadlineExceeded)
-	tu := NewHTTPUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
+	mockRepository.On("Do", AnythingOfType("*models.Request")).Return(nil, context.DeadlineExceeded)
+	tu := NewHTTPUsecase(mockRepository, coreModels.DefaultVariantName, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
 
 	tile, err := tu.HTTPStatus(&models.HTTPStatusParams{URL: "toto"})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
-		mockRepository.AssertNumberOfCalls(t, "Get", 1)
+		mockRepository.AssertNumberOfCalls(t, "Do", 1)
 		mockRepository.AssertExpectations(t)
 	}
 }
@@ -143,11 +143,115 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{"value"},
 		},
+		{
+			// HTTP Json with JMESPath query
+			body: `{"items": [{"status": "up"}, {"status": "down"}, {"status": "down"}]}`,
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Query: "length(items[?status=='down'])"})
+			},
+			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"2"},
+		},
+		{
+			// HTTP Json with JMESPath query returning several values
+			body: `{"items": [{"name": "api", "status": "up"}, {"name": "db", "status": "down"}]}`,
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Query: "items[*].status"})
+			},
+			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{"up", "down"},
+		},
+		{
+			// HTTP Json with JSONPath query returning several values
+			body: `{"items": [{"size": 1}, {"size": 2.5}]}`,
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Query: "$.items[*].size"})
+			},
+			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"1", "2.5"},
+		},
+		{
+			// HTTP YAML with query and regex on every value
+			body: "versions: [v1.2, v1.3]",
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.YAMLFormat, Query: "versions", Regex: `v(.*)`})
+			},
+			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"1.2", "1.3"},
+		},
+		{
+			// HTTP Json query without result
+			body: `{"key": "value"}`,
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Query: "key2"})
+			},
+			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: `unable to lookup for query "key2"`,
+		},
+		{
+			// HTTP Json with value above failure rule
+			body: `{"queue": {"depth": 42}}`,
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Query: "queue.depth", WarnAbove: pointer.ToFloat64(10), FailAbove: pointer.ToFloat64(40)})
+			},
+			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: "value 42 is above 40", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"42"},
+		},
+		{
+			// HTTP Json with values above warning rule
+			body: `{"queues": [{"depth": 5}, {"depth": 12}]}`,
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Query: "queues[*].depth", WarnAbove: pointer.ToFloat64(10), FailAbove: pointer.ToFloat64(40)})
+			},
+			expectedStatus: coreModels.WarningStatus, expectedLabel: "toto", expectedMessage: "value 12 is above 10", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"5", "12"},
+		},
+		{
+			// HTTP Json with empty query result and rule
+			body: `{"items": [{"name": "api", "status": "up"}]}`,
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Query: "items[?status=='down'].name", NotEquals: "db"})
+			},
+			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: "no value to check",
+		},
+		{
+			// HTTP Raw with empty regex match and rule
+			body: "errors: ",
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPRaw(&models.HTTPRawParams{URL: "toto", Regex: `errors: (\d*)`, FailAbove: pointer.ToFloat64(1)})
+			},
+			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: "no value to check",
+		},
+		{
+			// HTTP Raw with empty body and rule
+			body: "",
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPRaw(&models.HTTPRawParams{URL: "toto", Equals: "OK"})
+			},
+			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: "no value to check",
+		},
+		{
+			// HTTP Raw with expected values
+			body: "status: degraded",
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPRaw(&models.HTTPRawParams{URL: "toto", Regex: `status: (.*)`, ExpectedValues: []string{"ok", "maintenance"}})
+			},
+			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: `value "degraded" is not one of [ok, maintenance]`, expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{"degraded"},
+		},
+		{
+			// HTTP Raw with rules respected
+			body: "errors: 0",
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPRaw(&models.HTTPRawParams{URL: "toto", Regex: `errors: (\d*)`, Equals: "0", FailAbove: pointer.ToFloat64(1)})
+			},
+			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"0"},
+		},
+		{
+			// HTTP Raw without matched regex, rules are ignored
+			body: "api call: 20",
+			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
+				return usecase.HTTPRaw(&models.HTTPRawParams{URL: "toto", Regex: `errors: (\d*)`, Equals: "0"})
+			},
+			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{`api call: 20`},
+		},
 	} {
 		mockRepository := new(mocks.Repository)
-		mockRepository.On("Get", AnythingOfType("string")).
+		mockRepository.On("Do", AnythingOfType("*models.Request")).
 			Return(&models.Response{StatusCode: 200, Body: []byte(testcase.body)}, nil)
-		tu := NewHTTPUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
+		tu := NewHTTPUsecase(mockRepository, coreModels.DefaultVariantName, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
 
 		tile, err := testcase.usecaseFunc(tu)
 		if assert.NoError(t, err) {
@@ -158,7 +262,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 				assert.Equal(t, testcase.expectedValueUnit, tile.Metrics.Unit)
 				assert.Equal(t, testcase.expectedValueValues, tile.Metrics.Values)
 			}
-			mockRepository.AssertNumberOfCalls(t, "Get", 1)
+			mockRepository.AssertNumberOfCalls(t, "Do", 1)
 			mockRepository.AssertExpectations(t)
 		}
 	}
@@ -166,10 +270,10 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 
 func TestHTTPStatus_WithCache(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("Get", AnythingOfType("string")).
+	mockRepository.On("Do", AnythingOfType("*models.Request")).
 		Return(&models.Response{StatusCode: 200, Body: []byte("test with cache")}, nil)
 
-	tu := NewHTTPUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
+	tu := NewHTTPUsecase(mockRepository, coreModels.DefaultVariantName, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
 
 	tile, err := tu.HTTPRaw(&models.HTTPRawParams{URL: "toto"})
 	if assert.NoError(t, err) {
@@ -182,10 +286,123 @@ func TestHTTPStatus_WithCache(t *testing.T) {
 		assert.Equal(t, "toto", tile.Label)
 		assert.Equal(t, "test with cache", tile.Metrics.Values[0])
 	}
-	mockRepository.AssertNumberOfCalls(t, "Get", 1)
+	mockRepository.AssertNumberOfCalls(t, "Do", 1)
+	mockRepository.AssertExpectations(t)
+}
+
+func TestHTTPRaw_WithRequestParams(t *testing.T) {
+	mockRepository := new(mocks.Repository)
+	mockRepository.On("Do", &models.Request{Method: "POST", URL: "toto", Headers: []string{"X-Api-Key: secret"}, Body: `{"ping":true}`}).
+		Return(&models.Response{StatusCode: 200, Body: []byte("pong")}, nil)
+	mockRepository.On("Do", &models.Request{Method: "POST", URL: "toto", Headers: []string{"X-Api-Key: other"}, Body: `{"ping":true}`}).
+		Return(&models.Response{StatusCode: 401}, nil)
+
+	tu := NewHTTPUsecase(mockRepository, coreModels.DefaultVariantName, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
+
+	params := &models.HTTPRawParams{URL: "toto", Method: "POST", Headers: []string{"X-Api-Key: secret"}, Body: `{"ping":true}`}
+	tile, err := tu.HTTPRaw(params)
+	if assert.NoError(t, err) {
+		assert.Equal(t, coreModels.SuccessStatus, tile.Status)
+		assert.Equal(t, "pong", tile.Metrics.Values[0])
+	}
+
+	// Same url, other headers: not cached
+	params.Headers = []string{"X-Api-Key: other"}
+	tile, err = tu.HTTPRaw(params)
+	if assert.NoError(t, err) {
+		assert.Equal(t, coreModels.FailedStatus, tile.Status)
+	}
+
+	mockRepository.AssertNumberOfCalls(t, "Do", 2)
 	mockRepository.AssertExpectations(t)
 }
 
+func TestHTTPLatency(t *testing.T) {
+	timings := models.Timings{
+		DNS:     10 * time.Millisecond,
+		Connect: 20 * time.Millisecond,
+		TLS:     30 * time.Millisecond,
+		TTFB:    150 * time.Millisecond,
+		Total:   600 * time.Millisecond,
+	}
+
+	for _, testcase := range []struct {
+		params          *models.HTTPLatencyParams
+		statusCode      int
+		expectedStatus  coreModels.TileStatus
+		expectedMessage string
+	}{
+		{
+			params:     &models.HTTPLatencyParams{URL: "toto"},
+			statusCode: 200, expectedStatus: coreModels.SuccessStatus, expectedMessage: "DNS 10ms, connect 20ms, TLS 30ms, TTFB 150ms, total 600ms",
+		},
+		{
+			params:     &models.HTTPLatencyParams{URL: "toto", WarningThreshold: pointer.ToInt(600), FailureThreshold: pointer.ToInt(1000)},
+			statusCode: 200, expectedStatus: coreModels.SuccessStatus, expectedMessage: "DNS 10ms, connect 20ms, TLS 30ms, TTFB 150ms, total 600ms",
+		},
+		{
+			params:     &models.HTTPLatencyParams{URL: "toto", WarningThreshold: pointer.ToInt(500), FailureThreshold: pointer.ToInt(1000)},
+			statusCode: 200, expectedStatus: coreModels.WarningStatus, expectedMessage: "response time 600ms is above 500ms (DNS 10ms, connect 20ms, TLS 30ms, TTFB 150ms, total 600ms)",
+		},
+		{
+			params:     &models.HTTPLatencyParams{URL: "toto", WarningThreshold: pointer.ToInt(200), FailureThreshold: pointer.ToInt(500)},
+			statusCode: 200, expectedStatus: coreModels.FailedStatus, expectedMessage: "response time 600ms is above 500ms (DNS 10ms, connect 20ms, TLS 30ms, TTFB 150ms, total 600ms)",
+		},
+		{
+			params:     &models.HTTPLatencyParams{URL: "toto", FailureThreshold: pointer.ToInt(500)},
+			statusCode: 500, expectedStatus: coreModels.FailedStatus, expectedMessage: "status code 500",
+		},
+	} {
+		mockRepository := new(mocks.Repository)
+		mockRepository.On("Do", &models.Request{Method: "GET", URL: "toto", NewConnection: true}).
+			Return(&models.Response{StatusCode: testcase.statusCode, Timings: timings}, nil)
+		tu := NewHTTPUsecase(mockRepository, coreModels.DefaultVariantName, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
+
+		tile, err := tu.HTTPLatency(testcase.params)
+		if assert.NoError(t, err) {
+			assert.Equal(t, api.HTTPLatencyTileType, tile.Type)
+			assert.Equal(t, "toto", tile.Label)
+			assert.Equal(t, testcase.expectedStatus, tile.Status)
+			assert.Equal(t, testcase.expectedMessage, tile.Message)
+			if testcase.statusCode == 200 {
+				assert.Equal(t, coreModels.MillisecondUnit, tile.Metrics.Unit)
+				assert.Equal(t, []string{"10", "20", "30", "150", "600"}, tile.Metrics.Values)
+			}
+			mockRepository.AssertExpectations(t)
+		}
+	}
+}
+
+func TestHTTPLatency_WithError(t *testing.T) {
+	mockRepository := new(mocks.Repository)
+	mockRepository.On("Do", AnythingOfType("*models.Request")).Return(nil, context.DeadlineExceeded)
+	tu := NewHTTPUsecase(mockRepository, coreModels.DefaultVariantName, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
+
+	tile, err := tu.HTTPLatency(&models.HTTPLatencyParams{URL: "toto"})
+	if assert.Error(t, err) {
+		assert.Nil(t, tile)
+		assert.IsType(t, &coreModels.MonitororError{}, err)
+	}
+}
+
+func TestHTTPUsecase_CacheKey(t *testing.T) {
+	hu := &httpUsecase{variantName: coreModels.DefaultVariantName}
+	otherVariant := &httpUsecase{variantName: "other"}
+
+	get := &models.Request{Method: "GET", URL: "http://example.com"}
+	post := &models.Request{Method: "POST", URL: "http://example.com", Body: "body"}
+	withHeaders := &models.Request{Method: "GET", URL: "http://example.com", Headers: []string{"Authorization: Bearer secret"}}
+
+	assert.Equal(t, coreModels.UpstreamStoreKeyPrefix+":default:GET:http://example.com", hu.cacheKey(get))
+	assert.NotEqual(t, hu.cacheKey(get), otherVariant.cacheKey(get))
+	assert.NotEqual(t, hu.cacheKey(get), hu.cacheKey(post))
+	assert.NotEqual(t, hu.cacheKey(get), hu.cacheKey(withHeaders))
+	assert.NotContains(t, hu.cacheKey(withHeaders), "secret")
+
+	newConnection := &models.Request{Method: "GET", URL: "http://example.com", NewConnection: true}
+	assert.NotEqual(t, hu.cacheKey(get), hu.cacheKey(newConnection))
+}
+
 func TestHTTPUsecase_CheckStatusCode(t *testing.T) {
 	httpAny := &models.HTTPStatusParams{}
 	assert.True(t, checkStatusCode(httpAny, 301))
@@ -283,3 +500,67 @@ bloc1:
 		assert.False(t, found)
 	}
 }
+
+func TestHTTPUsecase_LookupQuery(t *testing.T) {
+	input := `
+{
+	"bloc1": {
+		"bloc.2": [
+			{ "value": "YEAH !!", "count": 2 },
+			{ "value": null, "count": 3 }
+		]
+	}
+}
+`
+	var data interface{}
+	if err := json.Unmarshal([]byte(input), &data); !assert.NoError(t, err) {
+		return
+	}
+
+	for _, testcase := range []struct {
+		query          string
+		expectedFound  bool
+		expectedValues []string
+	}{
+		{query: `bloc1."bloc.2"[0].value`, expectedFound: true, expectedValues: []string{"YEAH !!"}},
+		{query: `bloc1."bloc.2"[*].value`, expectedFound: true, expectedValues: []string{"YEAH !!"}},
+		{query: `bloc1."bloc.2"[*].count | sum(@)`, expectedFound: true, expectedValues: []string{"5"}},
+		{query: `$.bloc1["bloc.2"][*].value`, expectedFound: true, expectedValues: []string{"YEAH !!", ""}},
+		{query: `bloc1."bloc.2"[?value == 'NOOO !!']`, expectedFound: true, expectedValues: []string{}},
+		{query: `bloc1.missing`, expectedFound: false},
+		{query: `$.bloc1.missing`, expectedFound: false},
+		{query: `bloc1[`, expectedFound: false},
+	} {
+		found, values := lookupQuery(&models.HTTPFormattedParams{Query: testcase.query}, data)
+		assert.Equal(t, testcase.expectedFound, found, testcase.query)
+		assert.Equal(t, testcase.expectedValues, values, testcase.query)
+	}
+}
+
+func TestHTTPUsecase_CheckRules(t *testing.T) {
+	for _, testcase := range []struct {
+		rules           *models.Rules
+		values          []string
+		expectedStatus  coreModels.TileStatus
+		expectedMessage string
+	}{
+		{rules: &models.Rules{}, values: []string{"anything"}, expectedStatus: coreModels.SuccessStatus},
+		{rules: &models.Rules{}, values: nil, expectedStatus: coreModels.SuccessStatus},
+		{rules: &models.Rules{FailAbove: pointer.ToFloat64(10)}, values: nil, expectedStatus: coreModels.FailedStatus, expectedMessage: "no value to check"},
+		{rules: &models.Rules{NotEquals: "DOWN"}, values: []string{}, expectedStatus: coreModels.FailedStatus, expectedMessage: "no value to check"},
+		{rules: &models.Rules{FailAbove: pointer.ToFloat64(10)}, values: []string{"10"}, expectedStatus: coreModels.SuccessStatus},
+		{rules: &models.Rules{FailAbove: pointer.ToFloat64(10)}, values: []string{"10.5"}, expectedStatus: coreModels.FailedStatus, expectedMessage: "value 10.5 is above 10"},
+		{rules: &models.Rules{FailBelow: pointer.ToFloat64(1.5)}, values: []string{"3", "1"}, expectedStatus: coreModels.FailedStatus, expectedMessage: "value 1 is below 1.5"},
+		{rules: &models.Rules{FailBelow: pointer.ToFloat64(1)}, values: []string{"OK"}, expectedStatus: coreModels.FailedStatus, expectedMessage: `value "OK" is not a number`},
+		{rules: &models.Rules{WarnAbove: pointer.ToFloat64(5)}, values: []string{"6"}, expectedStatus: coreModels.WarningStatus, expectedMessage: "value 6 is above 5"},
+		{rules: &models.Rules{WarnAbove: pointer.ToFloat64(5), FailAbove: pointer.ToFloat64(10)}, values: []string{"6", "11"}, expectedStatus: coreModels.FailedStatus, expectedMessage: "value 11 is above 10"},
+		{rules: &models.Rules{Equals: "1"}, values: []string{"1.0"}, expectedStatus: coreModels.SuccessStatus},
+		{rules: &models.Rules{Equals: "UP"}, values: []string{"up"}, expectedStatus: coreModels.FailedStatus, expectedMessage: `value "up" is not equal to "UP"`},
+		{rules: &models.Rules{NotEquals: "DOWN"}, values: []string{"UP", "DOWN"}, expectedStatus: coreModels.FailedStatus, expectedMessage: `value "DOWN" is equal to "DOWN"`},
+		{rules: &models.Rules{ExpectedValues: []string{"UP", "MAINTENANCE"}}, values: []string{"MAINTENANCE"}, expectedStatus: coreModels.SuccessStatus},
+	} {
+		status, message := checkRules(testcase.rules, testcase.values)
+		assert.Equal(t, testcase.expectedStatus, status, "%+v %v", testcase.rules, testcase.values)
+		assert.Equal(t, testcase.expectedMessage, message, "%+v %v", testcase.rules, testcase.values)
+	}
+}
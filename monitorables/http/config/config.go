@@ -1,13 +1,78 @@
 package config
 
+import (
+	"net/url"
+	"strings"
+
+	"github.com/monitoror/monitoror/internal/pkg/validator"
+	"github.com/monitoror/monitoror/monitorables/http/api/models"
+)
+
 type (
 	HTTP struct {
 		Timeout   int `validate:"gte=0"` // In Millisecond
 		SSLVerify bool
+
+		// Sent with every request of this variant to AllowedHosts, never exposed in config
+		Headers  []string // "Name: value", comma separated in env
+		Login    string   // Basic auth
+		Password string
+		Token    string // Bearer token
+
+		// AllowedHosts receiving Headers and authentication, required when they are set
+		AllowedHosts []string // "hostname" or "hostname:port", comma separated in env
 	}
 )
 
 var Default = &HTTP{
-	Timeout:   2000,
-	SSLVerify: true,
+	Timeout:      2000,
+	SSLVerify:    true,
+	Headers:      nil,
+	Login:        "",
+	Password:     "",
+	Token:        "",
+	AllowedHosts: nil,
+}
+
+func (c *HTTP) Validate() []validator.Error {
+	var errors []validator.Error
+
+	for _, header := range c.Headers {
+		if _, _, err := models.ParseHeader(header); err != nil {
+			errors = append(errors, validator.NewDefaultError("Headers", `a comma separated list of "Name: value" headers`))
+			break
+		}
+	}
+
+	if c.Token != "" && c.Login != "" {
+		errors = append(errors, validator.NewDefaultError("Token", "empty when Login is set (basic auth and bearer token can't be used together)"))
+	}
+
+	if c.HasCredentials() && len(c.AllowedHosts) == 0 {
+		errors = append(errors, validator.NewDefaultError("AllowedHosts", "defined when Headers, Login or Token are set"))
+	}
+	for _, host := range c.AllowedHosts {
+		if host = strings.TrimSpace(host); host == "" || strings.Contains(host, "/") {
+			errors = append(errors, validator.NewDefaultError("AllowedHosts", `a comma separated list of "hostname" or "hostname:port"`))
+			break
+		}
+	}
+
+	return errors
+}
+
+// HasCredentials return true when Headers or authentication are sent with requests
+func (c *HTTP) HasCredentials() bool {
+	return len(c.Headers) > 0 || c.Login != "" || c.Token != ""
+}
+
+// IsAllowedHost return true when Headers and authentication can be sent to given url (see AllowedHosts)
+func (c *HTTP) IsAllowedHost(u *url.URL) bool {
+	for _, host := range c.AllowedHosts {
+		host = strings.TrimSpace(host)
+		if strings.EqualFold(host, u.Host) || strings.EqualFold(host, u.Hostname()) {
+			return true
+		}
+	}
+	return false
 }
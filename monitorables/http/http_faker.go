@@ -23,6 +23,7 @@ type Monitorable struct {
 	statusTileEnabler    registry.TileEnabler
 	rawTileEnabler       registry.TileEnabler
 	formattedTileEnabler registry.TileEnabler
+	latencyTileEnabler   registry.TileEnabler
 }
 
 func NewMonitorable(store *store.Store) *Monitorable {
@@ -33,6 +34,7 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.statusTileEnabler = store.Registry.RegisterTile(api.HTTPStatusTileType, versions.MinimalVersion, m.GetVariantsNames())
 	m.rawTileEnabler = store.Registry.RegisterTile(api.HTTPRawTileType, versions.MinimalVersion, m.GetVariantsNames())
 	m.formattedTileEnabler = store.Registry.RegisterTile(api.HTTPFormattedTileType, versions.MinimalVersion, m.GetVariantsNames())
+	m.latencyTileEnabler = store.Registry.RegisterTile(api.HTTPLatencyTileType, versions.MinimalVersion, m.GetVariantsNames())
 
 	return m
 }
@@ -48,9 +50,11 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	routeStatus := routeGroup.GET("/status", delivery.GetHTTPStatus)
 	routeRaw := routeGroup.GET("/raw", delivery.GetHTTPRaw)
 	routeJSON := routeGroup.GET("/formatted", delivery.GetHTTPFormatted)
+	routeLatency := routeGroup.GET("/latency", delivery.GetHTTPLatency)
 
 	// EnableTile data for config hydration
 	m.statusTileEnabler.Enable(variantName, &httpModels.HTTPStatusParams{}, routeStatus.Path)
 	m.rawTileEnabler.Enable(variantName, &httpModels.HTTPRawParams{}, routeRaw.Path)
 	m.formattedTileEnabler.Enable(variantName, &httpModels.HTTPFormattedParams{}, routeJSON.Path)
+	m.latencyTileEnabler.Enable(variantName, &httpModels.HTTPLatencyParams{}, routeLatency.Path)
 }
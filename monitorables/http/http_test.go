@@ -16,6 +16,15 @@ func TestNewMonitorable(t *testing.T) {
 	// init Env
 	// Wrong Timeout
 	_ = os.Setenv("MO_MONITORABLE_HTTP_VARIANT0_TIMEOUT", "-1000")
+	// Basic auth and bearer token
+	_ = os.Setenv("MO_MONITORABLE_HTTP_VARIANT1_LOGIN", "login")
+	_ = os.Setenv("MO_MONITORABLE_HTTP_VARIANT1_TOKEN", "token")
+	_ = os.Setenv("MO_MONITORABLE_HTTP_VARIANT1_ALLOWEDHOSTS", "api.example.com")
+	// Valid headers
+	_ = os.Setenv("MO_MONITORABLE_HTTP_VARIANT2_HEADERS", "X-Api-Key: key,Accept: application/json")
+	_ = os.Setenv("MO_MONITORABLE_HTTP_VARIANT2_ALLOWEDHOSTS", "api.example.com,localhost:8080")
+	// Token without allowed hosts
+	_ = os.Setenv("MO_MONITORABLE_HTTP_VARIANT3_TOKEN", "token")
 
 	// NewMonitorable
 	monitorable := NewMonitorable(store)
@@ -25,9 +34,21 @@ func TestNewMonitorable(t *testing.T) {
 	assert.NotNil(t, monitorable.GetDisplayName())
 
 	// GetVariantsNames and check
-	if assert.Len(t, monitorable.GetVariantsNames(), 2) {
+	if assert.Len(t, monitorable.GetVariantsNames(), 5) {
 		_, errors := monitorable.Validate("variant0")
 		assert.NotEmpty(t, errors)
+		_, errors = monitorable.Validate("variant1")
+		if assert.Len(t, errors, 1) {
+			assert.Contains(t, errors[0].Error(), "MO_MONITORABLE_HTTP_VARIANT1_TOKEN")
+		}
+		valid, _ := monitorable.Validate("variant2")
+		assert.True(t, valid)
+		assert.Equal(t, []string{"X-Api-Key: key", "Accept: application/json"}, monitorable.config["variant2"].Headers)
+		assert.Equal(t, []string{"api.example.com", "localhost:8080"}, monitorable.config["variant2"].AllowedHosts)
+		_, errors = monitorable.Validate("variant3")
+		if assert.Len(t, errors, 1) {
+			assert.Contains(t, errors[0].Error(), "MO_MONITORABLE_HTTP_VARIANT3_ALLOWEDHOSTS")
+		}
 	}
 
 	// Enable
@@ -38,6 +59,6 @@ func TestNewMonitorable(t *testing.T) {
 	}
 
 	// Test calls
-	mockMonitorableHelper.RouterAssertNumberOfCalls(t, 1, 3)
-	mockMonitorableHelper.TileSettingsManagerAssertNumberOfCalls(t, 3, 0, 3, 0)
+	mockMonitorableHelper.RouterAssertNumberOfCalls(t, 2, 8)
+	mockMonitorableHelper.TileSettingsManagerAssertNumberOfCalls(t, 4, 0, 8, 0)
 }
@@ -3,18 +3,20 @@
 package usecase
 
 import (
+	"fmt"
 	"net/url"
 	"regexp"
 	"time"
 
 	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
-	"github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
+	monitorableCache "github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/jenkins/api"
 	"github.com/monitoror/monitoror/monitorables/jenkins/api/models"
 	"github.com/monitoror/monitoror/pkg/git"
 
 	"github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -22,16 +24,16 @@ type (
 		repository api.Repository
 
 		// builds cache. used for save small history of build for stats
-		buildsCache *cache.BuildCache
+		buildsCache *monitorableCache.BuildCache
 	}
 )
 
 const buildCacheSize = 5
 
-func NewJenkinsUsecase(repository api.Repository) api.Usecase {
+func NewJenkinsUsecase(repository api.Repository, store cache.Store, variantName coreModels.VariantName) api.Usecase {
 	return &jenkinsUsecase{
 		repository,
-		cache.NewBuildCache(buildCacheSize),
+		monitorableCache.NewBuildCache(store, fmt.Sprintf("jenkins.%s", variantName), buildCacheSize),
 	}
 }
 
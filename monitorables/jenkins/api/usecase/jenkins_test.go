@@ -13,6 +13,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/git"
 
 	. "github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	. "github.com/stretchr/testify/mock"
 )
@@ -24,7 +25,7 @@ func TestBuild_Error(t *testing.T) {
 	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := tu.Build(&models.BuildParams{Job: job, Branch: branch})
 	if assert.Error(t, err) {
@@ -45,7 +46,7 @@ func TestBuild_DisabledBuild(t *testing.T) {
 	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := tu.Build(&models.BuildParams{Job: job})
 	if assert.NoError(t, err) {
@@ -67,7 +68,7 @@ func TestBuild_Error_NoBuild(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", Anything).
 		Return(nil, errors.New("boom"))
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := tu.Build(&models.BuildParams{Job: job, Branch: branch})
 	if assert.Error(t, err) {
@@ -93,7 +94,7 @@ func CheckBuild(t *testing.T, result string) {
 	mockRepository.On("GetLastBuildStatus", Anything).
 		Return(repositoryBuild, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tUsecase, ok := tu.(*jenkinsUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		expected := coreModels.NewTile(api.JenkinsBuildTileType).WithBuild()
@@ -153,7 +154,7 @@ func TestBuild_Queued(t *testing.T) {
 	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tUsecase, ok := tu.(*jenkinsUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		expected := coreModels.NewTile(api.JenkinsBuildTileType).WithBuild()
@@ -189,7 +190,7 @@ func TestBuild_Running(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", Anything).
 		Return(repositoryBuild, nil)
 
-	ju := NewJenkinsUsecase(mockRepository)
+	ju := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	jUsecase, ok := ju.(*jenkinsUsecase)
 	if assert.True(t, ok, "enable to case ju into jenkinsUsecase") {
 		// Without cached build
@@ -239,7 +240,7 @@ func TestBuildGenerator_Success(t *testing.T) {
 	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tiles, err := tu.BuildGenerator(&models.BuildGeneratorParams{Job: job})
 	if assert.NoError(t, err) {
@@ -280,7 +281,7 @@ func TestBuildGenerator_Error(t *testing.T) {
 	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	_, err := tu.BuildGenerator(&models.BuildGeneratorParams{Job: "test"})
 	assert.Error(t, err)
@@ -295,7 +296,7 @@ func TestBuildGenerator_ErrorWithRegex(t *testing.T) {
 	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	_, err := tu.BuildGenerator(&models.BuildGeneratorParams{Job: "test", Match: "("})
 	assert.Error(t, err)
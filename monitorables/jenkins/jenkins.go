@@ -69,7 +69,7 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	conf := m.config[variantName]
 
 	repository := jenkinsRepository.NewJenkinsRepository(conf)
-	usecase := jenkinsUsecase.NewJenkinsUsecase(repository)
+	usecase := jenkinsUsecase.NewJenkinsUsecase(repository, m.store.CacheStore, variantName)
 	delivery := jenkinsDelivery.NewJenkinsDelivery(usecase)
 
 	// EnableTile route to echo
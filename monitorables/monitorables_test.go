@@ -13,6 +13,7 @@ import (
 	pingApi "github.com/monitoror/monitoror/monitorables/ping/api"
 	pingdomApi "github.com/monitoror/monitoror/monitorables/pingdom/api"
 	portApi "github.com/monitoror/monitoror/monitorables/port/api"
+	tlsApi "github.com/monitoror/monitoror/monitorables/tls/api"
 	travisCIApi "github.com/monitoror/monitoror/monitorables/travisci/api"
 	"github.com/monitoror/monitoror/registry"
 
@@ -44,6 +45,7 @@ func TestManager_RegisterMonitorables(t *testing.T) {
 	assert.NotNil(t, mr.TileMetadata[httpApi.HTTPStatusTileType])
 	assert.NotNil(t, mr.TileMetadata[httpApi.HTTPRawTileType])
 	assert.NotNil(t, mr.TileMetadata[httpApi.HTTPFormattedTileType])
+	assert.NotNil(t, mr.TileMetadata[httpApi.HTTPLatencyTileType])
 	// ------------ JENKINS ------------
 	assert.NotNil(t, mr.TileMetadata[jenkinsApi.JenkinsBuildTileType])
 	assert.NotNil(t, mr.GeneratorMetadata[coreModels.NewGeneratorTileType(jenkinsApi.JenkinsBuildTileType)])
@@ -56,6 +58,9 @@ func TestManager_RegisterMonitorables(t *testing.T) {
 	assert.NotNil(t, mr.GeneratorMetadata[coreModels.NewGeneratorTileType(pingdomApi.PingdomTransactionCheckTileType)])
 	// ------------ PORT ------------
 	assert.NotNil(t, mr.TileMetadata[portApi.PortTileType])
+	// ------------ TLS ------------
+	assert.NotNil(t, mr.TileMetadata[tlsApi.TLSCertificateTileType])
+	assert.NotNil(t, mr.GeneratorMetadata[coreModels.NewGeneratorTileType(tlsApi.TLSCertificateTileType)])
 	// ------------ TRAVIS CI ------------
 	assert.NotNil(t, mr.TileMetadata[travisCIApi.TravisCIBuildTileType])
 }
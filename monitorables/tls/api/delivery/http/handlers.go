@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/monitoror/monitoror/internal/pkg/monitorable/delivery"
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	"github.com/monitoror/monitoror/monitorables/tls/api/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type TLSDelivery struct {
+	tlsUsecase api.Usecase
+}
+
+func NewTLSDelivery(p api.Usecase) *TLSDelivery {
+	return &TLSDelivery{p}
+}
+
+func (h *TLSDelivery) GetCertificate(c echo.Context) error {
+	// Bind / check Params
+	params := &models.CertificateParams{}
+	if err := delivery.BindAndValidateParams(c, params); err != nil {
+		return err
+	}
+
+	tile, err := h.tlsUsecase.Certificate(params)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tile)
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	"github.com/monitoror/monitoror/monitorables/tls/api/mocks"
+	"github.com/monitoror/monitoror/monitorables/tls/api/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func initEcho() (ctx echo.Context, res *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(echo.GET, "/api/v1/info", nil)
+	res = httptest.NewRecorder()
+	ctx = e.NewContext(req, res)
+
+	ctx.QueryParams().Set("hostname", "monitoror.example.com")
+	ctx.QueryParams().Set("port", "8443")
+
+	return
+}
+
+func TestDelivery_CertificateHandler_Success(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+
+	tile := coreModels.NewTile(api.TLSCertificateTileType)
+	tile.Label = "monitoror.example.com:8443"
+	tile.Status = coreModels.SuccessStatus
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Certificate", &models.CertificateParams{Hostname: "monitoror.example.com", Port: 8443}).Return(tile, nil)
+	handler := NewTLSDelivery(mockUsecase)
+
+	// Expected
+	json, err := json.Marshal(tile)
+	assert.NoError(t, err, "unable to marshal tile")
+
+	// Test
+	if assert.NoError(t, handler.GetCertificate(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, string(json), strings.TrimSpace(res.Body.String()))
+		mockUsecase.AssertNumberOfCalls(t, "Certificate", 1)
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
+func TestDelivery_CertificateHandler_QueryParamsError_MissingHostname(t *testing.T) {
+	// Init
+	ctx, _ := initEcho()
+	ctx.QueryParams().Del("hostname")
+	mockUsecase := new(mocks.Usecase)
+	handler := NewTLSDelivery(mockUsecase)
+
+	// Test
+	err := handler.GetCertificate(ctx)
+	assert.Error(t, err)
+	assert.IsType(t, &coreModels.MonitororError{}, err)
+}
+
+func TestDelivery_CertificateHandler_Error(t *testing.T) {
+	// Init
+	ctx, _ := initEcho()
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Certificate", Anything).Return(nil, errors.New("certificate error"))
+	handler := NewTLSDelivery(mockUsecase)
+
+	// Test
+	assert.Error(t, handler.GetCertificate(ctx))
+	mockUsecase.AssertNumberOfCalls(t, "Certificate", 1)
+	mockUsecase.AssertExpectations(t)
+}
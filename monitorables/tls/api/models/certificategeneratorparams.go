@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/monitoror/monitoror/internal/pkg/validator"
+)
+
+type (
+	CertificateGeneratorParams struct {
+		Hosts []string `json:"hosts" query:"hosts" validate:"required,notempty"` // "hostname" or "hostname:port"
+
+		// Thresholds of generated tiles
+		WarningDays *int `json:"warningDays,omitempty" query:"warningDays" validate:"omitempty,gte=0"`
+		FailureDays *int `json:"failureDays,omitempty" query:"failureDays" validate:"omitempty,gte=0"`
+	}
+)
+
+func (p *CertificateGeneratorParams) Validate() []validator.Error {
+	for _, host := range p.Hosts {
+		if _, _, err := ParseHost(host); err != nil {
+			return []validator.Error{validator.NewDefaultError("Hosts", `a list of "hostname" or "hostname:port"`)}
+		}
+	}
+
+	return validateThresholds(p)
+}
+
+func (p *CertificateGeneratorParams) GetThresholds() (warningDays int, failureDays int) {
+	return getThresholdsWithDefault(p.WarningDays, p.FailureDays)
+}
@@ -0,0 +1,41 @@
+//+build !faker
+
+package models
+
+import (
+	"github.com/monitoror/monitoror/internal/pkg/validator"
+)
+
+type (
+	CertificateParams struct {
+		Hostname   string `json:"hostname" query:"hostname" validate:"required"`
+		Port       int    `json:"port,omitempty" query:"port" validate:"omitempty,gt=0,lte=65535"`
+		ServerName string `json:"serverName,omitempty" query:"serverName"` // SNI, hostname by default
+
+		// Status is WARNING / FAILURE when certificate expire in less than these number of days
+		WarningDays *int `json:"warningDays,omitempty" query:"warningDays" validate:"omitempty,gte=0"`
+		FailureDays *int `json:"failureDays,omitempty" query:"failureDays" validate:"omitempty,gte=0"`
+	}
+)
+
+func (p *CertificateParams) Validate() []validator.Error {
+	return validateThresholds(p)
+}
+
+func (p *CertificateParams) GetPort() int {
+	if p.Port == 0 {
+		return DefaultPort
+	}
+	return p.Port
+}
+
+func (p *CertificateParams) GetServerName() string {
+	if p.ServerName == "" {
+		return p.Hostname
+	}
+	return p.ServerName
+}
+
+func (p *CertificateParams) GetThresholds() (warningDays int, failureDays int) {
+	return getThresholdsWithDefault(p.WarningDays, p.FailureDays)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/monitoror/monitoror/internal/pkg/validator"
+)
+
+type (
+	ThresholdParamsProvider interface {
+		GetThresholds() (warningDays int, failureDays int)
+	}
+)
+
+const (
+	DefaultPort        = 443
+	DefaultWarningDays = 30
+	DefaultFailureDays = 7
+)
+
+func validateThresholds(params ThresholdParamsProvider) []validator.Error {
+	if warningDays, failureDays := params.GetThresholds(); warningDays <= failureDays {
+		return []validator.Error{validator.NewDefaultError("WarningDays", "warningDays > failureDays")}
+	}
+
+	return nil
+}
+
+func getThresholdsWithDefault(warningDays, failureDays *int) (warning int, failure int) {
+	warning = DefaultWarningDays
+	if warningDays != nil {
+		warning = *warningDays
+	}
+	failure = DefaultFailureDays
+	if failureDays != nil {
+		failure = *failureDays
+	}
+	return
+}
+
+// ParseHost split "hostname" or "hostname:port" host, port is 443 by default
+func ParseHost(host string) (hostname string, port int, err error) {
+	hostname, rawPort, err := net.SplitHostPort(host)
+	if err != nil {
+		// Without port
+		if _, _, err := net.SplitHostPort(host + ":0"); err != nil || host == "" {
+			return "", 0, fmt.Errorf(`invalid %q host, expected "hostname" or "hostname:port"`, host)
+		}
+		return host, DefaultPort, nil
+	}
+
+	port, err = strconv.Atoi(rawPort)
+	if err != nil || port <= 0 || port > 65535 || hostname == "" {
+		return "", 0, fmt.Errorf(`invalid %q host, expected "hostname" or "hostname:port"`, host)
+	}
+
+	return hostname, port, nil
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/monitoror/monitoror/internal/pkg/monitorable/params"
+	"github.com/monitoror/monitoror/internal/pkg/monitorable/test"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTLSParams(t *testing.T) {
+	for _, testcase := range []struct {
+		params     params.Validator
+		errorCount int
+	}{
+		{&CertificateParams{}, 1},
+		{&CertificateParams{Hostname: "example.com"}, 0},
+		{&CertificateParams{Hostname: "example.com", Port: 8443, ServerName: "www.example.com"}, 0},
+		{&CertificateParams{Hostname: "example.com", Port: 70000}, 1},
+		{&CertificateParams{Hostname: "example.com", WarningDays: pointer.ToInt(-1), FailureDays: pointer.ToInt(-2)}, 2},
+		{&CertificateParams{Hostname: "example.com", WarningDays: pointer.ToInt(7)}, 1},
+		{&CertificateParams{Hostname: "example.com", WarningDays: pointer.ToInt(14), FailureDays: pointer.ToInt(3)}, 0},
+
+		{&CertificateGeneratorParams{}, 1},
+		{&CertificateGeneratorParams{Hosts: []string{}}, 1},
+		{&CertificateGeneratorParams{Hosts: []string{"example.com", "example.com:8443", "[::1]:443"}}, 0},
+		{&CertificateGeneratorParams{Hosts: []string{"example.com:port"}}, 1},
+		{&CertificateGeneratorParams{Hosts: []string{"example.com"}, FailureDays: pointer.ToInt(60)}, 1},
+	} {
+		test.AssertParams(t, testcase.params, testcase.errorCount)
+	}
+}
+
+func TestCertificateParams_Defaults(t *testing.T) {
+	p := &CertificateParams{Hostname: "example.com"}
+	assert.Equal(t, DefaultPort, p.GetPort())
+	assert.Equal(t, "example.com", p.GetServerName())
+	warningDays, failureDays := p.GetThresholds()
+	assert.Equal(t, DefaultWarningDays, warningDays)
+	assert.Equal(t, DefaultFailureDays, failureDays)
+
+	p = &CertificateParams{Hostname: "10.0.0.1", Port: 8443, ServerName: "example.com", WarningDays: pointer.ToInt(10), FailureDays: pointer.ToInt(0)}
+	assert.Equal(t, 8443, p.GetPort())
+	assert.Equal(t, "example.com", p.GetServerName())
+	warningDays, failureDays = p.GetThresholds()
+	assert.Equal(t, 10, warningDays)
+	assert.Equal(t, 0, failureDays)
+}
+
+func TestParseHost(t *testing.T) {
+	for host, expected := range map[string]struct {
+		hostname string
+		port     int
+	}{
+		"example.com":      {"example.com", 443},
+		"example.com:8443": {"example.com", 8443},
+		"10.0.0.1":         {"10.0.0.1", 443},
+		"[::1]:8443":       {"::1", 8443},
+	} {
+		hostname, port, err := ParseHost(host)
+		if assert.NoError(t, err, host) {
+			assert.Equal(t, expected.hostname, hostname)
+			assert.Equal(t, expected.port, port)
+		}
+	}
+
+	for _, host := range []string{"", ":443", "example.com:", "example.com:0", "example.com:https", "a b:c:d"} {
+		_, _, err := ParseHost(host)
+		assert.Error(t, err, host)
+	}
+}
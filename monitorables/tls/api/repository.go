@@ -0,0 +1,14 @@
+//go:generate mockery -name Repository
+
+package api
+
+import (
+	"crypto/x509"
+)
+
+type (
+	Repository interface {
+		// GetCertificates return certificate chain presented by server, without verifying it
+		GetCertificates(hostname string, port int, serverName string) ([]*x509.Certificate, error)
+	}
+)
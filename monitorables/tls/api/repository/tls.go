@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	"github.com/monitoror/monitoror/monitorables/tls/config"
+)
+
+type (
+	tlsRepository struct {
+		config *config.TLS
+		dialer *net.Dialer
+	}
+)
+
+func NewTLSRepository(conf *config.TLS) api.Repository {
+	timeout := time.Millisecond * time.Duration(conf.Timeout)
+	return &tlsRepository{conf, &net.Dialer{Timeout: timeout}}
+}
+
+func (r *tlsRepository) GetCertificates(hostname string, port int, serverName string) ([]*x509.Certificate, error) {
+	target := net.JoinHostPort(hostname, strconv.Itoa(port))
+
+	// Chain is verified by usecase, to report expired / untrusted certificates instead of handshake error
+	conn, err := tls.DialWithDialer(r.dialer, "tcp", target, &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: true, //nolint:gosec
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return conn.ConnectionState().PeerCertificates, nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/monitoror/monitoror/monitorables/tls/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository_GetCertificates_Success(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	u, _ := url.Parse(ts.URL)
+	hostname, rawPort, _ := net.SplitHostPort(u.Host)
+	port, _ := strconv.Atoi(rawPort)
+
+	repository := NewTLSRepository(&config.TLS{Timeout: 1000})
+	certificates, err := repository.GetCertificates(hostname, port, "example.com")
+	if assert.NoError(t, err) && assert.Len(t, certificates, 1) {
+		assert.Equal(t, ts.Certificate().Raw, certificates[0].Raw)
+	}
+}
+
+func TestRepository_GetCertificates_Failed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	repository := NewTLSRepository(&config.TLS{Timeout: 1000})
+	_, err = repository.GetCertificates("127.0.0.1", port, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,20 @@
+//go:generate mockery -name Usecase
+
+package api
+
+import (
+	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api/models"
+)
+
+const (
+	TLSCertificateTileType coreModels.TileType = "TLS-CERTIFICATE"
+)
+
+type (
+	Usecase interface {
+		Certificate(params *models.CertificateParams) (*coreModels.Tile, error)
+		CertificateGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error)
+	}
+)
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api/models"
+)
+
+// generateCertificateTiles return one tile by host, with generator thresholds
+func generateCertificateTiles(params *models.CertificateGeneratorParams) ([]uiConfigModels.GeneratedTile, error) {
+	var results []uiConfigModels.GeneratedTile
+	for _, host := range params.Hosts {
+		hostname, port, err := models.ParseHost(host)
+		if err != nil {
+			return nil, err
+		}
+
+		p := &models.CertificateParams{}
+		p.Hostname = hostname
+		if port != models.DefaultPort {
+			p.Port = port
+		}
+		p.WarningDays = params.WarningDays
+		p.FailureDays = params.FailureDays
+
+		results = append(results, uiConfigModels.GeneratedTile{
+			Params: p,
+		})
+	}
+
+	return results, nil
+}
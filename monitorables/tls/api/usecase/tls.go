@@ -0,0 +1,146 @@
+//+build !faker
+
+package usecase
+
+import (
+	"crypto/x509"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	"github.com/monitoror/monitoror/monitorables/tls/api/models"
+)
+
+type (
+	tlsUsecase struct {
+		repository api.Repository
+
+		// roots used to verify chain, system roots when nil
+		roots *x509.CertPool
+		now   func() time.Time
+	}
+)
+
+func NewTLSUsecase(repository api.Repository) api.Usecase {
+	return &tlsUsecase{repository: repository, now: time.Now}
+}
+
+// Certificate return days until expiration of certificate chain presented by host.
+// Status is FAILURE when chain is expired, untrusted or doesn't match server name, WARNING when it expires soon
+func (tu *tlsUsecase) Certificate(params *models.CertificateParams) (*coreModels.Tile, error) {
+	tile := coreModels.NewTile(api.TLSCertificateTileType)
+	tile.Label = net.JoinHostPort(params.Hostname, strconv.Itoa(params.GetPort()))
+	tile.Status = coreModels.SuccessStatus
+
+	// Timeouts and unreachable hosts are handled by error handler (previous status is used when cached)
+	certificates, err := tu.repository.GetCertificates(params.Hostname, params.GetPort(), params.GetServerName())
+	if err != nil {
+		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: fmt.Sprintf("unable to get certificate, %v", err)}
+	}
+	if len(certificates) == 0 {
+		return nil, &coreModels.MonitororError{Tile: tile, Message: "no certificate presented by host"}
+	}
+
+	now := tu.now()
+	leaf := certificates[0]
+
+	// An expired intermediate breaks the chain too, the first expiration of verified chain is used.
+	// Extra certificates sent by host (like expired cross-signed roots) aren't used by clients, they are ignored
+	chain := tu.verifiedChain(certificates, now)
+	notAfter := leaf.NotAfter
+	for _, certificate := range chain {
+		if certificate.NotAfter.Before(notAfter) {
+			notAfter = certificate.NotAfter
+		}
+	}
+	days := int(math.Floor(notAfter.Sub(now).Hours() / 24))
+
+	tile.WithMetrics(coreModels.NumberUnit)
+	tile.Metrics.Values = []string{strconv.Itoa(days)}
+
+	var messages []string
+	warningDays, failureDays := params.GetThresholds()
+	switch {
+	case now.After(notAfter):
+		tile.Status = coreModels.FailedStatus
+		messages = append(messages, fmt.Sprintf("expired since %s", notAfter.Format("2006-01-02")))
+	case now.Before(leaf.NotBefore):
+		tile.Status = coreModels.FailedStatus
+		messages = append(messages, fmt.Sprintf("not valid before %s", leaf.NotBefore.Format("2006-01-02")))
+	case days < failureDays:
+		tile.Status = coreModels.FailedStatus
+		messages = append(messages, fmt.Sprintf("expires in %d days", days))
+	case days < warningDays:
+		tile.Status = coreModels.WarningStatus
+		messages = append(messages, fmt.Sprintf("expires in %d days", days))
+	}
+
+	if err := leaf.VerifyHostname(params.GetServerName()); err != nil {
+		tile.Status = coreModels.FailedStatus
+		messages = append(messages, fmt.Sprintf("hostname mismatch, valid for %s", strings.Join(certificateNames(leaf), ", ")))
+	}
+
+	if chain == nil {
+		tile.Status = coreModels.FailedStatus
+		messages = append(messages, "untrusted certificate chain")
+	}
+
+	tile.Message = strings.Join(messages, ", ")
+
+	return tile, nil
+}
+
+func (tu *tlsUsecase) CertificateGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+	return generateCertificateTiles(params.(*models.CertificateGeneratorParams))
+}
+
+// verifiedChain return chain verified from leaf to root (without hostname, checked separately), nil when untrusted.
+// Validity period is already reported, so an expired chain is verified again at a time when every certificate is valid
+func (tu *tlsUsecase) verifiedChain(certificates []*x509.Certificate, now time.Time) []*x509.Certificate {
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	options := x509.VerifyOptions{
+		Roots:         tu.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	}
+	if chains, err := certificates[0].Verify(options); err == nil {
+		return chains[0]
+	}
+
+	for _, certificate := range certificates {
+		if certificate.NotAfter.Before(options.CurrentTime) {
+			options.CurrentTime = certificate.NotAfter
+		}
+	}
+	for _, certificate := range certificates {
+		if certificate.NotBefore.After(options.CurrentTime) {
+			options.CurrentTime = certificate.NotBefore
+		}
+	}
+	if chains, err := certificates[0].Verify(options); err == nil {
+		return chains[0]
+	}
+
+	return nil
+}
+
+func certificateNames(certificate *x509.Certificate) []string {
+	names := append([]string{}, certificate.DNSNames...)
+	for _, ip := range certificate.IPAddresses {
+		names = append(names, ip.String())
+	}
+	if len(names) == 0 && certificate.Subject.CommonName != "" {
+		names = append(names, certificate.Subject.CommonName)
+	}
+	return names
+}
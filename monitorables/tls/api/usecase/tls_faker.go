@@ -0,0 +1,72 @@
+//+build faker
+
+package usecase
+
+import (
+	"math/rand"
+	"net"
+	"strconv"
+	"time"
+
+	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/internal/pkg/monitorable/faker"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	"github.com/monitoror/monitoror/monitorables/tls/api/models"
+	"github.com/monitoror/monitoror/pkg/nonempty"
+)
+
+type (
+	tlsUsecase struct {
+		timeRefByHost map[string]time.Time
+	}
+)
+
+var availableStatuses = faker.Statuses{
+	{coreModels.SuccessStatus, time.Second * 60},
+	{coreModels.WarningStatus, time.Second * 20},
+	{coreModels.FailedStatus, time.Second * 20},
+}
+
+func NewTLSUsecase() api.Usecase {
+	return &tlsUsecase{make(map[string]time.Time)}
+}
+
+func (tu *tlsUsecase) Certificate(params *models.CertificateParams) (tile *coreModels.Tile, err error) {
+	tile = coreModels.NewTile(api.TLSCertificateTileType)
+	tile.Label = net.JoinHostPort(params.Hostname, strconv.Itoa(params.GetPort()))
+
+	tile.Status = nonempty.Struct(params.Status, tu.computeStatus(tile.Label)).(coreModels.TileStatus)
+
+	// Days until expiration, consistent with status
+	warningDays, failureDays := params.GetThresholds()
+	days := warningDays + rand.Intn(300)
+	switch tile.Status {
+	case coreModels.WarningStatus:
+		days = failureDays + rand.Intn(warningDays-failureDays)
+	case coreModels.FailedStatus:
+		days = rand.Intn(failureDays + 1)
+		tile.Message = nonempty.String(params.Message, "Fake error message")
+	}
+
+	tile.WithMetrics(coreModels.NumberUnit)
+	tile.Metrics.Values = []string{strconv.Itoa(days)}
+	if len(params.ValueValues) != 0 {
+		tile.Metrics.Values = params.ValueValues
+	}
+
+	return
+}
+
+func (tu *tlsUsecase) CertificateGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+	return generateCertificateTiles(params.(*models.CertificateGeneratorParams))
+}
+
+func (tu *tlsUsecase) computeStatus(host string) coreModels.TileStatus {
+	value, ok := tu.timeRefByHost[host]
+	if !ok {
+		tu.timeRefByHost[host] = faker.GetRefTime()
+	}
+
+	return faker.ComputeStatus(value, availableStatuses)
+}
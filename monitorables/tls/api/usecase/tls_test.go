@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	"github.com/monitoror/monitoror/monitorables/tls/api/mocks"
+	"github.com/monitoror/monitoror/monitorables/tls/api/models"
+
+	"github.com/AlekSi/pointer"
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+var now = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// newCertificate create certificate signed by parent (self-signed when parent is nil)
+func newCertificate(t *testing.T, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, notAfter time.Time, dnsNames ...string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "test"},
+		NotBefore:             now.AddDate(-1, 0, 0),
+		NotAfter:              notAfter,
+		DNSNames:              dnsNames,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	certificate, _ := x509.ParseCertificate(der)
+
+	return certificate, key
+}
+
+func TestUsecase_Certificate(t *testing.T) {
+	ca, caKey := newCertificate(t, nil, nil, now.AddDate(5, 0, 0))
+	otherCA, otherCAKey := newCertificate(t, nil, nil, now.AddDate(5, 0, 0))
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	valid, _ := newCertificate(t, ca, caKey, now.AddDate(0, 0, 90), "example.com")
+	expiringSoon, _ := newCertificate(t, ca, caKey, now.AddDate(0, 0, 20), "example.com")
+	expiringVerySoon, _ := newCertificate(t, ca, caKey, now.AddDate(0, 0, 3).Add(time.Hour), "example.com")
+	expired, _ := newCertificate(t, ca, caKey, now.AddDate(0, 0, -2), "example.com")
+	otherHost, _ := newCertificate(t, ca, caKey, now.AddDate(0, 0, 90), "other.com", "www.other.com")
+	untrusted, _ := newCertificate(t, otherCA, otherCAKey, now.AddDate(0, 0, 90), "example.com")
+	expiredCrossSigned, _ := newCertificate(t, otherCA, otherCAKey, now.AddDate(0, 0, -10))
+
+	for _, testcase := range []struct {
+		params          *models.CertificateParams
+		certificates    []*x509.Certificate
+		expectedStatus  coreModels.TileStatus
+		expectedDays    string
+		expectedMessage string
+	}{
+		{
+			params:       &models.CertificateParams{Hostname: "example.com"},
+			certificates: []*x509.Certificate{valid, ca}, expectedStatus: coreModels.SuccessStatus, expectedDays: "90",
+		},
+		{
+			// Extra expired certificate, not on verified chain
+			params:       &models.CertificateParams{Hostname: "example.com"},
+			certificates: []*x509.Certificate{valid, ca, expiredCrossSigned}, expectedStatus: coreModels.SuccessStatus, expectedDays: "90",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "example.com"},
+			certificates: []*x509.Certificate{expiringSoon}, expectedStatus: coreModels.WarningStatus, expectedDays: "20",
+			expectedMessage: "expires in 20 days",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "example.com", WarningDays: pointer.ToInt(15)},
+			certificates: []*x509.Certificate{expiringSoon}, expectedStatus: coreModels.SuccessStatus, expectedDays: "20",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "example.com"},
+			certificates: []*x509.Certificate{expiringVerySoon}, expectedStatus: coreModels.FailedStatus, expectedDays: "3",
+			expectedMessage: "expires in 3 days",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "example.com"},
+			certificates: []*x509.Certificate{expired}, expectedStatus: coreModels.FailedStatus, expectedDays: "-2",
+			expectedMessage: "expired since 2020-05-30",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "example.com"},
+			certificates: []*x509.Certificate{otherHost}, expectedStatus: coreModels.FailedStatus, expectedDays: "90",
+			expectedMessage: "hostname mismatch, valid for other.com, www.other.com",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "10.0.0.1", ServerName: "other.com"},
+			certificates: []*x509.Certificate{otherHost}, expectedStatus: coreModels.SuccessStatus, expectedDays: "90",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "example.com"},
+			certificates: []*x509.Certificate{untrusted, otherCA}, expectedStatus: coreModels.FailedStatus, expectedDays: "90",
+			expectedMessage: "untrusted certificate chain",
+		},
+		{
+			params:       &models.CertificateParams{Hostname: "other.com"},
+			certificates: []*x509.Certificate{expiringSoon}, expectedStatus: coreModels.FailedStatus, expectedDays: "20",
+			expectedMessage: "expires in 20 days, hostname mismatch, valid for example.com",
+		},
+	} {
+		mockRepository := new(mocks.Repository)
+		mockRepository.On("GetCertificates", testcase.params.Hostname, 443, testcase.params.GetServerName()).Return(testcase.certificates, nil)
+
+		usecase := NewTLSUsecase(mockRepository).(*tlsUsecase)
+		usecase.roots = roots
+		usecase.now = func() time.Time { return now }
+
+		tile, err := usecase.Certificate(testcase.params)
+		if assert.NoError(t, err) {
+			assert.Equal(t, api.TLSCertificateTileType, tile.Type)
+			assert.Equal(t, testcase.params.Hostname+":443", tile.Label)
+			assert.Equal(t, testcase.expectedStatus, tile.Status, testcase.expectedMessage)
+			assert.Equal(t, testcase.expectedMessage, tile.Message)
+			assert.Equal(t, coreModels.NumberUnit, tile.Metrics.Unit)
+			assert.Equal(t, []string{testcase.expectedDays}, tile.Metrics.Values)
+			mockRepository.AssertExpectations(t)
+		}
+	}
+}
+
+func TestUsecase_Certificate_Error(t *testing.T) {
+	mockRepository := new(mocks.Repository)
+	mockRepository.On("GetCertificates", AnythingOfType("string"), AnythingOfType("int"), AnythingOfType("string")).Return(nil, errors.New("boom"))
+
+	tile, err := NewTLSUsecase(mockRepository).Certificate(&models.CertificateParams{Hostname: "example.com", Port: 8443})
+	assert.Nil(t, tile)
+	if assert.IsType(t, &coreModels.MonitororError{}, err) {
+		monitororError := err.(*coreModels.MonitororError)
+		assert.Equal(t, "boom", monitororError.Err.Error())
+		assert.Equal(t, "unable to get certificate, boom", monitororError.Error())
+		assert.Equal(t, "example.com:8443", monitororError.Tile.Label)
+		mockRepository.AssertExpectations(t)
+	}
+
+	// No certificate
+	mockRepository = new(mocks.Repository)
+	mockRepository.On("GetCertificates", AnythingOfType("string"), AnythingOfType("int"), AnythingOfType("string")).Return([]*x509.Certificate{}, nil)
+
+	tile, err = NewTLSUsecase(mockRepository).Certificate(&models.CertificateParams{Hostname: "example.com"})
+	assert.Nil(t, tile)
+	if assert.IsType(t, &coreModels.MonitororError{}, err) {
+		assert.Equal(t, "no certificate presented by host", err.Error())
+		mockRepository.AssertExpectations(t)
+	}
+}
+
+func TestUsecase_CertificateGenerator(t *testing.T) {
+	usecase := NewTLSUsecase(nil)
+
+	tiles, err := usecase.CertificateGenerator(&models.CertificateGeneratorParams{
+		Hosts:       []string{"example.com", "example.com:8443"},
+		WarningDays: pointer.ToInt(14),
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []uiConfigModels.GeneratedTile{
+			{Params: &models.CertificateParams{Hostname: "example.com", WarningDays: pointer.ToInt(14)}},
+			{Params: &models.CertificateParams{Hostname: "example.com", Port: 8443, WarningDays: pointer.ToInt(14)}},
+		}, tiles)
+	}
+
+	_, err = usecase.CertificateGenerator(&models.CertificateGeneratorParams{Hosts: []string{"example.com:port"}})
+	assert.Error(t, err)
+}
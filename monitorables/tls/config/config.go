@@ -0,0 +1,11 @@
+package config
+
+type (
+	TLS struct {
+		Timeout int `validate:"gte=0"` // In Millisecond
+	}
+)
+
+var Default = &TLS{
+	Timeout: 2000,
+}
@@ -0,0 +1,77 @@
+//+build !faker
+
+package tls
+
+import (
+	"github.com/monitoror/monitoror/api/config/versions"
+	pkgMonitorable "github.com/monitoror/monitoror/internal/pkg/monitorable"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	tlsDelivery "github.com/monitoror/monitoror/monitorables/tls/api/delivery/http"
+	tlsModels "github.com/monitoror/monitoror/monitorables/tls/api/models"
+	tlsRepository "github.com/monitoror/monitoror/monitorables/tls/api/repository"
+	tlsUsecase "github.com/monitoror/monitoror/monitorables/tls/api/usecase"
+	tlsConfig "github.com/monitoror/monitoror/monitorables/tls/config"
+	"github.com/monitoror/monitoror/registry"
+	"github.com/monitoror/monitoror/store"
+)
+
+type Monitorable struct {
+	store *store.Store
+
+	config map[coreModels.VariantName]*tlsConfig.TLS
+
+	// Config tile settings
+	certificateTileEnabler      registry.TileEnabler
+	certificateGeneratorEnabler registry.GeneratorEnabler
+}
+
+func NewMonitorable(store *store.Store) *Monitorable {
+	m := &Monitorable{}
+	m.store = store
+	m.config = make(map[coreModels.VariantName]*tlsConfig.TLS)
+
+	// Load core config from env
+	pkgMonitorable.LoadConfig(&m.config, tlsConfig.Default)
+
+	// Register Monitorable Tile in config manager
+	m.certificateTileEnabler = store.Registry.RegisterTile(api.TLSCertificateTileType, versions.MinimalVersion, m.GetVariantsNames())
+	m.certificateGeneratorEnabler = store.Registry.RegisterGenerator(api.TLSCertificateTileType, versions.MinimalVersion, m.GetVariantsNames())
+
+	return m
+}
+
+func (m *Monitorable) GetDisplayName() string {
+	return "TLS"
+}
+
+func (m *Monitorable) GetVariantsNames() []coreModels.VariantName {
+	return pkgMonitorable.GetVariantsNames(m.config)
+}
+
+func (m *Monitorable) Validate(variantName coreModels.VariantName) (bool, []error) {
+	conf := m.config[variantName]
+
+	// Validate Config
+	if errors := pkgMonitorable.ValidateConfig(conf, variantName); errors != nil {
+		return false, errors
+	}
+
+	return true, nil
+}
+
+func (m *Monitorable) Enable(variantName coreModels.VariantName) {
+	conf := m.config[variantName]
+
+	repository := tlsRepository.NewTLSRepository(conf)
+	usecase := tlsUsecase.NewTLSUsecase(repository)
+	delivery := tlsDelivery.NewTLSDelivery(usecase)
+
+	// EnableTile route to echo
+	routeGroup := m.store.MonitorableRouter.Group("/tls", variantName)
+	route := routeGroup.GET("/certificate", delivery.GetCertificate)
+
+	// EnableTile data for config hydration
+	m.certificateTileEnabler.Enable(variantName, &tlsModels.CertificateParams{}, route.Path)
+	m.certificateGeneratorEnabler.Enable(variantName, &tlsModels.CertificateGeneratorParams{}, usecase.CertificateGenerator)
+}
@@ -0,0 +1,51 @@
+//+build faker
+
+package tls
+
+import (
+	"github.com/monitoror/monitoror/api/config/versions"
+	"github.com/monitoror/monitoror/internal/pkg/monitorable"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables/tls/api"
+	tlsDelivery "github.com/monitoror/monitoror/monitorables/tls/api/delivery/http"
+	tlsModels "github.com/monitoror/monitoror/monitorables/tls/api/models"
+	tlsUsecase "github.com/monitoror/monitoror/monitorables/tls/api/usecase"
+	"github.com/monitoror/monitoror/registry"
+	"github.com/monitoror/monitoror/store"
+)
+
+type Monitorable struct {
+	monitorable.DefaultMonitorableFaker
+
+	store *store.Store
+
+	// Config tile settings
+	certificateTileEnabler      registry.TileEnabler
+	certificateGeneratorEnabler registry.GeneratorEnabler
+}
+
+func NewMonitorable(store *store.Store) *Monitorable {
+	m := &Monitorable{}
+	m.store = store
+
+	// Register Monitorable Tile in config manager
+	m.certificateTileEnabler = store.Registry.RegisterTile(api.TLSCertificateTileType, versions.MinimalVersion, m.GetVariantsNames())
+	m.certificateGeneratorEnabler = store.Registry.RegisterGenerator(api.TLSCertificateTileType, versions.MinimalVersion, m.GetVariantsNames())
+
+	return m
+}
+
+func (m *Monitorable) GetDisplayName() string { return "TLS" }
+
+func (m *Monitorable) Enable(variantName coreModels.VariantName) {
+	usecase := tlsUsecase.NewTLSUsecase()
+	delivery := tlsDelivery.NewTLSDelivery(usecase)
+
+	// EnableTile route to echo
+	routeGroup := m.store.MonitorableRouter.Group("/tls", variantName)
+	route := routeGroup.GET("/certificate", delivery.GetCertificate)
+
+	// EnableTile data for config hydration
+	m.certificateTileEnabler.Enable(variantName, &tlsModels.CertificateParams{}, route.Path)
+	m.certificateGeneratorEnabler.Enable(variantName, &tlsModels.CertificateGeneratorParams{}, usecase.CertificateGenerator)
+}
@@ -6,13 +6,14 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
+	monitorableCache "github.com/monitoror/monitoror/internal/pkg/monitorable/cache"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/travisci/api"
 	"github.com/monitoror/monitoror/monitorables/travisci/api/models"
 	"github.com/monitoror/monitoror/pkg/git"
 
 	"github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -20,14 +21,14 @@ type (
 		repository api.Repository
 
 		// builds cache
-		buildsCache *cache.BuildCache
+		buildsCache *monitorableCache.BuildCache
 	}
 )
 
 const cacheSize = 5
 
-func NewTravisCIUsecase(repository api.Repository) api.Usecase {
-	return &travisCIUsecase{repository, cache.NewBuildCache(cacheSize)}
+func NewTravisCIUsecase(repository api.Repository, store cache.Store, variantName coreModels.VariantName) api.Usecase {
+	return &travisCIUsecase{repository, monitorableCache.NewBuildCache(store, fmt.Sprintf("travisci.%s", variantName), cacheSize)}
 }
 
 func (tu *travisCIUsecase) Build(params *models.BuildParams) (*coreModels.Tile, error) {
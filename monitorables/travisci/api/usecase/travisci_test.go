@@ -13,6 +13,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/git"
 
 	. "github.com/AlekSi/pointer"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	. "github.com/stretchr/testify/mock"
 )
@@ -24,7 +25,7 @@ func TestBuild_Error(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := tu.Build(&models.BuildParams{Owner: owner, Repository: repo, Branch: branch})
 	if assert.Error(t, err) {
@@ -41,7 +42,7 @@ func TestBuild_Error_NoBuild(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 
 	tile, err := tu.Build(&models.BuildParams{Owner: owner, Repository: repo, Branch: branch})
 	if assert.Error(t, err) {
@@ -62,7 +63,7 @@ func TestBuild_Success(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		// Expected
@@ -103,7 +104,7 @@ func TestBuild_Failed(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		// Expected
@@ -147,7 +148,7 @@ func TestBuild_Queued(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok) {
 		// Expected
@@ -178,7 +179,7 @@ func TestBuild_Running(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		// Expected
@@ -224,7 +225,7 @@ func TestBuild_Aborded(t *testing.T) {
 	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), coreModels.DefaultVariantName)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok) {
 		// Expected
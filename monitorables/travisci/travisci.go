@@ -64,7 +64,7 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	conf := m.config[variantName]
 
 	repository := travisciRepository.NewTravisCIRepository(conf)
-	usecase := travisciUsecase.NewTravisCIUsecase(repository)
+	usecase := travisciUsecase.NewTravisCIUsecase(repository, m.store.CacheStore, variantName)
 	delivery := travisciDelivery.NewTravisCIDelivery(usecase)
 
 	// EnableTile route to echo
@@ -0,0 +1,271 @@
+package diskcache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucket = []byte("cache")
+
+// openTimeout avoid waiting forever when database file is locked by another process
+const openTimeout = time.Second
+
+// DiskStore is a cache.Store persisted in an embedded bolt database
+type DiskStore struct {
+	db                *bolt.DB
+	defaultExpiration time.Duration
+	done              chan struct{}
+}
+
+// NewDiskStore open (or create) database file and remove expired items every cleanupInterval (disabled when <= 0)
+func NewDiskStore(path string, defaultExpiration, cleanupInterval time.Duration) (*DiskStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	s := &DiskStore{db: db, defaultExpiration: defaultExpiration, done: make(chan struct{})}
+	if cleanupInterval > 0 {
+		go s.cleanup(cleanupInterval)
+	}
+
+	return s, nil
+}
+
+func (s *DiskStore) Get(key string, value interface{}) error {
+	var data []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var ok bool
+		data, ok = s.get(tx, key)
+		if !ok {
+			return cache.ErrCacheMiss
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return deserialize(data, value)
+}
+
+func (s *DiskStore) Set(key string, value interface{}, expires time.Duration) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return s.put(tx, key, value, expires)
+	})
+}
+
+func (s *DiskStore) Add(key string, value interface{}, expires time.Duration) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if _, ok := s.get(tx, key); ok {
+			return cache.ErrNotStored
+		}
+		return s.put(tx, key, value, expires)
+	})
+}
+
+func (s *DiskStore) Replace(key string, value interface{}, expires time.Duration) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if _, ok := s.get(tx, key); !ok {
+			return cache.ErrNotStored
+		}
+		return s.put(tx, key, value, expires)
+	})
+}
+
+func (s *DiskStore) Delete(key string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if _, ok := s.get(tx, key); !ok {
+			return cache.ErrCacheMiss
+		}
+		return tx.Bucket(bucket).Delete([]byte(key))
+	})
+}
+
+func (s *DiskStore) Increment(key string, delta uint64) (uint64, error) {
+	return s.incr(key, func(value uint64) uint64 { return value + delta })
+}
+
+// Decrement can only go to 0
+func (s *DiskStore) Decrement(key string, delta uint64) (uint64, error) {
+	return s.incr(key, func(value uint64) uint64 {
+		if delta > value {
+			return 0
+		}
+		return value - delta
+	})
+}
+
+func (s *DiskStore) Flush() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.DeleteBucket(bucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucket(bucket)
+		return err
+	})
+}
+
+// Close stop cleanup and close database file
+func (s *DiskStore) Close() error {
+	close(s.done)
+	return s.db.Close()
+}
+
+func (s *DiskStore) incr(key string, f func(uint64) uint64) (newValue uint64, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(bucket).Get([]byte(key))
+		if raw == nil || isExpired(raw) {
+			return cache.ErrCacheMiss
+		}
+
+		value, err := strconv.ParseUint(string(raw[8:]), 10, 64)
+		if err != nil {
+			return err
+		}
+		newValue = f(value)
+
+		// Keep expiration
+		entry := make([]byte, 8)
+		copy(entry, raw[:8])
+		entry = append(entry, strconv.FormatUint(newValue, 10)...)
+		return tx.Bucket(bucket).Put([]byte(key), entry)
+	})
+	return
+}
+
+// get return value of non expired key. Value is only valid during transaction
+func (s *DiskStore) get(tx *bolt.Tx, key string) ([]byte, bool) {
+	raw := tx.Bucket(bucket).Get([]byte(key))
+	if raw == nil || isExpired(raw) {
+		return nil, false
+	}
+	return raw[8:], true
+}
+
+func (s *DiskStore) put(tx *bolt.Tx, key string, value interface{}, expires time.Duration) error {
+	data, err := serialize(value)
+	if err != nil {
+		return err
+	}
+
+	if expires == cache.DEFAULT {
+		expires = s.defaultExpiration
+	}
+
+	// Entry is prefixed by expiration date in UnixNano, 0 if item never expire
+	var expiration int64
+	if expires > 0 {
+		expiration = time.Now().Add(expires).UnixNano()
+	}
+	entry := make([]byte, 8, 8+len(data))
+	binary.BigEndian.PutUint64(entry, uint64(expiration))
+	entry = append(entry, data...)
+
+	return tx.Bucket(bucket).Put([]byte(key), entry)
+}
+
+func (s *DiskStore) cleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			_ = s.deleteExpired()
+		}
+	}
+}
+
+func (s *DiskStore) deleteExpired() error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		var keys [][]byte
+		_ = tx.Bucket(bucket).ForEach(func(k, v []byte) error {
+			if isExpired(v) {
+				keys = append(keys, append([]byte(nil), k...))
+			}
+			return nil
+		})
+
+		for _, k := range keys {
+			if err := tx.Bucket(bucket).Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func isExpired(raw []byte) bool {
+	if len(raw) < 8 {
+		return true
+	}
+	expiration := int64(binary.BigEndian.Uint64(raw[:8]))
+	return expiration != 0 && time.Now().UnixNano() > expiration
+}
+
+// serialize / deserialize use the same encoding than cache.RedisStore (integers as string to be incremented)
+func serialize(value interface{}) ([]byte, error) {
+	if b, ok := value.([]byte); ok {
+		return b, nil
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []byte(strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []byte(strconv.FormatUint(v.Uint(), 10)), nil
+	}
+
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(value); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func deserialize(data []byte, ptr interface{}) error {
+	if b, ok := ptr.(*[]byte); ok {
+		*b = append([]byte(nil), data...)
+		return nil
+	}
+
+	if v := reflect.ValueOf(ptr); v.Kind() == reflect.Ptr {
+		switch p := v.Elem(); p.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := strconv.ParseInt(string(data), 10, 64)
+			if err != nil {
+				return err
+			}
+			p.SetInt(i)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			i, err := strconv.ParseUint(string(data), 10, 64)
+			if err != nil {
+				return err
+			}
+			p.SetUint(i)
+			return nil
+		}
+	}
+
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(ptr)
+}
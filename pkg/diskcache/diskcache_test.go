@@ -0,0 +1,126 @@
+package diskcache
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type testValue struct {
+	Status int
+	Data   []byte
+}
+
+func newTestStore(t *testing.T) (*DiskStore, string) {
+	dir, err := ioutil.TempDir("", "diskcache")
+	if assert.NoError(t, err) {
+		t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	}
+
+	path := filepath.Join(dir, "cache.db")
+	store, err := NewDiskStore(path, time.Hour, 0)
+	if assert.NoError(t, err) {
+		t.Cleanup(func() { _ = store.Close() })
+	}
+
+	return store, path
+}
+
+func TestDiskStore_GetSet(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	assert.NoError(t, store.Set("struct", testValue{Status: 200, Data: []byte("body")}, cache.DEFAULT))
+	var value testValue
+	assert.NoError(t, store.Get("struct", &value))
+	assert.Equal(t, testValue{Status: 200, Data: []byte("body")}, value)
+
+	assert.NoError(t, store.Set("string", "foo", cache.NEVER))
+	var str string
+	assert.NoError(t, store.Get("string", &str))
+	assert.Equal(t, "foo", str)
+
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("missing", &str))
+}
+
+func TestDiskStore_IncrDecr(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	_, err := store.Increment("int", 1)
+	assert.Equal(t, cache.ErrCacheMiss, err)
+
+	assert.NoError(t, store.Set("int", 10, cache.DEFAULT))
+
+	value, err := store.Increment("int", 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(60), value)
+
+	value, err = store.Decrement("int", 50)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), value)
+
+	value, err = store.Decrement("int", 20)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), value)
+
+	var i int
+	assert.NoError(t, store.Get("int", &i))
+	assert.Equal(t, 0, i)
+}
+
+func TestDiskStore_Expiration(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	assert.NoError(t, store.Set("key", "value", time.Millisecond))
+	time.Sleep(time.Millisecond * 10)
+
+	var value string
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("key", &value))
+	assert.Equal(t, cache.ErrCacheMiss, store.Delete("key"))
+
+	assert.NoError(t, store.Set("expired", "value", time.Millisecond))
+	assert.NoError(t, store.Set("kept", "value", cache.NEVER))
+	time.Sleep(time.Millisecond * 10)
+	assert.NoError(t, store.deleteExpired())
+	assert.NoError(t, store.Get("kept", &value))
+}
+
+func TestDiskStore_AddReplaceDeleteFlush(t *testing.T) {
+	store, _ := newTestStore(t)
+
+	assert.Equal(t, cache.ErrNotStored, store.Replace("key", "value", cache.DEFAULT))
+	assert.NoError(t, store.Add("key", "value", cache.DEFAULT))
+	assert.Equal(t, cache.ErrNotStored, store.Add("key", "value2", cache.DEFAULT))
+	assert.NoError(t, store.Replace("key", "value3", cache.DEFAULT))
+
+	var value string
+	assert.NoError(t, store.Get("key", &value))
+	assert.Equal(t, "value3", value)
+
+	assert.NoError(t, store.Delete("key"))
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("key", &value))
+
+	assert.NoError(t, store.Set("key", "value", cache.DEFAULT))
+	assert.NoError(t, store.Flush())
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("key", &value))
+}
+
+func TestDiskStore_Persistence(t *testing.T) {
+	store, path := newTestStore(t)
+
+	assert.NoError(t, store.Set("key", "value", cache.NEVER))
+	assert.NoError(t, store.db.Close())
+
+	reopened, err := NewDiskStore(path, time.Hour, time.Minute)
+	if assert.NoError(t, err) {
+		defer reopened.Close()
+
+		var value string
+		assert.NoError(t, reopened.Get("key", &value))
+		assert.Equal(t, "value", value)
+	}
+}
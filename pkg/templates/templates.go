@@ -3,6 +3,8 @@
 package templates
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"text/template"
 
@@ -16,6 +18,14 @@ var basicFunctions = template.FuncMap{
 	"join":  strings.Join,
 	"lower": strings.ToLower,
 	"upper": strings.ToUpper,
+	"json": func(v interface{}) string {
+		buf := &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		_ = enc.Encode(v)
+		// Remove the trailing new line added by the encoder
+		return strings.TrimSpace(buf.String())
+	},
 
 	// For terminal only
 	"blue":         colorer.Blue,
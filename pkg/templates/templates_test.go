@@ -26,3 +26,16 @@ func TestNew(t *testing.T) {
 	want := "this is a string"
 	assert.Equal(t, want, b.String())
 }
+
+func TestParseJSONFunction(t *testing.T) {
+	tm, err := New("").Parse(`{"label": {{ json .Label }}, "id": {{ json .ID }}}`)
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	assert.NoError(t, tm.Execute(&b, struct {
+		Label string
+		ID    *string
+	}{Label: `"quoted" <label>`}))
+	want := `{"label": "\"quoted\" <label>", "id": null}`
+	assert.Equal(t, want, b.String())
+}
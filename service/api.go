@@ -5,13 +5,24 @@ import (
 	configRepository "github.com/monitoror/monitoror/api/config/repository"
 	configUsecase "github.com/monitoror/monitoror/api/config/usecase"
 	"github.com/monitoror/monitoror/api/info"
+	schedulerDelivery "github.com/monitoror/monitoror/api/scheduler/delivery/http"
+	streamDelivery "github.com/monitoror/monitoror/api/stream/delivery/http"
+	streamUsecase "github.com/monitoror/monitoror/api/stream/usecase"
+	"github.com/monitoror/monitoror/internal/pkg/path"
 	"github.com/monitoror/monitoror/monitorables"
+	"github.com/monitoror/monitoror/service/metrics"
+	"github.com/monitoror/monitoror/service/notifier"
 	"github.com/monitoror/monitoror/service/router"
+	"github.com/monitoror/monitoror/service/scheduler"
 
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 func InitApis(s *Server) {
+	// ------------- METRICS ------------- //
+	s.GET("/metrics", metrics.Handler())
+	s.ObserverMiddleware.AddObserver(metrics.NewTileObserver())
+
 	// API group
 	apiGroup := s.Group("/api/v1")
 
@@ -20,14 +31,43 @@ func InitApis(s *Server) {
 	apiGroup.GET("/info", s.CacheMiddleware.UpstreamCacheHandlerWithExpiration(cache.NEVER, infoDelivery.GetInfo))
 
 	// ------------- CONFIG ------------- //
-	confRepository := configRepository.NewConfigRepository()
-	confUsecase := configUsecase.NewConfigUsecase(confRepository, s.store)
+	confRepository := configRepository.NewConfigRepositoryWithRemoteConfig(s.store.CoreConfig, s.store.CacheStore)
+	revRepository := configRepository.NewRevisionRepository(
+		path.ToAbsolute(path.MonitororBaseDir, s.store.CoreConfig.ConfigRevisionDir), s.store.CoreConfig.ConfigRevisionLimit)
+	confUsecase := configUsecase.NewConfigUsecase(confRepository, revRepository, s.store)
 	confDelivery := configDelivery.NewConfigDelivery(confUsecase)
 	apiGroup.GET("/configs", s.CacheMiddleware.UpstreamCacheHandler(confDelivery.GetConfigList))
 	apiGroup.GET("/configs/:config", s.CacheMiddleware.UpstreamCacheHandler(confDelivery.GetConfig))
+	apiGroup.GET("/configs/:config/hash", confDelivery.GetConfigHash)
+	apiGroup.GET("/schema", confDelivery.GetSchema)
+	apiGroup.POST("/configs/validate", confDelivery.ValidateConfig) // Read-only, restricted to writers when auth is enabled
+
+	// Config can only be updated by authenticated users (see Access.Writers)
+	if s.store.CoreConfig.IsAuthEnabled() {
+		apiGroup.PUT("/configs/:config", confDelivery.SaveConfig)
+		apiGroup.POST("/configs/:config", confDelivery.SaveConfig)
+		apiGroup.GET("/configs/:config/revisions", confDelivery.GetConfigRevisions)
+		apiGroup.POST("/configs/:config/revisions/:revision/restore", confDelivery.RestoreConfigRevision)
+	}
+
+	// Used by commands, like validate
+	s.ConfigUsecase = confUsecase
+
+	// ------------- STREAM ------------- //
+	strUsecase := streamUsecase.NewStreamUsecase(confUsecase, &tileProvider{handler: s.Echo}, s.store)
+	strDelivery := streamDelivery.NewStreamDelivery(strUsecase)
+	apiGroup.GET("/stream", strDelivery.GetStream)
+
+	// ------------- SCHEDULER ------------- //
+	s.store.Scheduler = scheduler.NewScheduler(s.store.CoreConfig, confUsecase, &tileProvider{handler: s.Echo})
+	schDelivery := schedulerDelivery.NewSchedulerDelivery(s.store.Scheduler, s.AuthMiddleware.CanRead)
+	apiGroup.GET("/scheduler/jobs", schDelivery.GetJobs)
+
+	// ------------- NOTIFIER ------------- //
+	s.ObserverMiddleware.AddObserver(notifier.NewNotifier(s.store.CoreConfig.Notifier))
 
 	// ---------------------------------- //
-	s.store.MonitorableRouter = router.NewMonitorableRouter(apiGroup, s.CacheMiddleware)
+	s.store.MonitorableRouter = router.NewMonitorableRouter(apiGroup, s.CacheMiddleware, s.ObserverMiddleware, s.AuthMiddleware)
 	// ---------------------------------- //
 
 	// ------------- MONITORABLES ------------- //
@@ -0,0 +1,148 @@
+package certificate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ReloadInterval is the minimal delay between two checks of certificate files
+const ReloadInterval = 10 * time.Second
+
+type (
+	// Reloader serve certificate (and client CA) from files, files are reloaded on change without restarting server
+	Reloader struct {
+		certFile     string
+		keyFile      string
+		clientCAFile string
+
+		sync.Mutex
+		config    *tls.Config
+		modTimes  map[string]time.Time
+		lastCheck time.Time
+	}
+)
+
+// NewReloader load certificate files. clientCAFile is optional, when defined clients need a certificate signed by this CA (mTLS)
+func NewReloader(certFile, keyFile, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	r.lastCheck = time.Now()
+
+	return r, nil
+}
+
+// TLSConfig return tls.Config using current certificate for every new connection
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.getConfig(), nil
+		},
+		// Not used (GetConfigForClient return certificates), but required by http.Server.ServeTLS
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &r.getConfig().Certificates[0], nil
+		},
+	}
+}
+
+func (r *Reloader) getConfig() *tls.Config {
+	r.Lock()
+	defer r.Unlock()
+
+	if time.Since(r.lastCheck) >= ReloadInterval {
+		r.lastCheck = time.Now()
+		if r.hasChanged() {
+			if err := r.load(); err != nil {
+				log.Warnf("unable to reload certificate, keeping previous one. %v", err)
+			} else {
+				log.Infof("certificate reloaded")
+			}
+		}
+	}
+
+	return r.config
+}
+
+// load read every files and build new tls.Config. Previous config is kept on error
+func (r *Reloader) load() error {
+	modTimes, err := r.getModTimes()
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load certificate, %w", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{certificate},
+	}
+
+	if r.clientCAFile != "" {
+		bytes, err := ioutil.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("unable to load client CA, %w", err)
+		}
+
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(bytes) {
+			return errors.New("unable to load client CA, no certificate found")
+		}
+
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.config = config
+	r.modTimes = modTimes
+
+	return nil
+}
+
+func (r *Reloader) hasChanged() bool {
+	modTimes, err := r.getModTimes()
+	if err != nil {
+		log.Warnf("unable to check certificate files, %v", err)
+		return false
+	}
+
+	for file, modTime := range modTimes {
+		if !modTime.Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) getModTimes() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, err
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
@@ -0,0 +1,150 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCertificate generate self-signed certificate and write it in dir
+func writeCertificate(t *testing.T, dir string, serial int64) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	assert.NoError(t, ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	assert.NoError(t, ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+
+	return
+}
+
+func getServerSerial(t *testing.T, reloader *Reloader) int64 {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.TLS = reloader.TLSConfig()
+	ts.StartTLS()
+	defer ts.Close()
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	resp, err := client.Get(ts.URL)
+	if !assert.NoError(t, err) {
+		return 0
+	}
+	defer resp.Body.Close()
+
+	return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+}
+
+func TestNewReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-certificate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeCertificate(t, dir, 1)
+
+	reloader, err := NewReloader(certFile, keyFile, "")
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), getServerSerial(t, reloader))
+		assert.Nil(t, reloader.config.ClientCAs)
+	}
+
+	_, err = NewReloader(certFile, filepath.Join(dir, "missing.pem"), "")
+	assert.Error(t, err)
+	_, err = NewReloader(certFile, certFile, "")
+	assert.Error(t, err)
+	_, err = NewReloader(certFile, keyFile, keyFile)
+	assert.Error(t, err)
+}
+
+func TestReloader_Reload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-certificate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeCertificate(t, dir, 1)
+
+	reloader, err := NewReloader(certFile, keyFile, "")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// Files changed, but ReloadInterval not reached
+	writeCertificate(t, dir, 2)
+	future := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(certFile, future, future))
+	assert.Equal(t, int64(1), getServerSerial(t, reloader))
+
+	// ReloadInterval reached
+	reloader.lastCheck = time.Time{}
+	assert.Equal(t, int64(2), getServerSerial(t, reloader))
+
+	// Broken files, previous certificate is kept
+	assert.NoError(t, ioutil.WriteFile(keyFile, []byte("broken"), 0600))
+	future = future.Add(time.Minute)
+	assert.NoError(t, os.Chtimes(keyFile, future, future))
+	reloader.lastCheck = time.Time{}
+	assert.Equal(t, int64(2), getServerSerial(t, reloader))
+}
+
+func TestReloader_ClientCA(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-certificate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile := writeCertificate(t, dir, 1)
+
+	reloader, err := NewReloader(certFile, keyFile, certFile)
+	if assert.NoError(t, err) {
+		assert.NotNil(t, reloader.config.ClientCAs)
+		assert.Equal(t, tls.RequireAndVerifyClientCert, reloader.config.ClientAuth)
+
+		ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		ts.TLS = reloader.TLSConfig()
+		ts.StartTLS()
+		defer ts.Close()
+
+		// Without client certificate
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+		_, err = client.Get(ts.URL)
+		assert.Error(t, err)
+
+		// With client certificate
+		clientCertificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		assert.NoError(t, err)
+		client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true, Certificates: []tls.Certificate{clientCertificate}}}}
+		resp, err := client.Get(ts.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/metrics"
 
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/labstack/echo/v4"
@@ -51,7 +52,9 @@ func handleMonitororError(me *models.MonitororError, ctx echo.Context) error {
 	// Check if error was timeout and check cache
 	if me.Timeout() {
 		// If cache found, reply cache and exit
-		if found := cacheMiddleware(ctx); found {
+		found := cacheMiddleware(ctx)
+		metrics.ObserveTimeout(found)
+		if found {
 			return nil
 		}
 
@@ -91,7 +94,9 @@ func cacheMiddleware(ctx echo.Context) bool {
 
 	// Looking for Data in DownstreamStore
 	var cachedResponse cache.ResponseCache
-	if err := store.Get(cache.GetKey(models.DownstreamStoreKeyPrefix, ctx.Request()), &cachedResponse); err != nil {
+	err := store.Get(cache.GetKey(models.DownstreamStoreKeyPrefix, ctx.Request()), &cachedResponse)
+	metrics.ObserveCache(metrics.DownstreamCache, err == nil)
+	if err != nil {
 		// Cache not found, return
 		return false
 	}
@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	Namespace = "monitoror"
+
+	UpstreamCache   = "upstream"
+	DownstreamCache = "downstream"
+)
+
+var (
+	// Registry contains every monitoror metrics, it's exposed on /metrics
+	Registry = prometheus.NewRegistry()
+
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by route and method.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+
+	cacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of lookups in upstream / downstream cache by result (hit or miss).",
+	}, []string{"cache", "result"})
+
+	timeoutsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "timeouts_total",
+		Help:      "Number of monitorable timeouts, recovered when previous response was found in downstream cache.",
+	}, []string{"recovered"})
+)
+
+func init() {
+	Registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestDuration,
+		cacheRequestsTotal,
+		timeoutsTotal,
+		tileStatus,
+	)
+}
+
+// Handler expose metrics in prometheus format
+func Handler() echo.HandlerFunc {
+	return echo.WrapHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+// Middleware count requests and measure their latencies by route
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			start := time.Now()
+
+			// Handle error here (like echo Logger) to get real response status
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			route := ctx.Path()
+			code := strconv.Itoa(ctx.Response().Status)
+			requestsTotal.WithLabelValues(route, ctx.Request().Method, code).Inc()
+			requestDuration.WithLabelValues(route, ctx.Request().Method).Observe(time.Since(start).Seconds())
+
+			return nil
+		}
+	}
+}
+
+// ObserveCache count cache lookups
+func ObserveCache(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheRequestsTotal.WithLabelValues(cache, result).Inc()
+}
+
+// ObserveTimeout count monitorable timeouts
+func ObserveTimeout(recovered bool) {
+	timeoutsTotal.WithLabelValues(strconv.FormatBool(recovered)).Inc()
+}
@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/api/v1/test/:id", func(ctx echo.Context) error {
+		if ctx.Param("id") == "error" {
+			return echo.NewHTTPError(http.StatusBadRequest, "boom")
+		}
+		return ctx.String(http.StatusOK, "ok")
+	})
+	e.GET("/metrics", Handler())
+
+	for _, id := range []string{"1", "2", "error"} {
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/test/"+id, nil))
+	}
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(requestsTotal.WithLabelValues("/api/v1/test/:id", http.MethodGet, "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(requestsTotal.WithLabelValues("/api/v1/test/:id", http.MethodGet, "400")))
+
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Contains(t, res.Body.String(), `monitoror_http_request_duration_seconds_count{method="GET",route="/api/v1/test/:id"} 3`)
+}
+
+func TestObserveCache(t *testing.T) {
+	ObserveCache(UpstreamCache, true)
+	ObserveCache(UpstreamCache, false)
+	ObserveCache(UpstreamCache, false)
+	ObserveCache(DownstreamCache, true)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheRequestsTotal.WithLabelValues(UpstreamCache, "hit")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(cacheRequestsTotal.WithLabelValues(UpstreamCache, "miss")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(cacheRequestsTotal.WithLabelValues(DownstreamCache, "hit")))
+}
+
+func TestObserveTimeout(t *testing.T) {
+	ObserveTimeout(true)
+	ObserveTimeout(false)
+	ObserveTimeout(false)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(timeoutsTotal.WithLabelValues("true")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(timeoutsTotal.WithLabelValues("false")))
+}
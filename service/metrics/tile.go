@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"sync"
+
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var tileStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: Namespace,
+	Name:      "tile_status",
+	Help:      "Current status of tiles by type, variant and label (1 on current status only).",
+}, []string{"type", "variant", "label", "status"})
+
+type (
+	// TileObserver update tile status gauge. See middlewares.ObserverMiddleware
+	TileObserver struct {
+		sync.Mutex
+		statuses map[tileKey]coreModels.TileStatus
+	}
+
+	tileKey struct {
+		tileType coreModels.TileType
+		variant  coreModels.VariantName
+		label    string
+	}
+)
+
+func NewTileObserver() *TileObserver {
+	return &TileObserver{statuses: make(map[tileKey]coreModels.TileStatus)}
+}
+
+// ObserveTile implements middlewares.TileObserver
+func (o *TileObserver) ObserveTile(ctx echo.Context, tile *coreModels.Tile) {
+	variantName, _ := ctx.Get(coreModels.VariantNameContextKey).(coreModels.VariantName)
+	key := tileKey{tileType: tile.Type, variant: variantName, label: tile.Label}
+
+	o.Lock()
+	defer o.Unlock()
+
+	// Remove previous status, only current status is exposed
+	if previous, ok := o.statuses[key]; ok && previous != tile.Status {
+		tileStatus.DeleteLabelValues(string(key.tileType), string(key.variant), key.label, string(previous))
+	}
+
+	o.statuses[key] = tile.Status
+	tileStatus.WithLabelValues(string(key.tileType), string(key.variant), key.label, string(tile.Status)).Set(1)
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileObserver_ObserveTile(t *testing.T) {
+	observer := NewTileObserver()
+
+	ctx := echo.New().NewContext(httptest.NewRequest(echo.GET, "/api/v1/test/variant1/test", nil), httptest.NewRecorder())
+	ctx.Set(coreModels.VariantNameContextKey, coreModels.VariantName("variant1"))
+
+	tile := coreModels.NewTile("TEST")
+	tile.Label = "test"
+	tile.Status = coreModels.SuccessStatus
+	observer.ObserveTile(ctx, tile)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(tileStatus.WithLabelValues("TEST", "variant1", "test", "SUCCESS")))
+
+	tile.Status = coreModels.FailedStatus
+	observer.ObserveTile(ctx, tile)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(tileStatus.WithLabelValues("TEST", "variant1", "test", "FAILURE")))
+	assert.False(t, tileStatus.DeleteLabelValues("TEST", "variant1", "test", "SUCCESS"))
+}
@@ -0,0 +1,342 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"strings"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/signature"
+	"github.com/monitoror/monitoror/service/handlers"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+/*AuthMiddleware for monitoror
+*
+* Users are identified by (in this order):
+* - Bearer token (Authorization: Bearer <token>), user is the token name
+* - Basic auth (Authorization: Basic <user:password>)
+* - Header set by a trusted reverse proxy
+*
+* Anonymous requests are only allowed on:
+* - named configs marked as public (and their stream)
+*   config updates and revisions always need an authenticated user listed in Access.Writers
+*   config validation needs an authenticated user listed in Access.Writers of at least one named config
+* - tiles with valid signature, signature is added by config hydrate. So access to tiles follows access to their config
+*   signature is also required for authenticated users, see TileMiddleware
+* - UI and info when at least one named config is public
+*
+* Requests executed on server side (scheduler, stream, ...) are not checked, see WithInternalRequest.
+ */
+const (
+	AuthUserContextKey = "monitoror.auth.user"
+
+	AnyUser = "*"
+)
+
+type (
+	AuthMiddleware struct {
+		coreConfig *coreConfig.CoreConfig
+
+		users          map[string]string // user -> password
+		tokens         map[string]string // token -> name
+		trustedProxies []*net.IPNet
+
+		// publicUI is true when at least one named config is public
+		publicUI bool
+	}
+
+	internalRequestKey struct{}
+)
+
+// NewAuthMiddleware parse users, tokens and trusted proxies of CoreConfig
+func NewAuthMiddleware(config *coreConfig.CoreConfig) *AuthMiddleware {
+	am := &AuthMiddleware{
+		coreConfig: config,
+		users:      make(map[string]string),
+		tokens:     make(map[string]string),
+	}
+
+	for name, password := range parsePairs(config.AuthUsers) {
+		am.users[name] = password
+	}
+	for name, token := range parsePairs(config.AuthTokens) {
+		am.tokens[token] = name
+	}
+	for _, value := range splitList(config.AuthTrustedProxies) {
+		if !strings.Contains(value, "/") {
+			if strings.Contains(value, ":") {
+				value += "/128"
+			} else {
+				value += "/32"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			log.Warnf("ignoring invalid trusted proxy %q, %v", value, err)
+			continue
+		}
+		am.trustedProxies = append(am.trustedProxies, ipNet)
+	}
+
+	for configName := range config.NamedConfigs {
+		if config.GetAccess(configName).Public {
+			am.publicUI = true
+		}
+	}
+
+	return am
+}
+
+// WithInternalRequest mark request as executed on server side, auth is skipped for these requests
+func WithInternalRequest(request *http.Request) *http.Request {
+	return request.WithContext(context.WithValue(request.Context(), internalRequestKey{}, true))
+}
+
+// Middleware authenticate user and check access of requested named config
+func (am *AuthMiddleware) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if !am.coreConfig.IsAuthEnabled() || ctx.Request().Context().Value(internalRequestKey{}) != nil {
+				return next(ctx)
+			}
+
+			user, ok := am.authenticate(ctx)
+			if !ok {
+				return unauthorized(ctx, "Invalid credentials")
+			}
+
+			if user != "" {
+				ctx.Set(AuthUserContextKey, user)
+			}
+
+			// Named config routes
+			if configName, ok := requestedConfig(ctx); ok {
+				access := am.coreConfig.GetAccess(configName)
+
+				// Updates and revisions always need an authenticated writer, even for public configs
+				if isWriteRoute(ctx) {
+					if user == "" {
+						return unauthorized(ctx, "Authentication required")
+					}
+					if !isAllowedWriter(access, user) {
+						return ctx.JSON(http.StatusForbidden, handlers.APIError{Code: http.StatusForbidden, Message: "Forbidden"})
+					}
+					return next(ctx)
+				}
+
+				if access.Public {
+					return next(ctx)
+				}
+				if user == "" {
+					return unauthorized(ctx, "Authentication required")
+				}
+				if !isAllowed(access, user) {
+					return ctx.JSON(http.StatusForbidden, handlers.APIError{Code: http.StatusForbidden, Message: "Forbidden"})
+				}
+				return next(ctx)
+			}
+
+			// Config validation, same access as config updates
+			if ctx.Path() == "/api/v1/configs/validate" {
+				if user == "" {
+					return unauthorized(ctx, "Authentication required")
+				}
+				if !am.isWriter(user) {
+					return ctx.JSON(http.StatusForbidden, handlers.APIError{Code: http.StatusForbidden, Message: "Forbidden"})
+				}
+				return next(ctx)
+			}
+
+			if user != "" {
+				return next(ctx)
+			}
+
+			// Signed tiles
+			if signature.VerifyURL(am.coreConfig.AuthSecret, ctx.Request().RequestURI) {
+				return next(ctx)
+			}
+
+			// UI and info
+			if am.publicUI && isUIRoute(ctx) {
+				return next(ctx)
+			}
+
+			return unauthorized(ctx, "Authentication required")
+		}
+	}
+}
+
+// TileMiddleware check signature of monitorable tile routes, even for authenticated users.
+// Tile URLs are only signed by hydrate of configs, so users can only request tiles of configs they can read
+func (am *AuthMiddleware) TileMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if !am.coreConfig.IsAuthEnabled() || ctx.Request().Context().Value(internalRequestKey{}) != nil {
+				return next(ctx)
+			}
+
+			if !signature.VerifyURL(am.coreConfig.AuthSecret, ctx.Request().RequestURI) {
+				return ctx.JSON(http.StatusForbidden, handlers.APIError{Code: http.StatusForbidden, Message: "Forbidden"})
+			}
+			return next(ctx)
+		}
+	}
+}
+
+// CanRead return true when request can read named config (auth disabled, internal request, public config or allowed user)
+func (am *AuthMiddleware) CanRead(ctx echo.Context, configName coreConfig.ConfigName) bool {
+	if !am.coreConfig.IsAuthEnabled() || ctx.Request().Context().Value(internalRequestKey{}) != nil {
+		return true
+	}
+
+	access := am.coreConfig.GetAccess(configName)
+	if access.Public {
+		return true
+	}
+
+	user, _ := ctx.Get(AuthUserContextKey).(string)
+	return user != "" && isAllowed(access, user)
+}
+
+// authenticate return user name, empty when request is anonymous. false is returned for invalid credentials
+func (am *AuthMiddleware) authenticate(ctx echo.Context) (string, bool) {
+	authorization := ctx.Request().Header.Get(echo.HeaderAuthorization)
+
+	if strings.HasPrefix(authorization, "Bearer ") {
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		for t, name := range am.tokens {
+			if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+				return name, true
+			}
+		}
+		return "", false
+	}
+
+	if user, password, ok := ctx.Request().BasicAuth(); ok {
+		expected, exists := am.users[user]
+		if exists && subtle.ConstantTimeCompare([]byte(expected), []byte(password)) == 1 {
+			return user, true
+		}
+		return "", false
+	}
+
+	if am.coreConfig.AuthProxyHeader != "" && am.isTrustedProxy(ctx.Request()) {
+		if user := ctx.Request().Header.Get(am.coreConfig.AuthProxyHeader); user != "" {
+			return user, true
+		}
+	}
+
+	return "", true
+}
+
+func (am *AuthMiddleware) isTrustedProxy(request *http.Request) bool {
+	// No proxy is trusted when list is empty (see CoreConfig.Validate)
+	host, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		host = request.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range am.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestedConfig return named config of config / stream routes
+func requestedConfig(ctx echo.Context) (coreConfig.ConfigName, bool) {
+	var configName string
+	switch ctx.Path() {
+	case "/api/v1/configs/:config", "/api/v1/configs/:config/hash", "/api/v1/configs/:config/revisions", "/api/v1/configs/:config/revisions/:revision/restore":
+		configName = ctx.Param("config")
+	case "/api/v1/stream":
+		configName = ctx.QueryParam("config")
+	default:
+		return "", false
+	}
+
+	if configName == "" {
+		configName = string(coreConfig.DefaultConfigName)
+	}
+	return coreConfig.ConfigName(strings.ToLower(configName)), true
+}
+
+// isWriteRoute return true for config updates and revisions
+func isWriteRoute(ctx echo.Context) bool {
+	return ctx.Request().Method != http.MethodGet || strings.HasPrefix(ctx.Path(), "/api/v1/configs/:config/revisions")
+}
+
+// isUIRoute return true for static files and info route
+func isUIRoute(ctx echo.Context) bool {
+	if ctx.Path() == "/api/v1/info" {
+		return true
+	}
+	return !strings.HasPrefix(ctx.Request().URL.Path, "/api/") && ctx.Path() != "/metrics"
+}
+
+func isAllowed(access *coreConfig.Access, user string) bool {
+	for _, allowed := range splitList(access.Users) {
+		if allowed == AnyUser || allowed == user {
+			return true
+		}
+	}
+	return false
+}
+
+// isWriter return true when user can update at least one named config
+func (am *AuthMiddleware) isWriter(user string) bool {
+	for configName := range am.coreConfig.NamedConfigs {
+		if isAllowedWriter(am.coreConfig.GetAccess(configName), user) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAllowedWriter(access *coreConfig.Access, user string) bool {
+	for _, allowed := range splitList(access.Writers) {
+		if allowed == AnyUser || allowed == user {
+			return true
+		}
+	}
+	return false
+}
+
+func unauthorized(ctx echo.Context, message string) error {
+	ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="monitoror"`)
+	return ctx.JSON(http.StatusUnauthorized, handlers.APIError{Code: http.StatusUnauthorized, Message: message})
+}
+
+// parsePairs parse "name1:value1,name2:value2"
+func parsePairs(value string) map[string]string {
+	pairs := make(map[string]string)
+	for _, pair := range splitList(value) {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			log.Warnf("ignoring invalid auth entry, expected format is name:value")
+			continue
+		}
+		pairs[parts[0]] = parts[1]
+	}
+	return pairs
+}
+
+func splitList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,272 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/internal/pkg/signature"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func initAuthEcho(config *coreConfig.CoreConfig) *echo.Echo {
+	e := echo.New()
+	am := NewAuthMiddleware(config)
+	e.Use(am.Middleware())
+
+	handler := func(ctx echo.Context) error {
+		user, _ := ctx.Get(AuthUserContextKey).(string)
+		return ctx.String(http.StatusOK, user)
+	}
+	e.GET("/*", handler)
+	e.GET("/metrics", handler)
+	e.GET("/api/v1/info", handler)
+	e.GET("/api/v1/configs", handler)
+	e.GET("/api/v1/configs/:config", handler)
+	e.PUT("/api/v1/configs/:config", handler)
+	e.GET("/api/v1/configs/:config/hash", handler)
+	e.GET("/api/v1/configs/:config/revisions", handler)
+	e.POST("/api/v1/configs/:config/revisions/:revision/restore", handler)
+	e.POST("/api/v1/configs/validate", handler)
+	e.GET("/api/v1/stream", handler)
+	e.GET("/api/v1/ping/default/ping", handler, am.TileMiddleware())
+
+	return e
+}
+
+func initAuthCoreConfig() *coreConfig.CoreConfig {
+	return &coreConfig.CoreConfig{
+		AuthUsers:          "alice:secret1,bob:secret2",
+		AuthTokens:         "ci:token1",
+		AuthProxyHeader:    "X-Forwarded-User",
+		AuthTrustedProxies: "10.0.0.1,192.168.0.0/16,invalid",
+		AuthSecret:         "secret",
+		NamedConfigs: map[coreConfig.ConfigName]string{
+			"default":    "./default.json",
+			"welcome":    "./welcome.json",
+			"screenexec": "./screenexec.json",
+		},
+		Access: map[coreConfig.ConfigName]*coreConfig.Access{
+			"default":    {Public: false, Users: "*"},
+			"welcome":    {Public: true, Writers: "alice"},
+			"screenexec": {Users: "alice, ci", Writers: "ci"},
+		},
+	}
+}
+
+func serveAuth(e *echo.Echo, target string, setup func(r *http.Request)) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.RemoteAddr = "127.0.0.1:1234"
+	if setup != nil {
+		setup(req)
+	}
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func withBasicAuth(user, password string) func(r *http.Request) {
+	return func(r *http.Request) { r.SetBasicAuth(user, password) }
+}
+
+func withToken(token string) func(r *http.Request) {
+	return func(r *http.Request) { r.Header.Set(echo.HeaderAuthorization, "Bearer "+token) }
+}
+
+func TestAuthMiddleware_Disabled(t *testing.T) {
+	e := initAuthEcho(&coreConfig.CoreConfig{})
+
+	for _, target := range []string{"/", "/metrics", "/api/v1/configs/default", "/api/v1/ping/default/ping?hostname=test"} {
+		assert.Equal(t, http.StatusOK, serveAuth(e, target, nil).Code)
+	}
+}
+
+func TestAuthMiddleware_Authenticate(t *testing.T) {
+	e := initAuthEcho(initAuthCoreConfig())
+
+	for _, testcase := range []struct {
+		setup        func(r *http.Request)
+		expectedCode int
+		expectedUser string
+	}{
+		{setup: nil, expectedCode: http.StatusUnauthorized},
+		{setup: withBasicAuth("alice", "secret1"), expectedCode: http.StatusOK, expectedUser: "alice"},
+		{setup: withBasicAuth("alice", "secret2"), expectedCode: http.StatusUnauthorized},
+		{setup: withBasicAuth("unknown", "secret1"), expectedCode: http.StatusUnauthorized},
+		{setup: withToken("token1"), expectedCode: http.StatusOK, expectedUser: "ci"},
+		{setup: withToken("token2"), expectedCode: http.StatusUnauthorized},
+		{
+			setup:        func(r *http.Request) { r.Header.Set("X-Forwarded-User", "carol"); r.RemoteAddr = "10.0.0.1:1234" },
+			expectedCode: http.StatusOK, expectedUser: "carol",
+		},
+		{
+			setup:        func(r *http.Request) { r.Header.Set("X-Forwarded-User", "carol"); r.RemoteAddr = "192.168.1.1:1234" },
+			expectedCode: http.StatusOK, expectedUser: "carol",
+		},
+		{
+			setup:        func(r *http.Request) { r.Header.Set("X-Forwarded-User", "carol") }, // Untrusted proxy
+			expectedCode: http.StatusUnauthorized,
+		},
+	} {
+		res := serveAuth(e, "/api/v1/configs", testcase.setup)
+		assert.Equal(t, testcase.expectedCode, res.Code)
+		if res.Code == http.StatusOK {
+			assert.Equal(t, testcase.expectedUser, res.Body.String())
+		} else {
+			assert.Equal(t, `Basic realm="monitoror"`, res.Header().Get(echo.HeaderWWWAuthenticate))
+		}
+	}
+}
+
+func TestAuthMiddleware_Authenticate_WithoutTrustedProxies(t *testing.T) {
+	config := initAuthCoreConfig()
+	config.AuthTrustedProxies = ""
+	e := initAuthEcho(config)
+
+	for _, remoteAddr := range []string{"127.0.0.1:1234", "10.0.0.1:1234", "203.0.113.7:1234"} {
+		res := serveAuth(e, "/api/v1/configs", func(r *http.Request) {
+			r.Header.Set("X-Forwarded-User", "carol")
+			r.RemoteAddr = remoteAddr
+		})
+		assert.Equal(t, http.StatusUnauthorized, res.Code, remoteAddr)
+	}
+}
+
+func TestAuthMiddleware_Access(t *testing.T) {
+	e := initAuthEcho(initAuthCoreConfig())
+
+	for _, testcase := range []struct {
+		target       string
+		setup        func(r *http.Request)
+		expectedCode int
+	}{
+		// Public config
+		{target: "/api/v1/configs/welcome", expectedCode: http.StatusOK},
+		{target: "/api/v1/configs/WELCOME", expectedCode: http.StatusOK},
+		{target: "/api/v1/stream?config=welcome", expectedCode: http.StatusOK},
+		{target: "/api/v1/configs/welcome/hash", expectedCode: http.StatusOK},
+		{target: "/api/v1/configs/welcome", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusOK},
+		// Default access
+		{target: "/api/v1/configs/default", expectedCode: http.StatusUnauthorized},
+		{target: "/api/v1/stream", expectedCode: http.StatusUnauthorized},
+		{target: "/api/v1/stream", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusOK},
+		{target: "/api/v1/configs/unknown", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusOK},
+		// Restricted config
+		{target: "/api/v1/configs/screenexec", expectedCode: http.StatusUnauthorized},
+		{target: "/api/v1/configs/screenexec", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusForbidden},
+		{target: "/api/v1/stream?config=screenexec", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusForbidden},
+		{target: "/api/v1/configs/screenexec/hash", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusForbidden},
+		{target: "/api/v1/configs/screenexec", setup: withBasicAuth("alice", "secret1"), expectedCode: http.StatusOK},
+		{target: "/api/v1/configs/screenexec", setup: withToken("token1"), expectedCode: http.StatusOK},
+		// UI is public because welcome is public
+		{target: "/", expectedCode: http.StatusOK},
+		{target: "/api/v1/info", expectedCode: http.StatusOK},
+		{target: "/metrics", expectedCode: http.StatusUnauthorized},
+		{target: "/metrics", setup: withToken("token1"), expectedCode: http.StatusOK},
+	} {
+		res := serveAuth(e, testcase.target, testcase.setup)
+		assert.Equal(t, testcase.expectedCode, res.Code, testcase.target)
+	}
+}
+
+func TestAuthMiddleware_PrivateUI(t *testing.T) {
+	config := initAuthCoreConfig()
+	config.Access["welcome"].Public = false
+	e := initAuthEcho(config)
+
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(e, "/", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(e, "/api/v1/info", nil).Code)
+	assert.Equal(t, http.StatusOK, serveAuth(e, "/", withBasicAuth("bob", "secret2")).Code)
+}
+
+func TestAuthMiddleware_SignedTile(t *testing.T) {
+	e := initAuthEcho(initAuthCoreConfig())
+
+	signedURL, err := signature.SignURL("secret", "/api/v1/ping/default/ping?hostname=test")
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, serveAuth(e, signedURL, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(e, signedURL+"&hostname=other", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAuth(e, "/api/v1/ping/default/ping?hostname=test", nil).Code)
+	// Authenticated users need signature too, access to tiles follows access to configs
+	assert.Equal(t, http.StatusForbidden, serveAuth(e, "/api/v1/ping/default/ping?hostname=test", withBasicAuth("bob", "secret2")).Code)
+	assert.Equal(t, http.StatusOK, serveAuth(e, signedURL, withBasicAuth("bob", "secret2")).Code)
+}
+
+func TestAuthMiddleware_CanRead(t *testing.T) {
+	am := NewAuthMiddleware(initAuthCoreConfig())
+
+	for _, testcase := range []struct {
+		user       string
+		configName coreConfig.ConfigName
+		expected   bool
+	}{
+		{configName: "welcome", expected: true},
+		{configName: "default", expected: false},
+		{user: "bob", configName: "default", expected: true},
+		{user: "bob", configName: "screenexec", expected: false},
+		{user: "ci", configName: "screenexec", expected: true},
+	} {
+		ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/api/v1/scheduler/jobs", nil), httptest.NewRecorder())
+		if testcase.user != "" {
+			ctx.Set(AuthUserContextKey, testcase.user)
+		}
+		assert.Equal(t, testcase.expected, am.CanRead(ctx, testcase.configName), "%s %s", testcase.user, testcase.configName)
+	}
+
+	ctx := echo.New().NewContext(WithInternalRequest(httptest.NewRequest(http.MethodGet, "/api/v1/scheduler/jobs", nil)), httptest.NewRecorder())
+	assert.True(t, am.CanRead(ctx, "screenexec"))
+	assert.True(t, NewAuthMiddleware(&coreConfig.CoreConfig{}).CanRead(ctx, "screenexec"))
+}
+
+func TestAuthMiddleware_InternalRequest(t *testing.T) {
+	e := initAuthEcho(initAuthCoreConfig())
+
+	req := WithInternalRequest(httptest.NewRequest(http.MethodGet, "/api/v1/ping/default/ping?hostname=test", nil))
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+}
+
+func TestAuthMiddleware_WriteAccess(t *testing.T) {
+	e := initAuthEcho(initAuthCoreConfig())
+
+	for _, testcase := range []struct {
+		method       string
+		target       string
+		setup        func(r *http.Request)
+		expectedCode int
+	}{
+		// Public config can't be updated anonymously
+		{method: http.MethodPut, target: "/api/v1/configs/welcome", expectedCode: http.StatusUnauthorized},
+		{method: http.MethodGet, target: "/api/v1/configs/welcome/revisions", expectedCode: http.StatusUnauthorized},
+		{method: http.MethodPut, target: "/api/v1/configs/welcome", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusForbidden},
+		{method: http.MethodPut, target: "/api/v1/configs/welcome", setup: withBasicAuth("alice", "secret1"), expectedCode: http.StatusOK},
+		// Nobody can update config by default
+		{method: http.MethodPut, target: "/api/v1/configs/default", setup: withBasicAuth("alice", "secret1"), expectedCode: http.StatusForbidden},
+		// Restricted config
+		{method: http.MethodPut, target: "/api/v1/configs/screenexec", setup: withBasicAuth("alice", "secret1"), expectedCode: http.StatusForbidden},
+		{method: http.MethodPut, target: "/api/v1/configs/screenexec", setup: withToken("token1"), expectedCode: http.StatusOK},
+		{method: http.MethodGet, target: "/api/v1/configs/screenexec/revisions", setup: withBasicAuth("alice", "secret1"), expectedCode: http.StatusForbidden},
+		{method: http.MethodGet, target: "/api/v1/configs/screenexec/revisions", setup: withToken("token1"), expectedCode: http.StatusOK},
+		{method: http.MethodPost, target: "/api/v1/configs/screenexec/revisions/1/restore", setup: withToken("token1"), expectedCode: http.StatusOK},
+		// Validation needs a writer of any config
+		{method: http.MethodPost, target: "/api/v1/configs/validate", expectedCode: http.StatusUnauthorized},
+		{method: http.MethodPost, target: "/api/v1/configs/validate", setup: withBasicAuth("bob", "secret2"), expectedCode: http.StatusForbidden},
+		{method: http.MethodPost, target: "/api/v1/configs/validate", setup: withBasicAuth("alice", "secret1"), expectedCode: http.StatusOK},
+		{method: http.MethodPost, target: "/api/v1/configs/validate", setup: withToken("token1"), expectedCode: http.StatusOK},
+	} {
+		req := httptest.NewRequest(testcase.method, testcase.target, nil)
+		req.RemoteAddr = "127.0.0.1:1234"
+		if testcase.setup != nil {
+			testcase.setup(req)
+		}
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, req)
+		assert.Equal(t, testcase.expectedCode, res.Code, testcase.method+" "+testcase.target)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/metrics"
 
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/labstack/echo/v4"
@@ -81,7 +82,9 @@ func (cm *CacheMiddleware) DownstreamStoreMiddleware() echo.MiddlewareFunc {
 // ResponsesStore methods (implementation of cache.Store)
 //==============================================================================
 func (c *upstreamStore) Get(key string, value interface{}) error {
-	return c.store.Get(models.UpstreamStoreKeyPrefix+key[1:], value)
+	err := c.store.Get(models.UpstreamStoreKeyPrefix+key[1:], value)
+	metrics.ObserveCache(metrics.UpstreamCache, err == nil)
+	return err
 }
 
 func (c *upstreamStore) Set(key string, val interface{}, expires time.Duration) (err error) {
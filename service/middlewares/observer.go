@@ -0,0 +1,104 @@
+package middlewares
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+/*ObserverMiddleware for monitoror
+*
+* Observers are notified of every tile computed by monitorable usecases (notifier, metrics, ...).
+*
+* ObserverMiddleware is implemented as a decorator on the handler of each monitorable route, inside UpstreamCache.
+* So cached responses are not observed again. Errored tiles are rebuilt like in handlers/errors.go,
+* timeout errors are ignored because tile status is unknown.
+ */
+type (
+	// TileObserver is notified of every tile computed by monitorable usecases
+	// Note: ctx is only valid during the call, observers shouldn't keep it
+	TileObserver interface {
+		ObserveTile(ctx echo.Context, tile *models.Tile)
+	}
+
+	ObserverMiddleware struct {
+		observers []TileObserver
+	}
+
+	// Wrapper for copying response body sent by handler
+	bodyRecorder struct {
+		http.ResponseWriter
+		body *bytes.Buffer
+	}
+)
+
+// NewObserverMiddleware instantiate ObserverMiddleware with given observers
+func NewObserverMiddleware(observers ...TileObserver) *ObserverMiddleware {
+	return &ObserverMiddleware{observers: observers}
+}
+
+// AddObserver register observer. Should be called before starting server
+func (om *ObserverMiddleware) AddObserver(observer TileObserver) {
+	om.observers = append(om.observers, observer)
+}
+
+// ObserverHandler notify observers with tile returned by handler. (Decorator Handlers)
+func (om *ObserverMiddleware) ObserverHandler(handle echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		if len(om.observers) == 0 {
+			return handle(ctx)
+		}
+
+		writer := ctx.Response().Writer
+		recorder := &bodyRecorder{ResponseWriter: writer, body: &bytes.Buffer{}}
+		ctx.Response().Writer = recorder
+
+		err := handle(ctx)
+		ctx.Response().Writer = writer
+
+		var tile *models.Tile
+		if err != nil {
+			tile = erroredTile(err)
+		} else if ctx.Response().Status == http.StatusOK {
+			tile = &models.Tile{}
+			// Generators return list of tiles, they are not observed
+			if json.Unmarshal(recorder.body.Bytes(), tile) != nil {
+				tile = nil
+			}
+		}
+
+		if tile != nil && tile.Type != "" && tile.Status != "" {
+			for _, observer := range om.observers {
+				observer.ObserveTile(ctx, tile)
+			}
+		}
+
+		return err
+	}
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// erroredTile rebuild tile sent by HTTPErrorHandler. See handlers/errors.go
+func erroredTile(err error) *models.Tile {
+	me, ok := err.(*models.MonitororError)
+	if !ok || me.Tile == nil || me.Timeout() {
+		return nil
+	}
+
+	tile := *me.Tile
+	tile.Message = me.Error()
+	tile.Status = me.ErrorStatus
+	if tile.Status == "" {
+		tile.Status = models.FailedStatus
+	}
+
+	return &tile
+}
@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type testObserver struct {
+	tiles []*models.Tile
+}
+
+func (o *testObserver) ObserveTile(_ echo.Context, tile *models.Tile) {
+	o.tiles = append(o.tiles, tile)
+}
+
+func serveObserverHandler(middleware *ObserverMiddleware, handle echo.HandlerFunc) (*httptest.ResponseRecorder, error) {
+	e := echo.New()
+	req := httptest.NewRequest(echo.GET, "/api/v1/test/default/test?param=1", nil)
+	res := httptest.NewRecorder()
+	ctx := e.NewContext(req, res)
+
+	return res, middleware.ObserverHandler(handle)(ctx)
+}
+
+func TestObserverHandler_Tile(t *testing.T) {
+	observer := &testObserver{}
+	middleware := NewObserverMiddleware()
+	middleware.AddObserver(observer)
+
+	tile := models.NewTile("TEST")
+	tile.Status = models.SuccessStatus
+	tile.Label = "test"
+
+	res, err := serveObserverHandler(middleware, func(c echo.Context) error {
+		return c.JSON(http.StatusOK, tile)
+	})
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"label":"test"`)
+		if assert.Len(t, observer.tiles, 1) {
+			assert.Equal(t, tile, observer.tiles[0])
+		}
+	}
+}
+
+func TestObserverHandler_Error(t *testing.T) {
+	observer := &testObserver{}
+	middleware := NewObserverMiddleware(observer)
+
+	// Errored tile
+	_, err := serveObserverHandler(middleware, func(c echo.Context) error {
+		return &models.MonitororError{Err: errors.New("boom"), Tile: models.NewTile("TEST")}
+	})
+	assert.Error(t, err)
+	if assert.Len(t, observer.tiles, 1) {
+		assert.Equal(t, models.FailedStatus, observer.tiles[0].Status)
+		assert.Equal(t, "boom", observer.tiles[0].Message)
+	}
+
+	// Errored tile with status
+	_, err = serveObserverHandler(middleware, func(c echo.Context) error {
+		return &models.MonitororError{Err: errors.New("boom"), Tile: models.NewTile("TEST"), ErrorStatus: models.UnknownStatus}
+	})
+	assert.Error(t, err)
+	if assert.Len(t, observer.tiles, 2) {
+		assert.Equal(t, models.UnknownStatus, observer.tiles[1].Status)
+	}
+
+	// Timeout and errors without tile are ignored
+	_, err = serveObserverHandler(middleware, func(c echo.Context) error {
+		return &models.MonitororError{Err: context.DeadlineExceeded, Tile: models.NewTile("TEST")}
+	})
+	assert.Error(t, err)
+	_, err = serveObserverHandler(middleware, func(c echo.Context) error {
+		return models.ParamsError
+	})
+	assert.Error(t, err)
+	assert.Len(t, observer.tiles, 2)
+}
+
+func TestObserverHandler_NotATile(t *testing.T) {
+	observer := &testObserver{}
+	middleware := NewObserverMiddleware(observer)
+
+	// Generator response
+	_, err := serveObserverHandler(middleware, func(c echo.Context) error {
+		return c.JSON(http.StatusOK, []interface{}{map[string]string{"type": "TEST"}})
+	})
+	assert.NoError(t, err)
+
+	// Not OK
+	_, err = serveObserverHandler(middleware, func(c echo.Context) error {
+		return c.JSON(http.StatusBadRequest, models.NewTile("TEST"))
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, observer.tiles, 0)
+}
@@ -0,0 +1,198 @@
+//go:generate mockery -name Sink
+
+package notifier
+
+import (
+	"sync"
+	"time"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+type (
+	// Sink deliver status change outside of monitoror
+	Sink interface {
+		Send(event *Event) error
+	}
+
+	// Event describe a tile status change
+	Event struct {
+		URL            string                `json:"url"`
+		Type           coreModels.TileType   `json:"type"`
+		Label          string                `json:"label,omitempty"`
+		PreviousStatus coreModels.TileStatus `json:"previousStatus"`
+		Status         coreModels.TileStatus `json:"status"`
+		Message        string                `json:"message,omitempty"`
+		Author         *coreModels.Author    `json:"author,omitempty"`
+		BuildID        *string               `json:"buildId,omitempty"`
+		Time           time.Time             `json:"time"`
+	}
+
+	// Notifier observe tiles (see middlewares.ObserverMiddleware) and send status changes to its sinks
+	Notifier struct {
+		channels []*channel
+	}
+
+	// channel keep tile states of a sink, each sink has its own debounce
+	channel struct {
+		sync.Mutex
+
+		sink     Sink
+		debounce time.Duration
+		states   map[string]*tileState
+	}
+
+	tileState struct {
+		// status is the last stable status (notified or initial)
+		status coreModels.TileStatus
+
+		// pending is the change waiting for debounce
+		pending *Event
+		timer   *time.Timer
+	}
+)
+
+// transientStatuses are ignored while detecting changes, previous stable status is kept instead
+var transientStatuses = map[coreModels.TileStatus]bool{
+	coreModels.RunningStatus: true,
+	coreModels.QueuedStatus:  true,
+}
+
+// NewNotifier create a webhook sink for every notifier variant with URL
+func NewNotifier(notifierConfigs map[coreModels.VariantName]*coreConfig.Notifier) *Notifier {
+	notifier := &Notifier{}
+
+	for variantName, notifierConfig := range notifierConfigs {
+		if notifierConfig.URL == "" {
+			continue
+		}
+
+		sink, err := NewWebhookSink(notifierConfig)
+		if err != nil {
+			log.Errorf("unable to create %q notifier, %v", variantName, err)
+			continue
+		}
+
+		notifier.AddSink(sink, time.Millisecond*time.Duration(notifierConfig.Debounce))
+	}
+
+	return notifier
+}
+
+// AddSink register sink with its debounce
+func (n *Notifier) AddSink(sink Sink, debounce time.Duration) {
+	n.channels = append(n.channels, &channel{
+		sink:     sink,
+		debounce: debounce,
+		states:   make(map[string]*tileState),
+	})
+}
+
+// ObserveTile implements middlewares.TileObserver
+func (n *Notifier) ObserveTile(ctx echo.Context, tile *coreModels.Tile) {
+	if len(n.channels) == 0 {
+		return
+	}
+
+	url := ctx.Request().RequestURI
+	for _, c := range n.channels {
+		c.observe(url, tile)
+	}
+}
+
+func (c *channel) observe(url string, tile *coreModels.Tile) {
+	c.Lock()
+	defer c.Unlock()
+
+	state, exists := c.states[url]
+
+	if transientStatuses[tile.Status] {
+		// Use status of previous build as initial status (computed by cache.BuildCache)
+		if !exists && tile.Build != nil && tile.Build.PreviousStatus != "" && tile.Build.PreviousStatus != coreModels.UnknownStatus {
+			c.states[url] = &tileState{status: tile.Build.PreviousStatus}
+		}
+		return
+	}
+
+	// First time this tile is seen, nothing to compare with
+	if !exists {
+		c.states[url] = &tileState{status: tile.Status}
+		return
+	}
+
+	// Status is back to stable status, cancel pending change (flapping)
+	if tile.Status == state.status {
+		state.cancel()
+		return
+	}
+
+	// Same change is already waiting for debounce
+	if state.pending != nil && state.pending.Status == tile.Status {
+		return
+	}
+
+	state.cancel()
+	event := newEvent(url, state.status, tile)
+
+	if c.debounce <= 0 {
+		state.status = event.Status
+		go c.send(event)
+		return
+	}
+
+	state.pending = event
+	state.timer = time.AfterFunc(c.debounce, func() { c.flush(url, event) })
+}
+
+// flush send pending event if it's still pending after debounce
+func (c *channel) flush(url string, event *Event) {
+	c.Lock()
+	state, exists := c.states[url]
+	if !exists || state.pending != event {
+		c.Unlock()
+		return
+	}
+	state.status = event.Status
+	state.pending = nil
+	state.timer = nil
+	c.Unlock()
+
+	c.send(event)
+}
+
+func (c *channel) send(event *Event) {
+	if err := c.sink.Send(event); err != nil {
+		log.Warnf("unable to notify %s status change of %s, %v", event.Status, event.URL, err)
+	}
+}
+
+func (s *tileState) cancel() {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.pending = nil
+	s.timer = nil
+}
+
+func newEvent(url string, previousStatus coreModels.TileStatus, tile *coreModels.Tile) *Event {
+	event := &Event{
+		URL:            url,
+		Type:           tile.Type,
+		Label:          tile.Label,
+		PreviousStatus: previousStatus,
+		Status:         tile.Status,
+		Message:        tile.Message,
+		Time:           time.Now(),
+	}
+
+	if tile.Build != nil {
+		event.Author = tile.Build.Author
+		event.BuildID = tile.Build.ID
+	}
+
+	return event
+}
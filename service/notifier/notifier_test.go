@@ -0,0 +1,133 @@
+package notifier_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/notifier"
+	"github.com/monitoror/monitoror/service/notifier/mocks"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func initSinkMock() (*mocks.Sink, chan *notifier.Event) {
+	events := make(chan *notifier.Event, 10)
+	mockSink := new(mocks.Sink)
+	mockSink.On("Send", Anything).Run(func(args Arguments) {
+		events <- args.Get(0).(*notifier.Event)
+	}).Return(nil)
+	return mockSink, events
+}
+
+func observe(n *notifier.Notifier, url string, status coreModels.TileStatus) {
+	tile := coreModels.NewTile("TEST").WithBuild()
+	tile.Label = "test"
+	tile.Status = status
+	tile.Build.ID = new(string)
+	*tile.Build.ID = "42"
+	tile.Build.Author = &coreModels.Author{Name: "me"}
+	tile.Build.PreviousStatus = coreModels.SuccessStatus
+
+	ctx := echo.New().NewContext(httptest.NewRequest(echo.GET, url, nil), httptest.NewRecorder())
+	n.ObserveTile(ctx, tile)
+}
+
+func waitEvent(t *testing.T, events chan *notifier.Event) *notifier.Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		assert.FailNow(t, "timeout while waiting event")
+	}
+	return nil
+}
+
+func assertNoEvent(t *testing.T, events chan *notifier.Event, wait time.Duration) {
+	select {
+	case event := <-events:
+		assert.Failf(t, "unexpected event", "%+v", event)
+	case <-time.After(wait):
+	}
+}
+
+func TestNewNotifier(t *testing.T) {
+	n := notifier.NewNotifier(map[coreModels.VariantName]*coreConfig.Notifier{
+		"default": {URL: ""},
+		"broken":  {URL: "http://example.com", Template: "{{ .Label"},
+	})
+	assert.NotNil(t, n)
+
+	// No sink, nothing happen
+	observe(n, "/test/1", coreModels.SuccessStatus)
+	observe(n, "/test/1", coreModels.FailedStatus)
+}
+
+func TestNotifier_WithoutDebounce(t *testing.T) {
+	mockSink, events := initSinkMock()
+	n := &notifier.Notifier{}
+	n.AddSink(mockSink, 0)
+
+	// First status is used as reference
+	observe(n, "/test/1", coreModels.SuccessStatus)
+	observe(n, "/test/1", coreModels.SuccessStatus)
+	assertNoEvent(t, events, time.Millisecond*20)
+
+	// Running is ignored
+	observe(n, "/test/1", coreModels.RunningStatus)
+	assertNoEvent(t, events, time.Millisecond*20)
+
+	observe(n, "/test/1", coreModels.FailedStatus)
+	event := waitEvent(t, events)
+	assert.Equal(t, "/test/1", event.URL)
+	assert.Equal(t, coreModels.TileType("TEST"), event.Type)
+	assert.Equal(t, "test", event.Label)
+	assert.Equal(t, coreModels.SuccessStatus, event.PreviousStatus)
+	assert.Equal(t, coreModels.FailedStatus, event.Status)
+	assert.Equal(t, "me", event.Author.Name)
+	assert.Equal(t, "42", *event.BuildID)
+
+	// Other tiles have their own status
+	observe(n, "/test/2", coreModels.FailedStatus)
+	assertNoEvent(t, events, time.Millisecond*20)
+}
+
+func TestNotifier_InitialStatusFromPreviousBuild(t *testing.T) {
+	mockSink, events := initSinkMock()
+	n := &notifier.Notifier{}
+	n.AddSink(mockSink, 0)
+
+	observe(n, "/test/1", coreModels.RunningStatus)
+	observe(n, "/test/1", coreModels.FailedStatus)
+
+	event := waitEvent(t, events)
+	assert.Equal(t, coreModels.SuccessStatus, event.PreviousStatus)
+	assert.Equal(t, coreModels.FailedStatus, event.Status)
+}
+
+func TestNotifier_WithDebounce(t *testing.T) {
+	mockSink, events := initSinkMock()
+	n := &notifier.Notifier{}
+	n.AddSink(mockSink, time.Millisecond*50)
+
+	observe(n, "/test/1", coreModels.SuccessStatus)
+
+	// Flapping
+	observe(n, "/test/1", coreModels.FailedStatus)
+	observe(n, "/test/1", coreModels.SuccessStatus)
+	assertNoEvent(t, events, time.Millisecond*100)
+
+	// Stable
+	observe(n, "/test/1", coreModels.FailedStatus)
+	observe(n, "/test/1", coreModels.FailedStatus)
+	event := waitEvent(t, events)
+	assert.Equal(t, coreModels.SuccessStatus, event.PreviousStatus)
+	assert.Equal(t, coreModels.FailedStatus, event.Status)
+	assertNoEvent(t, events, time.Millisecond*100)
+
+	mockSink.AssertNumberOfCalls(t, "Send", 1)
+}
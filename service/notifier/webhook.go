@@ -0,0 +1,79 @@
+package notifier
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/pkg/templates"
+)
+
+// DefaultWebhookTemplate is the JSON payload sent when notifier doesn't define its own template
+const DefaultWebhookTemplate = `{
+  "url": {{ json .URL }},
+  "type": {{ json .Type }},
+  "label": {{ json .Label }},
+  "previousStatus": {{ json .PreviousStatus }},
+  "status": {{ json .Status }},
+  "message": {{ json .Message }},
+  "author": {{ json .Author }},
+  "buildId": {{ json .BuildID }},
+  "time": {{ json .Time }}
+}`
+
+type webhookSink struct {
+	url      string
+	template *template.Template
+	client   *http.Client
+}
+
+// NewWebhookSink create a Sink posting templated JSON payload on notifier URL
+func NewWebhookSink(notifierConfig *coreConfig.Notifier) (Sink, error) {
+	text := notifierConfig.Template
+	if text == "" {
+		text = DefaultWebhookTemplate
+	}
+
+	tmpl, err := templates.New("webhook").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template, %w", err)
+	}
+
+	client := &http.Client{
+		Timeout: time.Millisecond * time.Duration(notifierConfig.Timeout),
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: !notifierConfig.SSLVerify},
+		},
+	}
+
+	return &webhookSink{url: notifierConfig.URL, template: tmpl, client: client}, nil
+}
+
+func (s *webhookSink) Send(event *Event) error {
+	payload := &bytes.Buffer{}
+	if err := s.template.Execute(payload, event); err != nil {
+		return fmt.Errorf("unable to execute template, %w", err)
+	}
+	if !json.Valid(payload.Bytes()) {
+		return errors.New("template doesn't produce valid JSON")
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook respond with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
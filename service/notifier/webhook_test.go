@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookSink_Send(t *testing.T) {
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	sink, err := NewWebhookSink(&coreConfig.Notifier{URL: ts.URL, Timeout: 1000})
+	if assert.NoError(t, err) {
+		buildID := "42"
+		event := &Event{
+			URL:            "/api/v1/test/default/test",
+			Type:           "TEST",
+			Label:          `"label"`,
+			PreviousStatus: coreModels.SuccessStatus,
+			Status:         coreModels.FailedStatus,
+			Author:         &coreModels.Author{Name: "me"},
+			BuildID:        &buildID,
+			Time:           time.Now(),
+		}
+		assert.NoError(t, sink.Send(event))
+
+		var payload map[string]interface{}
+		if assert.NoError(t, json.Unmarshal(body, &payload)) {
+			assert.Equal(t, `"label"`, payload["label"])
+			assert.Equal(t, "TEST", payload["type"])
+			assert.Equal(t, "SUCCESS", payload["previousStatus"])
+			assert.Equal(t, "FAILURE", payload["status"])
+			assert.Equal(t, "42", payload["buildId"])
+			assert.Equal(t, map[string]interface{}{"name": "me"}, payload["author"])
+		}
+	}
+}
+
+func TestWebhookSink_Send_WithTemplate(t *testing.T) {
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	sink, err := NewWebhookSink(&coreConfig.Notifier{URL: ts.URL, Template: `{"text": "{{ .Label }} is now {{ .Status }}"}`})
+	if assert.NoError(t, err) {
+		assert.NoError(t, sink.Send(&Event{Label: "test", Status: coreModels.FailedStatus}))
+		assert.Equal(t, `{"text": "test is now FAILURE"}`, string(body))
+	}
+}
+
+func TestWebhookSink_Send_Error(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	// Invalid template
+	_, err := NewWebhookSink(&coreConfig.Notifier{URL: ts.URL, Template: "{{ .Label"})
+	assert.Error(t, err)
+
+	// Invalid JSON
+	sink, err := NewWebhookSink(&coreConfig.Notifier{URL: ts.URL, Template: "{{ .Label }}"})
+	if assert.NoError(t, err) {
+		assert.Error(t, sink.Send(&Event{Label: "test"}))
+	}
+
+	// Error status
+	sink, err = NewWebhookSink(&coreConfig.Notifier{URL: ts.URL})
+	if assert.NoError(t, err) {
+		assert.Error(t, sink.Send(&Event{Label: "test"}))
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/middlewares"
+)
+
+type (
+	// tileProvider execute monitorable routes on server side (without network) to retrieve tiles.
+	// Requests go through every echo middlewares, so they use (and fill) the same caches as UI requests
+	tileProvider struct {
+		handler http.Handler
+	}
+)
+
+func (tp *tileProvider) GetTile(url string) (*coreModels.Tile, error) {
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	// RequestURI is used by cache middleware to build cache keys
+	request.RequestURI = url
+	// Request is executed on server side, access to its config is already checked
+	request = middlewares.WithInternalRequest(request)
+
+	response := httptest.NewRecorder()
+	tp.handler.ServeHTTP(response, request)
+
+	if response.Code != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", response.Code)
+	}
+
+	tile := &coreModels.Tile{}
+	if err := json.Unmarshal(response.Body.Bytes(), tile); err != nil {
+		return nil, err
+	}
+
+	return tile, nil
+}
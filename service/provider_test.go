@@ -0,0 +1,48 @@
+package service
+
+import (
+	"net/http"
+	"testing"
+
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTileProvider_GetTile(t *testing.T) {
+	e := echo.New()
+	e.GET("/api/v1/test", func(ctx echo.Context) error {
+		tile := coreModels.NewTile("TEST")
+		tile.Label = ctx.QueryParam("label")
+		tile.Status = coreModels.SuccessStatus
+		return ctx.JSON(http.StatusOK, tile)
+	})
+	e.GET("/api/v1/error", func(ctx echo.Context) error {
+		return ctx.String(http.StatusInternalServerError, "boom")
+	})
+	e.GET("/api/v1/wrong", func(ctx echo.Context) error {
+		return ctx.String(http.StatusOK, "not a tile")
+	})
+
+	provider := &tileProvider{handler: e}
+
+	tile, err := provider.GetTile("/api/v1/test?label=test")
+	if assert.NoError(t, err) {
+		assert.Equal(t, coreModels.TileType("TEST"), tile.Type)
+		assert.Equal(t, "test", tile.Label)
+		assert.Equal(t, coreModels.SuccessStatus, tile.Status)
+	}
+
+	_, err = provider.GetTile("/api/v1/error")
+	assert.Error(t, err)
+
+	_, err = provider.GetTile("/api/v1/wrong")
+	assert.Error(t, err)
+
+	_, err = provider.GetTile("/api/v1/unknown")
+	assert.Error(t, err)
+
+	_, err = provider.GetTile("%zz")
+	assert.Error(t, err)
+}
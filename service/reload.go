@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/monitoror/monitoror/store"
+
+	"github.com/labstack/gommon/log"
+)
+
+type (
+	// swapHandler serve current echo server. Echo server is swapped on reload without closing listeners
+	swapHandler struct {
+		current atomic.Value
+	}
+)
+
+func newSwapHandler(handler http.Handler) *swapHandler {
+	h := &swapHandler{}
+	h.current.Store(&handler)
+	return h
+}
+
+func (h *swapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*h.current.Load().(*http.Handler)).ServeHTTP(w, r)
+}
+
+func (h *swapHandler) swap(handler http.Handler) {
+	h.current.Store(&handler)
+}
+
+// Reload build new echo server (middlewares, routes, monitorables) from store and swap it with current one.
+// In-flight requests are served by previous echo server. CacheStore should be shared between stores to keep caches.
+//
+// Note: address, port and TLS settings are only applied on restart
+func (s *Server) Reload(store *store.Store) (err error) {
+	// Init panic when server can't be built
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unable to reload server, %v", r)
+		}
+	}()
+
+	next := Init(store)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	previous := s.store.CoreConfig
+	if previous.Address != store.CoreConfig.Address || previous.Port != store.CoreConfig.Port ||
+		previous.TLSCertFile != store.CoreConfig.TLSCertFile || previous.TLSKeyFile != store.CoreConfig.TLSKeyFile ||
+		previous.TLSClientCAFile != store.CoreConfig.TLSClientCAFile || previous.HTTPRedirectPort != store.CoreConfig.HTTPRedirectPort {
+		log.Warnf("address, port and TLS settings changes need a restart to be applied")
+	}
+
+	// Swap scheduler
+	if s.store.Scheduler != nil {
+		s.store.Scheduler.Stop()
+	}
+	if s.httpServer != nil && store.Scheduler != nil {
+		store.Scheduler.Start()
+	}
+
+	// Swap config watcher
+	if s.ConfigUsecase != nil {
+		s.ConfigUsecase.StopWatching()
+	}
+	if s.httpServer != nil && next.ConfigUsecase != nil {
+		next.ConfigUsecase.StartWatching()
+	}
+
+	// Swap echo server
+	s.handler.swap(next.Echo)
+	s.Echo = next.Echo
+	s.CacheMiddleware = next.CacheMiddleware
+	s.ObserverMiddleware = next.ObserverMiddleware
+	s.ConfigUsecase = next.ConfigUsecase
+	s.store = store
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/registry"
+	"github.com/monitoror/monitoror/store"
+
+	"github.com/GeertJohan/go.rice/embedded"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func initReloadStore(coreConfig *config.CoreConfig, cacheStore cache.Store) *store.Store {
+	return &store.Store{
+		CoreConfig: coreConfig,
+		Registry:   registry.NewRegistry(),
+		CacheStore: cacheStore,
+	}
+}
+
+func serveInfo(s *Server) int {
+	res := httptest.NewRecorder()
+	s.handler.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/info", nil))
+	return res.Code
+}
+
+func TestServer_Reload(t *testing.T) {
+	cacheStore := cache.NewGoCacheStore(time.Minute, time.Second)
+	s := Init(initReloadStore(&config.CoreConfig{DisableUI: true}, cacheStore))
+	assert.Equal(t, http.StatusOK, serveInfo(s))
+
+	// Enable auth
+	newStore := initReloadStore(&config.CoreConfig{DisableUI: true, AuthUsers: "user:password", Port: 1234}, cacheStore)
+	if assert.NoError(t, s.Reload(newStore)) {
+		assert.Equal(t, http.StatusUnauthorized, serveInfo(s))
+		assert.Equal(t, newStore, s.store)
+	}
+
+	// Unable to build server (missing UI), previous server is kept
+	delete(embedded.EmbeddedBoxes, "../ui/dist")
+	assert.Error(t, s.Reload(initReloadStore(&config.CoreConfig{DisableUI: false}, cacheStore)))
+	assert.Equal(t, http.StatusUnauthorized, serveInfo(s))
+	assert.Equal(t, newStore, s.store)
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	s := Init(initReloadStore(&config.CoreConfig{DisableUI: true, Address: "127.0.0.1", Port: 0}, nil))
+
+	// Not started
+	assert.NoError(t, s.Shutdown(context.Background()))
+
+	s = Init(initReloadStore(&config.CoreConfig{DisableUI: true, Address: "127.0.0.1", Port: 0}, nil))
+	errs := make(chan error)
+	go func() { errs <- s.Start() }()
+
+	assert.Eventually(t, func() bool {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+		return s.httpServer != nil
+	}, time.Second, time.Millisecond*10)
+
+	assert.NoError(t, s.Shutdown(context.Background()))
+	assert.NoError(t, s.Shutdown(context.Background())) // Already shut down
+
+	select {
+	case err := <-errs:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		assert.Fail(t, "server not stopped")
+	}
+}
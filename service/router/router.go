@@ -21,35 +21,54 @@ type (
 	}
 
 	router struct {
-		apiVersion      *echo.Group
-		cacheMiddleware *middlewares.CacheMiddleware
+		apiVersion         *echo.Group
+		cacheMiddleware    *middlewares.CacheMiddleware
+		observerMiddleware *middlewares.ObserverMiddleware
+		authMiddleware     *middlewares.AuthMiddleware
 	}
 
 	group struct {
-		router *router
-		group  *echo.Group
+		router      *router
+		group       *echo.Group
+		variantName coreModels.VariantName
 	}
 )
 
-func NewMonitorableRouter(apiVersion *echo.Group, cacheMiddleware *middlewares.CacheMiddleware) MonitorableRouter {
-	return &router{apiVersion: apiVersion, cacheMiddleware: cacheMiddleware}
+func NewMonitorableRouter(apiVersion *echo.Group, cacheMiddleware *middlewares.CacheMiddleware, observerMiddleware *middlewares.ObserverMiddleware, authMiddleware *middlewares.AuthMiddleware) MonitorableRouter {
+	return &router{apiVersion: apiVersion, cacheMiddleware: cacheMiddleware, observerMiddleware: observerMiddleware, authMiddleware: authMiddleware}
 }
 
 func (r *router) Group(path string, variantName coreModels.VariantName) MonitorableRouterGroup {
-	return &group{router: r, group: r.apiVersion.Group(fmt.Sprintf(`%s/%s`, path, variantName))}
+	return &group{router: r, group: r.apiVersion.Group(fmt.Sprintf(`%s/%s`, path, variantName)), variantName: variantName}
 }
 
 func (g *group) GET(path string, handlerFunc echo.HandlerFunc, opts ...options.RouterOption) *echo.Route {
 	routerSettings := options.ApplyOptions(opts...)
 
 	handler := handlerFunc
+	if g.router.observerMiddleware != nil {
+		handler = g.withVariantName(g.router.observerMiddleware.ObserverHandler(handler))
+	}
 	if !routerSettings.NoCache {
 		if routerSettings.CustomCacheExpiration != nil {
-			handler = g.router.cacheMiddleware.UpstreamCacheHandlerWithExpiration(*routerSettings.CustomCacheExpiration, handlerFunc)
+			handler = g.router.cacheMiddleware.UpstreamCacheHandlerWithExpiration(*routerSettings.CustomCacheExpiration, handler)
 		} else {
-			handler = g.router.cacheMiddleware.UpstreamCacheHandler(handlerFunc)
+			handler = g.router.cacheMiddleware.UpstreamCacheHandler(handler)
 		}
 	}
 
-	return g.group.GET(path, handler, routerSettings.Middlewares...)
+	routeMiddlewares := routerSettings.Middlewares
+	if g.router.authMiddleware != nil {
+		routeMiddlewares = append([]echo.MiddlewareFunc{g.router.authMiddleware.TileMiddleware()}, routeMiddlewares...)
+	}
+
+	return g.group.GET(path, handler, routeMiddlewares...)
+}
+
+// withVariantName expose variant name of the route to observers
+func (g *group) withVariantName(handlerFunc echo.HandlerFunc) echo.HandlerFunc {
+	return func(ctx echo.Context) error {
+		ctx.Set(coreModels.VariantNameContextKey, g.variantName)
+		return handlerFunc(ctx)
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	coreConfig "github.com/monitoror/monitoror/config"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/service/middlewares"
 	"github.com/monitoror/monitoror/service/options"
@@ -18,7 +19,7 @@ func TestNewMonitorableRouter(t *testing.T) {
 	// Init
 	g := echo.New().Group("/api/v1")
 	cacheMiddleware := middlewares.NewCacheMiddleware(cache.NewGoCacheStore(time.Minute, time.Second), time.Minute, time.Minute)
-	monitorableRouter := NewMonitorableRouter(g, cacheMiddleware)
+	monitorableRouter := NewMonitorableRouter(g, cacheMiddleware, middlewares.NewObserverMiddleware(), middlewares.NewAuthMiddleware(&coreConfig.CoreConfig{}))
 	handler := func(context echo.Context) error { return nil }
 
 	routeGroup := monitorableRouter.Group("/test", coreModels.DefaultVariantName)
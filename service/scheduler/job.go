@@ -0,0 +1,126 @@
+package scheduler
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/stream"
+	coreConfig "github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+// MaxJitterRatio is the maximum part of interval randomly added on every reschedule.
+// It keeps jobs registered together from running in lockstep after their first run
+const MaxJitterRatio = 0.1
+
+type (
+	job struct {
+		sync.Mutex
+
+		tileProvider stream.TileProvider
+		timer        *time.Timer
+		stopped      bool
+
+		state JobState
+	}
+
+	// JobState expose scheduler queue for debugging
+	JobState struct {
+		URL          string                  `json:"url"`
+		Configs      []coreConfig.ConfigName `json:"configs"`
+		Interval     int64                   `json:"interval"` // In Millisecond
+		NextRun      time.Time               `json:"nextRun"`
+		Running      bool                    `json:"running"`
+		LastRun      *time.Time              `json:"lastRun,omitempty"`
+		LastDuration *int64                  `json:"lastDuration,omitempty"` // In Millisecond
+		LastStatus   coreModels.TileStatus   `json:"lastStatus,omitempty"`
+		LastError    string                  `json:"lastError,omitempty"`
+	}
+)
+
+func newJob(url string, configs []coreConfig.ConfigName, interval time.Duration, tileProvider stream.TileProvider) *job {
+	return &job{
+		tileProvider: tileProvider,
+		state: JobState{
+			URL:      url,
+			Configs:  configs,
+			Interval: interval.Milliseconds(),
+		},
+	}
+}
+
+func (j *job) start(delay time.Duration) {
+	j.Lock()
+	defer j.Unlock()
+
+	j.state.NextRun = time.Now().Add(delay)
+	j.timer = time.AfterFunc(delay, j.run)
+}
+
+func (j *job) stop() {
+	j.Lock()
+	defer j.Unlock()
+
+	j.stopped = true
+	if j.timer != nil {
+		j.timer.Stop()
+	}
+}
+
+func (j *job) update(configs []coreConfig.ConfigName, interval time.Duration) {
+	j.Lock()
+	defer j.Unlock()
+
+	j.state.Configs = configs
+	j.state.Interval = interval.Milliseconds()
+}
+
+func (j *job) run() {
+	j.Lock()
+	j.state.Running = true
+	url := j.state.URL
+	j.Unlock()
+
+	start := time.Now()
+	tile, err := j.tileProvider.GetTile(url)
+	duration := time.Since(start).Milliseconds()
+
+	j.Lock()
+	defer j.Unlock()
+
+	j.state.Running = false
+	j.state.LastRun = &start
+	j.state.LastDuration = &duration
+	j.state.LastStatus = ""
+	j.state.LastError = ""
+	if err != nil {
+		j.state.LastError = err.Error()
+	} else {
+		j.state.LastStatus = tile.Status
+	}
+
+	if j.stopped {
+		return
+	}
+
+	delay := withJitter(time.Millisecond * time.Duration(j.state.Interval))
+	j.state.NextRun = time.Now().Add(delay)
+	j.timer.Reset(delay)
+}
+
+func (j *job) getState() JobState {
+	j.Lock()
+	defer j.Unlock()
+
+	return j.state
+}
+
+// withJitter return interval increased by a random delay, up to MaxJitterRatio of interval
+func withJitter(interval time.Duration) time.Duration {
+	maxJitter := int64(float64(interval) * MaxJitterRatio)
+	if maxJitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Int63n(maxJitter))
+}
@@ -0,0 +1,194 @@
+package scheduler
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream"
+	coreConfig "github.com/monitoror/monitoror/config"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ConfigSyncInterval is the delay between two walks of named configs, used to follow config changes
+const ConfigSyncInterval = time.Minute
+
+type (
+	// Scheduler refresh tiles of named configs in background to keep caches hot, even without any UI
+	Scheduler struct {
+		coreConfig    *coreConfig.CoreConfig
+		configUsecase config.Usecase
+		tileProvider  stream.TileProvider
+
+		sync.Mutex
+		jobs    map[string]*job
+		started bool
+		done    chan struct{}
+	}
+)
+
+func NewScheduler(coreConfig *coreConfig.CoreConfig, configUsecase config.Usecase, tileProvider stream.TileProvider) *Scheduler {
+	return &Scheduler{
+		coreConfig:    coreConfig,
+		configUsecase: configUsecase,
+		tileProvider:  tileProvider,
+		jobs:          make(map[string]*job),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start walk named configs and schedule their tiles. Named configs are walked again every ConfigSyncInterval
+func (s *Scheduler) Start() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.started || !s.isEnabled() {
+		return
+	}
+	s.started = true
+	// Recreated on every start, Stop close it (scheduler is stopped / started on reload)
+	s.done = make(chan struct{})
+	done := s.done
+
+	go func() {
+		ticker := time.NewTicker(ConfigSyncInterval)
+		defer ticker.Stop()
+
+		for {
+			s.sync()
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop every scheduled jobs
+func (s *Scheduler) Stop() {
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.started {
+		return
+	}
+	s.started = false
+	close(s.done)
+
+	for url, j := range s.jobs {
+		j.stop()
+		delete(s.jobs, url)
+	}
+}
+
+// GetJobs return state of every scheduled jobs, sorted by next run
+func (s *Scheduler) GetJobs() []JobState {
+	s.Lock()
+	defer s.Unlock()
+
+	jobStates := []JobState{}
+	for _, j := range s.jobs {
+		jobStates = append(jobStates, j.getState())
+	}
+
+	sort.Slice(jobStates, func(i, j int) bool {
+		return jobStates[i].NextRun.Before(jobStates[j].NextRun)
+	})
+
+	return jobStates
+}
+
+func (s *Scheduler) isEnabled() bool {
+	for configName := range s.coreConfig.NamedConfigs {
+		if s.coreConfig.GetScheduler(configName).Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// sync load every enabled named configs and update jobs to match their tiles
+func (s *Scheduler) sync() {
+	intervals := make(map[string]time.Duration)
+	configs := make(map[string][]coreConfig.ConfigName)
+
+	for configName := range s.coreConfig.NamedConfigs {
+		settings := s.coreConfig.GetScheduler(configName)
+		if !settings.Enabled {
+			continue
+		}
+
+		interval := time.Millisecond * time.Duration(settings.Interval)
+		if interval <= 0 {
+			interval = time.Millisecond * time.Duration(coreConfig.DefaultScheduler.Interval)
+		}
+
+		configBag := s.loadConfig(configName)
+		if len(configBag.Errors) > 0 {
+			log.Warnf("scheduler: unable to load %q named config, config contains errors", configName)
+			continue
+		}
+
+		for _, url := range configBag.Config.GetTileURLs() {
+			// When tile is used by multiple configs, use the shortest interval
+			if current, ok := intervals[url]; !ok || interval < current {
+				intervals[url] = interval
+			}
+			configs[url] = append(configs[url], configName)
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.started {
+		return
+	}
+
+	// Remove outdated jobs
+	for url, j := range s.jobs {
+		if _, ok := intervals[url]; !ok {
+			j.stop()
+			delete(s.jobs, url)
+		}
+	}
+
+	// Add or update jobs
+	for url, interval := range intervals {
+		if j, ok := s.jobs[url]; ok {
+			j.update(configs[url], interval)
+			continue
+		}
+
+		j := newJob(url, configs[url], interval, s.tileProvider)
+		j.start(s.initialDelay())
+		s.jobs[url] = j
+	}
+}
+
+func (s *Scheduler) loadConfig(configName coreConfig.ConfigName) *configModels.ConfigBag {
+	configBag := s.configUsecase.GetConfig(&configModels.ConfigParams{Config: string(configName)})
+
+	if len(configBag.Errors) == 0 {
+		s.configUsecase.Verify(configBag)
+	}
+	if len(configBag.Errors) == 0 {
+		s.configUsecase.Hydrate(configBag)
+	}
+
+	return configBag
+}
+
+// initialDelay spread first runs like UI does, to avoid bursting every requests at the same time
+func (s *Scheduler) initialDelay() time.Duration {
+	if s.coreConfig.InitialMaxDelay <= 0 {
+		return 0
+	}
+	return time.Millisecond * time.Duration(rand.Intn(s.coreConfig.InitialMaxDelay))
+}
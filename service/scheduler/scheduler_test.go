@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	configMocks "github.com/monitoror/monitoror/api/config/mocks"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream/mocks"
+	coreConfig "github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func initCoreConfig(schedulers map[coreConfig.ConfigName]*coreConfig.Scheduler) *coreConfig.CoreConfig {
+	return &coreConfig.CoreConfig{
+		NamedConfigs: map[coreConfig.ConfigName]string{
+			"default": "./config.json",
+			"screen1": "./screen1.json",
+		},
+		Scheduler: schedulers,
+	}
+}
+
+func initConfigUsecaseMock(configBags map[string]*configModels.ConfigBag) *configMocks.Usecase {
+	mockConfigUsecase := new(configMocks.Usecase)
+	for configName, configBag := range configBags {
+		mockConfigUsecase.On("GetConfig", &configModels.ConfigParams{Config: configName}).Return(configBag)
+	}
+	mockConfigUsecase.On("Verify", Anything)
+	mockConfigUsecase.On("Hydrate", Anything)
+	return mockConfigUsecase
+}
+
+func newConfigBag(urls ...string) *configModels.ConfigBag {
+	config := &configModels.Config{}
+	for _, url := range urls {
+		config.Tiles = append(config.Tiles, configModels.TileConfig{Type: "TEST", URL: url})
+	}
+	return &configModels.ConfigBag{Config: config}
+}
+
+func newTile(status coreModels.TileStatus) *coreModels.Tile {
+	tile := coreModels.NewTile("TEST")
+	tile.Status = status
+	return tile
+}
+
+func TestScheduler_Start_Disabled(t *testing.T) {
+	mockConfigUsecase := new(configMocks.Usecase)
+	scheduler := NewScheduler(initCoreConfig(nil), mockConfigUsecase, new(mocks.TileProvider))
+
+	scheduler.Start()
+	assert.False(t, scheduler.started)
+	assert.Len(t, scheduler.GetJobs(), 0)
+	mockConfigUsecase.AssertNotCalled(t, "GetConfig", Anything)
+
+	// Stop on non-started scheduler do nothing
+	scheduler.Stop()
+}
+
+func TestScheduler_Restart(t *testing.T) {
+	mockConfigUsecase := initConfigUsecaseMock(map[string]*configModels.ConfigBag{
+		"default": newConfigBag("/test/1"),
+	})
+	mockTileProvider := new(mocks.TileProvider)
+	mockTileProvider.On("GetTile", "/test/1").Return(newTile(coreModels.SuccessStatus), nil)
+
+	conf := initCoreConfig(map[coreConfig.ConfigName]*coreConfig.Scheduler{
+		"default": {Enabled: true, Interval: 60000},
+		"screen1": {Enabled: false},
+	})
+	scheduler := NewScheduler(conf, mockConfigUsecase, mockTileProvider)
+
+	// Stopped scheduler can be started again, like on reload
+	for i := 0; i < 2; i++ {
+		scheduler.Start()
+		assert.Eventually(t, func() bool { return len(scheduler.GetJobs()) == 1 }, time.Second, time.Millisecond*10)
+
+		scheduler.Stop()
+		assert.False(t, scheduler.started)
+		assert.Len(t, scheduler.GetJobs(), 0)
+	}
+}
+
+func TestScheduler_Sync(t *testing.T) {
+	mockConfigUsecase := initConfigUsecaseMock(map[string]*configModels.ConfigBag{
+		"default": newConfigBag("/test/1", "/test/2"),
+		"screen1": newConfigBag("/test/2", "/test/3"),
+	})
+
+	mockTileProvider := new(mocks.TileProvider)
+	mockTileProvider.On("GetTile", "/test/1").Return(newTile(coreModels.SuccessStatus), nil)
+	mockTileProvider.On("GetTile", "/test/2").Return(nil, errors.New("boom"))
+	mockTileProvider.On("GetTile", "/test/3").Return(newTile(coreModels.FailedStatus), nil)
+
+	conf := initCoreConfig(map[coreConfig.ConfigName]*coreConfig.Scheduler{
+		"default": {Enabled: true, Interval: 60000},
+		"screen1": {Enabled: true, Interval: 1000},
+	})
+	scheduler := NewScheduler(conf, mockConfigUsecase, mockTileProvider)
+	scheduler.started = true
+	defer scheduler.Stop()
+
+	scheduler.sync()
+
+	// Wait first runs (no initial delay)
+	assert.Eventually(t, func() bool {
+		for _, jobState := range scheduler.GetJobs() {
+			if jobState.LastRun == nil {
+				return false
+			}
+		}
+		return true
+	}, time.Second, time.Millisecond*10)
+
+	jobStates := make(map[string]JobState)
+	for _, jobState := range scheduler.GetJobs() {
+		jobStates[jobState.URL] = jobState
+	}
+
+	if assert.Len(t, jobStates, 3) {
+		assert.Equal(t, int64(60000), jobStates["/test/1"].Interval)
+		assert.Equal(t, coreModels.SuccessStatus, jobStates["/test/1"].LastStatus)
+
+		assert.Equal(t, int64(1000), jobStates["/test/2"].Interval)
+		assert.ElementsMatch(t, []coreConfig.ConfigName{"default", "screen1"}, jobStates["/test/2"].Configs)
+		assert.Equal(t, "boom", jobStates["/test/2"].LastError)
+		assert.Empty(t, jobStates["/test/2"].LastStatus)
+
+		assert.Equal(t, int64(1000), jobStates["/test/3"].Interval)
+		assert.Equal(t, coreModels.FailedStatus, jobStates["/test/3"].LastStatus)
+		assert.True(t, jobStates["/test/3"].NextRun.After(*jobStates["/test/3"].LastRun))
+	}
+
+	// Disable screen1, its jobs are removed on next sync
+	conf.Scheduler["screen1"].Enabled = false
+	scheduler.sync()
+
+	jobStates = make(map[string]JobState)
+	for _, jobState := range scheduler.GetJobs() {
+		jobStates[jobState.URL] = jobState
+	}
+	if assert.Len(t, jobStates, 2) {
+		assert.Equal(t, int64(60000), jobStates["/test/2"].Interval)
+		assert.Equal(t, []coreConfig.ConfigName{"default"}, jobStates["/test/2"].Configs)
+	}
+}
+
+func TestScheduler_Sync_ConfigError(t *testing.T) {
+	configBag := &configModels.ConfigBag{}
+	configBag.AddErrors(configModels.ConfigError{ID: configModels.ConfigErrorUnableToParseConfig})
+
+	mockConfigUsecase := initConfigUsecaseMock(map[string]*configModels.ConfigBag{
+		"default": configBag,
+		"screen1": newConfigBag("/test/1"),
+	})
+
+	conf := initCoreConfig(map[coreConfig.ConfigName]*coreConfig.Scheduler{
+		"default": {Enabled: true, Interval: 0},
+		"screen1": {Enabled: false},
+	})
+	scheduler := NewScheduler(conf, mockConfigUsecase, new(mocks.TileProvider))
+	scheduler.started = true
+	defer scheduler.Stop()
+
+	scheduler.sync()
+
+	assert.Len(t, scheduler.GetJobs(), 0)
+	mockConfigUsecase.AssertNotCalled(t, "Verify", Anything)
+	mockConfigUsecase.AssertNotCalled(t, "GetConfig", &configModels.ConfigParams{Config: "screen1"})
+}
+
+func TestScheduler_InitialDelay(t *testing.T) {
+	scheduler := NewScheduler(&coreConfig.CoreConfig{InitialMaxDelay: 100}, nil, nil)
+	for i := 0; i < 100; i++ {
+		delay := scheduler.initialDelay()
+		assert.True(t, delay >= 0 && delay < time.Millisecond*100)
+	}
+
+	scheduler = NewScheduler(&coreConfig.CoreConfig{}, nil, nil)
+	assert.Equal(t, time.Duration(0), scheduler.initialDelay())
+}
+
+func TestWithJitter(t *testing.T) {
+	interval := time.Second * 10
+	for i := 0; i < 100; i++ {
+		delay := withJitter(interval)
+		assert.True(t, delay >= interval && delay < interval+time.Second)
+	}
+
+	assert.Equal(t, time.Duration(0), withJitter(0))
+}
@@ -1,12 +1,20 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/monitoror/monitoror/api/config"
 	"github.com/monitoror/monitoror/cli/debug"
+	"github.com/monitoror/monitoror/service/certificate"
 	"github.com/monitoror/monitoror/service/handlers"
+	"github.com/monitoror/monitoror/service/metrics"
 	"github.com/monitoror/monitoror/service/middlewares"
 	"github.com/monitoror/monitoror/store"
 
@@ -23,10 +31,33 @@ type (
 		// CacheMiddleware using CacheStore to return cached data
 		CacheMiddleware *middlewares.CacheMiddleware
 
+		// ObserverMiddleware notifying observers of every tile computed by monitorables
+		ObserverMiddleware *middlewares.ObserverMiddleware
+
+		// AuthMiddleware authenticate users and check their access to configs and tiles
+		AuthMiddleware *middlewares.AuthMiddleware
+
+		// ConfigUsecase used to load, verify and hydrate configs
+		ConfigUsecase config.Usecase
+
 		store *store.Store
+
+		// lock protect listeners and current echo server (swapped on reload)
+		lock sync.Mutex
+		// handler serve current echo server, used by listeners
+		handler *swapHandler
+		// httpServer serve handler, nil until server is started
+		httpServer *http.Server
+		// redirectServer redirect HTTP requests to HTTPS, nil when disabled
+		redirectServer *http.Server
+		// done is closed when shutdown is complete
+		done chan struct{}
 	}
 )
 
+// ShutdownTimeout is the maximum delay to drain in-flight requests on shutdown
+const ShutdownTimeout = 10 * time.Second
+
 var colorer = color.New()
 
 func init() {
@@ -37,6 +68,7 @@ func init() {
 func Init(store *store.Store) *Server {
 	s := &Server{
 		store: store,
+		done:  make(chan struct{}),
 	}
 
 	s.setupEchoServer()
@@ -45,11 +77,117 @@ func Init(store *store.Store) *Server {
 	InitUI(s)
 	InitApis(s)
 
+	s.handler = newSwapHandler(s.Echo)
+
 	return s
 }
 
+// Start listen on configured address and block until server is shut down
 func (s *Server) Start() error {
-	return s.Echo.Start(fmt.Sprintf("%s:%d", s.store.CoreConfig.Address, s.store.CoreConfig.Port))
+	s.lock.Lock()
+
+	// Scheduler is started with server only, it needs monitorables routes to refresh tiles
+	if s.store.Scheduler != nil {
+		s.store.Scheduler.Start()
+	}
+	// Named configs are watched to notify UI of changes
+	if s.ConfigUsecase != nil {
+		s.ConfigUsecase.StartWatching()
+	}
+
+	s.httpServer = &http.Server{
+		Addr:     fmt.Sprintf("%s:%d", s.store.CoreConfig.Address, s.store.CoreConfig.Port),
+		Handler:  s.handler,
+		ErrorLog: s.StdLogger,
+	}
+
+	var err error
+	if !s.store.CoreConfig.IsTLSEnabled() {
+		s.lock.Unlock()
+		err = s.httpServer.ListenAndServe()
+	} else {
+		var reloader *certificate.Reloader
+		reloader, err = certificate.NewReloader(s.store.CoreConfig.TLSCertFile, s.store.CoreConfig.TLSKeyFile, s.store.CoreConfig.TLSClientCAFile)
+		if err != nil {
+			s.lock.Unlock()
+			return err
+		}
+		s.httpServer.TLSConfig = reloader.TLSConfig()
+
+		if s.store.CoreConfig.HTTPRedirectPort != 0 {
+			s.redirectServer = &http.Server{
+				Addr:     fmt.Sprintf("%s:%d", s.store.CoreConfig.Address, s.store.CoreConfig.HTTPRedirectPort),
+				Handler:  redirectHandler(s.store.CoreConfig.Port),
+				ErrorLog: s.StdLogger,
+			}
+			go func(redirectServer *http.Server) {
+				if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					s.Logger.Errorf("unable to start HTTP redirect listener, %v", err)
+				}
+			}(s.redirectServer)
+		}
+
+		s.lock.Unlock()
+		err = s.httpServer.ListenAndServeTLS("", "") // Certificates are provided by reloader
+	}
+
+	// Wait the end of graceful shutdown
+	if err == http.ErrServerClosed {
+		<-s.done
+		return nil
+	}
+	return err
+}
+
+// Shutdown stop scheduler and listeners, in-flight requests are drained until ctx is done
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Already shut down
+	select {
+	case <-s.done:
+		return nil
+	default:
+	}
+	defer close(s.done)
+
+	if s.store.Scheduler != nil {
+		s.store.Scheduler.Stop()
+	}
+	if s.ConfigUsecase != nil {
+		s.ConfigUsecase.StopWatching()
+	}
+
+	if s.redirectServer != nil {
+		_ = s.redirectServer.Shutdown(ctx)
+	}
+
+	if s.httpServer == nil {
+		return nil
+	}
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		// Close remaining connections (like streams)
+		_ = s.httpServer.Close()
+		return err
+	}
+
+	return nil
+}
+
+// redirectHandler redirect every requests to HTTPS on given port
+func redirectHandler(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
 }
 
 func (s *Server) setupEchoServer() {
@@ -65,6 +203,9 @@ func (s *Server) setupEchoMiddleware() {
 	// Recover (don't panic 😎)
 	s.Use(echoMiddleware.Recover())
 
+	// Metrics
+	s.Use(metrics.Middleware())
+
 	// Log requests
 	if debug.IsEnabled() {
 		s.Use(echoMiddleware.LoggerWithConfig(echoMiddleware.LoggerConfig{
@@ -79,9 +220,16 @@ func (s *Server) setupEchoMiddleware() {
 	) // Used as Handler wrapper in routes
 	s.Use(s.CacheMiddleware.DownstreamStoreMiddleware())
 
+	// Observer
+	s.ObserverMiddleware = middlewares.NewObserverMiddleware() // Used as Handler wrapper in monitorable routes
+
 	// CORS
 	s.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
 		AllowOrigins: []string{"*"},
-		AllowMethods: []string{echo.GET, echo.POST},
+		AllowMethods: []string{echo.GET, echo.POST, echo.PUT},
 	}))
+
+	// Auth
+	s.AuthMiddleware = middlewares.NewAuthMiddleware(s.store.CoreConfig) // Also used as route middleware of monitorable routes
+	s.Use(s.AuthMiddleware.Middleware())
 }
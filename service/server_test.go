@@ -1,6 +1,8 @@
 package service
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/monitoror/monitoror/cli/debug"
@@ -54,3 +56,20 @@ func TestInit_Prod_WithRicebox(t *testing.T) {
 		Init(s)
 	})
 }
+
+func TestRedirectHandler(t *testing.T) {
+	for _, testcase := range []struct {
+		port     int
+		target   string
+		expected string
+	}{
+		{port: 8443, target: "http://monitoror.example.com:8080/api/v1/info?test=1", expected: "https://monitoror.example.com:8443/api/v1/info?test=1"},
+		{port: 443, target: "http://monitoror.example.com/", expected: "https://monitoror.example.com/"},
+	} {
+		res := httptest.NewRecorder()
+		redirectHandler(testcase.port).ServeHTTP(res, httptest.NewRequest(http.MethodGet, testcase.target, nil))
+
+		assert.Equal(t, http.StatusMovedPermanently, res.Code)
+		assert.Equal(t, testcase.expected, res.Header().Get("Location"))
+	}
+}
@@ -0,0 +1,44 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/pkg/diskcache"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+)
+
+const (
+	// CacheDefaultExpiration is used when items are stored with cache.DEFAULT expiration
+	CacheDefaultExpiration = time.Minute * 5
+	// CacheCleanupInterval is the delay between two purges of expired items (memory and disk backends)
+	CacheCleanupInterval = time.Second
+	// diskCacheCleanupInterval is longer, purge need to rewrite database file
+	diskCacheCleanupInterval = time.Minute
+)
+
+// NewCacheStore return cache.Store selected by CacheBackend
+func NewCacheStore(conf *coreConfig.CoreConfig) (cache.Store, error) {
+	switch conf.CacheBackend {
+	case "", coreConfig.CacheBackendMemory:
+		return cache.NewGoCacheStore(CacheDefaultExpiration, CacheCleanupInterval), nil
+	case coreConfig.CacheBackendDisk:
+		store, err := diskcache.NewDiskStore(conf.CacheDiskPath, CacheDefaultExpiration, diskCacheCleanupInterval)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open cache database %q, %v", conf.CacheDiskPath, err)
+		}
+		return store, nil
+	case coreConfig.CacheBackendRedis:
+		store := cache.NewRedisCache(conf.CacheRedisAddress, conf.CacheRedisPassword, CacheDefaultExpiration)
+		// Check connection on startup, redis store fail silently after
+		if err := store.Set("monitoror.cache.ping", true, time.Second); err != nil {
+			return nil, fmt.Errorf("unable to connect to redis %q, %v", conf.CacheRedisAddress, err)
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q, expected %q, %q or %q",
+			conf.CacheBackend, coreConfig.CacheBackendMemory, coreConfig.CacheBackendDisk, coreConfig.CacheBackendRedis)
+	}
+}
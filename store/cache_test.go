@@ -0,0 +1,76 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/pkg/diskcache"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCacheStore_Memory(t *testing.T) {
+	store, err := NewCacheStore(&coreConfig.CoreConfig{CacheBackend: coreConfig.CacheBackendMemory})
+	assert.NoError(t, err)
+	assert.IsType(t, &cache.GoCacheStore{}, store)
+}
+
+func TestNewCacheStore_Disk(t *testing.T) {
+	dir, err := ioutil.TempDir("", "store")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := NewCacheStore(&coreConfig.CoreConfig{
+		CacheBackend:  coreConfig.CacheBackendDisk,
+		CacheDiskPath: filepath.Join(dir, "monitoror.db"),
+	})
+	if assert.NoError(t, err) {
+		assert.IsType(t, &diskcache.DiskStore{}, store)
+		_ = store.(*diskcache.DiskStore).Close()
+	}
+
+	_, err = NewCacheStore(&coreConfig.CoreConfig{
+		CacheBackend:  coreConfig.CacheBackendDisk,
+		CacheDiskPath: filepath.Join(dir, "missing", "monitoror.db"),
+	})
+	assert.Error(t, err)
+}
+
+func TestNewCacheStore_Redis(t *testing.T) {
+	server, err := miniredis.Run()
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer server.Close()
+	server.RequireAuth("secret")
+
+	store, err := NewCacheStore(&coreConfig.CoreConfig{
+		CacheBackend:       coreConfig.CacheBackendRedis,
+		CacheRedisAddress:  server.Addr(),
+		CacheRedisPassword: "secret",
+	})
+	if assert.NoError(t, err) {
+		assert.NoError(t, store.Set("key", "value", time.Minute))
+
+		var value string
+		assert.NoError(t, store.Get("key", &value))
+		assert.Equal(t, "value", value)
+	}
+
+	_, err = NewCacheStore(&coreConfig.CoreConfig{
+		CacheBackend:      coreConfig.CacheBackendRedis,
+		CacheRedisAddress: server.Addr(),
+	})
+	assert.Error(t, err)
+}
+
+func TestNewCacheStore_Unknown(t *testing.T) {
+	_, err := NewCacheStore(&coreConfig.CoreConfig{CacheBackend: "memcached"})
+	assert.Error(t, err)
+}
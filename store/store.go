@@ -6,6 +6,7 @@ import (
 	coreConfig "github.com/monitoror/monitoror/config"
 	"github.com/monitoror/monitoror/registry"
 	"github.com/monitoror/monitoror/service/router"
+	"github.com/monitoror/monitoror/service/scheduler"
 )
 
 type (
@@ -22,5 +23,8 @@ type (
 
 		// MonitorableRouter helper wrapping echo Router monitorable
 		MonitorableRouter router.MonitorableRouter
+
+		// Scheduler refreshing tiles of named configs in background
+		Scheduler *scheduler.Scheduler
 	}
 )
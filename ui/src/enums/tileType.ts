@@ -2,8 +2,10 @@ export enum TileType {
   HttpStatus = 'HTTP-STATUS',
   HttpRaw = 'HTTP-RAW',
   HttpFormatted = 'HTTP-FORMATTED',
+  HttpLatency = 'HTTP-LATENCY',
   Ping = 'PING',
   Port = 'PORT',
+  TlsCertificate = 'TLS-CERTIFICATE',
   PingdomCheck = 'PINGDOM-CHECK',
   PingdomTransactionCheck = 'PINGDOM-TRANSACTION-CHECK',
   GitHubChecks = 'GITHUB-CHECKS',
@@ -18,7 +18,9 @@ import mostImportantStatus from '@/helpers/mostImportantStatus'
 import Config from '@/types/config'
 import ConfigBag from '@/types/configBag'
 import ConfigError from '@/types/configError'
+import ConfigHash from '@/types/configHash'
 import ConfigMetadata from '@/types/configMetadata'
+import ConfigPage from '@/types/configPage'
 import Info from '@/types/info'
 import TaskOptions from '@/types/taskOptions'
 import TileConfig from '@/types/tileConfig'
@@ -28,6 +30,7 @@ export const DEFAULT_CONFIG_NAME = 'default'
 const API_BASE_PATH = '/api/v1'
 const INFO_PATH = '/info'
 const CONFIGS_PATH = '/configs'
+const CONFIG_HASH_PATH = '/hash'
 const QUERY_PARAM_KEYS = {
   API_BASE_URL: 'apiBaseUrl',
   CONFIG: 'config',
@@ -37,9 +40,14 @@ const QUERY_PARAM_KEYS = {
 export interface RootState {
   appVersion: string | undefined,
   configVersion: string | undefined,
+  configHash: string | undefined,
+  configCommit: string | undefined,
   columns: number,
   zoom: number,
   tiles: TileConfig[],
+  pages: ConfigPage[],
+  pageIndex: number,
+  pageStartDate: Date,
   tilesState: { [key: string]: TileState },
   tasks: Task[],
   errors: ConfigError[],
@@ -49,13 +57,26 @@ export interface RootState {
   configList: ConfigMetadata[],
 }
 
+function setLayout(state: RootState, layout: { columns: number, zoom?: number, tiles: TileConfig[] }): void {
+  state.columns = layout.columns
+  if (layout.zoom !== undefined) {
+    state.zoom = layout.zoom
+  }
+  state.tiles = layout.tiles
+}
+
 const store: StoreOptions<RootState> = {
   state: {
     appVersion: undefined,
     configVersion: undefined,
+    configHash: undefined,
+    configCommit: undefined,
     columns: 4,
     zoom: 1,
     tiles: [],
+    pages: [],
+    pageIndex: 0,
+    pageStartDate: new Date(),
     tilesState: {},
     tasks: [],
     errors: [],
@@ -87,6 +108,9 @@ const store: StoreOptions<RootState> = {
 
       return `${getters.configProxyUrl}/${urlEncodedConfigParam}`
     },
+    configHashUrl(state, getters): string {
+      return getters.proxyfiedConfigUrl + CONFIG_HASH_PATH
+    },
     currentRoute(): Route | undefined {
       const queryHash = window.location.hash.replace(/^#/, '')
       let route
@@ -109,7 +133,13 @@ const store: StoreOptions<RootState> = {
     },
     tileStateKeys(state): string[] {
       const tileStateKeys: string[] = []
-      state.tiles.forEach((tile: TileConfig) => {
+
+      // Tiles of every pages are kept up to date
+      const tiles = state.pages.reduce((pagesTiles: TileConfig[], page: ConfigPage) => {
+        return pagesTiles.concat(page.tiles)
+      }, state.tiles)
+
+      tiles.forEach((tile: TileConfig) => {
         tileStateKeys.push(tile.stateKey)
 
         // Add group subTiles stateKeys
@@ -210,11 +240,27 @@ const store: StoreOptions<RootState> = {
     },
     setConfig(state, payload: Config): void {
       state.configVersion = payload.version
-      state.columns = payload.columns
-      if (payload.zoom !== undefined) {
-        state.zoom = payload.zoom
+      state.pages = payload.pages || []
+
+      if (state.pages.length === 0) {
+        setLayout(state, payload)
+        return
       }
-      state.tiles = payload.tiles
+
+      // Keep current page on reload, when it still exists
+      state.pageIndex = state.pageIndex % state.pages.length
+      setLayout(state, state.pages[state.pageIndex])
+    },
+    setPageIndex(state, payload: number): void {
+      state.pageIndex = payload
+      state.pageStartDate = new Date()
+      setLayout(state, state.pages[payload])
+    },
+    setConfigHash(state, payload: string | undefined): void {
+      state.configHash = payload
+    },
+    setConfigCommit(state, payload: string | undefined): void {
+      state.configCommit = payload
     },
     setConfigList(state, payload: ConfigMetadata[]): void {
       state.configList = payload
@@ -257,6 +303,23 @@ const store: StoreOptions<RootState> = {
           }
         })
     },
+    async checkConfigHash({commit, state, getters, dispatch}) {
+      return axios.get(getters.configHashUrl)
+        .then((response) => {
+          const configHash: ConfigHash = response.data
+
+          if (state.configHash === undefined) {
+            commit('setConfigHash', configHash.hash)
+            return
+          }
+
+          // Config changed on Core side, reload it without waiting the next fetch
+          if (configHash.hash !== state.configHash) {
+            commit('setConfigHash', configHash.hash)
+            return dispatch('fetchConfiguration')
+          }
+        })
+    },
     async fetchConfigList({commit, getters}) {
       return axios.get(getters.configProxyUrl)
         .then((response) => {
@@ -309,6 +372,23 @@ const store: StoreOptions<RootState> = {
         .then((response) => {
           const configBag: ConfigBag = response.data
 
+          if (configBag.hash !== undefined) {
+            commit('setConfigHash', configBag.hash)
+          }
+
+          // Check config changes at the pace of Core (only available for watched named configs)
+          if (configBag.hashPollInterval !== undefined) {
+            dispatch('addTask', {
+              id: 'checkConfigHash',
+              type: TaskType.Root,
+              executor: async () => {
+                await dispatch('checkConfigHash')
+              },
+              interval: configBag.hashPollInterval,
+            })
+          }
+          commit('setConfigCommit', configBag.commit)
+
           // Kill old refreshTile tasks
           state.tasks
             .filter((task) => task.type === TaskType.RefreshTile && !getters.tileStateKeys.includes(task.id))
@@ -320,12 +400,30 @@ const store: StoreOptions<RootState> = {
             commit('setErrors', [])
 
             if (configBag.config !== undefined) {
-              configBag.config.tiles = configBag.config.tiles.map((tile) => hydrateTile(tile))
+              if (configBag.config.pages !== undefined) {
+                configBag.config.pages.forEach((page) => {
+                  page.tiles = page.tiles.map((tile) => hydrateTile(tile))
+                })
+              } else {
+                configBag.config.tiles = configBag.config.tiles.map((tile) => hydrateTile(tile))
+              }
               commit('setConfig', configBag.config)
             }
           }
         })
     },
+    rotatePage({state, commit}) {
+      if (state.pages.length < 2) {
+        return
+      }
+
+      const page = state.pages[state.pageIndex]
+      if (now() - state.pageStartDate.getTime() < page.duration * 1000) {
+        return
+      }
+
+      commit('setPageIndex', (state.pageIndex + 1) % state.pages.length)
+    },
     createRefreshTileTask({dispatch}, {tile, groupTile}: { tile: TileConfig, groupTile?: TileConfig }) {
       dispatch('addTask', {
         id: tile.stateKey,
@@ -469,6 +567,16 @@ const store: StoreOptions<RootState> = {
         },
       })
 
+      // Display next page when duration of current one is over
+      dispatch('addTask', {
+        id: 'rotatePage',
+        type: TaskType.Root,
+        executor: async () => {
+          await dispatch('rotatePage')
+        },
+        interval: 1 * TaskInterval.Second,
+      })
+
       // Update "now" each second
       dispatch('addTask', {
         id: 'updateNow',
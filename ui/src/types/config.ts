@@ -1,3 +1,4 @@
+import ConfigPage from '@/types/configPage'
 import TileConfig from '@/types/tileConfig'
 
 type Config = {
@@ -5,6 +6,7 @@ type Config = {
   columns: number,
   zoom?: number,
   tiles: TileConfig[],
+  pages?: ConfigPage[],
 }
 
 export default Config
@@ -4,6 +4,9 @@ import ConfigError from '@/types/configError'
 type ConfigBag = {
   config?: Config,
   errors?: ConfigError[],
+  hash?: string,
+  hashPollInterval?: number,
+  commit?: string,
 }
 
 export default ConfigBag
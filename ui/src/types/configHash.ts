@@ -0,0 +1,7 @@
+type ConfigHash = {
+  config: string,
+  hash: string,
+  updatedAt: string,
+}
+
+export default ConfigHash
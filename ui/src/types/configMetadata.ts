@@ -1,6 +1,13 @@
 type ConfigMetadata = {
   name: string,
   uiUrl: string,
+  source?: string,
+  location?: string,
+  lastLoad?: string,
+  hash?: string,
+  tileCount?: number,
+  errorCount?: number,
+  tileTypes?: string[],
 }
 
 export default ConfigMetadata
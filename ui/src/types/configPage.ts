@@ -0,0 +1,10 @@
+import TileConfig from '@/types/tileConfig'
+
+type ConfigPage = {
+  columns: number,
+  zoom?: number,
+  tiles: TileConfig[],
+  duration: number,
+}
+
+export default ConfigPage